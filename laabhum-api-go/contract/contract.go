@@ -0,0 +1,102 @@
+// Package contract records the HTTP requests a consumer expects a provider to
+// serve.
+//
+// A consumer writes a Pact of interactions, each an example request and the
+// status and response shape it relies on. The provider's tests replay every
+// interaction against its own router, set up in the interaction's state, so
+// a renamed route, a changed method or a reshaped payload fails verification
+// instead of surfacing in production.
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Request is the request a consumer sends. Path, query values and body may
+// hold {name} placeholders filled in from the provider state.
+type Request struct {
+	Method string            `json:"method"`
+	Path   string            `json:"path"`
+	Query  map[string]string `json:"query,omitempty"`
+	Body   json.RawMessage   `json:"body,omitempty"`
+	Stream bool              `json:"stream,omitempty"` // The provider keeps the response open; only status and headers are checked
+}
+
+// Response is what the consumer relies on in the reply. Body is an example:
+// the provider must answer with every field it has, of the same JSON type,
+// but may send other fields and other values.
+type Response struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"` // Matched as prefixes, e.g. "text/event-stream"
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// Interaction is one request a consumer makes and the reply it expects
+type Interaction struct {
+	Description string   `json:"description"`
+	State       string   `json:"provider_state,omitempty"` // What the provider must hold before the request, e.g. "an order is pending"
+	Request     Request  `json:"request"`
+	Response    Response `json:"response"`
+}
+
+// Pact is every interaction one consumer has with one provider
+type Pact struct {
+	Consumer     string        `json:"consumer"`
+	Provider     string        `json:"provider"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// FileName is the name a pact is written under
+func (p Pact) FileName() string {
+	return p.Consumer + "-" + p.Provider + ".json"
+}
+
+// Write stores the pact in dir under its FileName
+func (p Pact) Write(dir string) (string, error) {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, p.FileName())
+	return path, os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Load reads a pact written by Write
+func Load(path string) (Pact, error) {
+	var p Pact
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return p, err
+	}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return p, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// LoadDir reads the pacts in dir that name provider
+func LoadDir(dir, provider string) ([]Pact, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	var pacts []Pact
+	for _, path := range paths {
+		p, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		if p.Provider == provider {
+			pacts = append(pacts, p)
+		}
+	}
+	return pacts, nil
+}
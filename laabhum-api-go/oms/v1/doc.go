@@ -0,0 +1,5 @@
+// Package omsv1 holds the generated messages, client and server of the OMS
+// gRPC API defined in proto/oms/v1/oms.proto.
+package omsv1
+
+//go:generate sh -c "cd ../.. && buf generate"
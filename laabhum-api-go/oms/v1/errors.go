@@ -0,0 +1,90 @@
+package omsv1
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Code returns the gRPC code matching an HTTP status
+func Code(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
+
+// HTTPStatus returns the HTTP status matching a gRPC code
+func HTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.Aborted, codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// NewError returns a status error carrying an Error detail, for failures the
+// HTTP API would answer with httpStatus
+func NewError(httpStatus int, message string) error {
+	st := status.New(Code(httpStatus), message)
+	if detailed, err := st.WithDetails(&Error{HttpStatus: int32(httpStatus), Message: message}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// ErrorFrom describes any error returned by an OMS call. Errors without an
+// Error detail, such as transport failures, are described by their gRPC code.
+func ErrorFrom(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return &Error{HttpStatus: http.StatusInternalServerError, Message: err.Error()}
+	}
+	for _, detail := range st.Details() {
+		if described, ok := detail.(*Error); ok {
+			return described
+		}
+	}
+	return &Error{HttpStatus: int32(HTTPStatus(st.Code())), Message: st.Message()}
+}
@@ -0,0 +1,4502 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.0
+// 	protoc        (unknown)
+// source: oms/v1/oms.proto
+
+package omsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Side int32
+
+const (
+	Side_SIDE_UNSPECIFIED Side = 0
+	Side_SIDE_BUY         Side = 1
+	Side_SIDE_SELL        Side = 2
+)
+
+// Enum value maps for Side.
+var (
+	Side_name = map[int32]string{
+		0: "SIDE_UNSPECIFIED",
+		1: "SIDE_BUY",
+		2: "SIDE_SELL",
+	}
+	Side_value = map[string]int32{
+		"SIDE_UNSPECIFIED": 0,
+		"SIDE_BUY":         1,
+		"SIDE_SELL":        2,
+	}
+)
+
+func (x Side) Enum() *Side {
+	p := new(Side)
+	*p = x
+	return p
+}
+
+func (x Side) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Side) Descriptor() protoreflect.EnumDescriptor {
+	return file_oms_v1_oms_proto_enumTypes[0].Descriptor()
+}
+
+func (Side) Type() protoreflect.EnumType {
+	return &file_oms_v1_oms_proto_enumTypes[0]
+}
+
+func (x Side) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Side.Descriptor instead.
+func (Side) EnumDescriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{0}
+}
+
+type OrderType int32
+
+const (
+	OrderType_ORDER_TYPE_UNSPECIFIED OrderType = 0
+	OrderType_ORDER_TYPE_LIMIT       OrderType = 1
+	OrderType_ORDER_TYPE_MARKET      OrderType = 2
+	OrderType_ORDER_TYPE_STOP        OrderType = 3
+	OrderType_ORDER_TYPE_CTC         OrderType = 4
+	OrderType_ORDER_TYPE_MULTI_LEG   OrderType = 5
+)
+
+// Enum value maps for OrderType.
+var (
+	OrderType_name = map[int32]string{
+		0: "ORDER_TYPE_UNSPECIFIED",
+		1: "ORDER_TYPE_LIMIT",
+		2: "ORDER_TYPE_MARKET",
+		3: "ORDER_TYPE_STOP",
+		4: "ORDER_TYPE_CTC",
+		5: "ORDER_TYPE_MULTI_LEG",
+	}
+	OrderType_value = map[string]int32{
+		"ORDER_TYPE_UNSPECIFIED": 0,
+		"ORDER_TYPE_LIMIT":       1,
+		"ORDER_TYPE_MARKET":      2,
+		"ORDER_TYPE_STOP":        3,
+		"ORDER_TYPE_CTC":         4,
+		"ORDER_TYPE_MULTI_LEG":   5,
+	}
+)
+
+func (x OrderType) Enum() *OrderType {
+	p := new(OrderType)
+	*p = x
+	return p
+}
+
+func (x OrderType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderType) Descriptor() protoreflect.EnumDescriptor {
+	return file_oms_v1_oms_proto_enumTypes[1].Descriptor()
+}
+
+func (OrderType) Type() protoreflect.EnumType {
+	return &file_oms_v1_oms_proto_enumTypes[1]
+}
+
+func (x OrderType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderType.Descriptor instead.
+func (OrderType) EnumDescriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{1}
+}
+
+type OrderStatus int32
+
+const (
+	OrderStatus_ORDER_STATUS_UNSPECIFIED OrderStatus = 0
+	OrderStatus_ORDER_STATUS_PENDING     OrderStatus = 1
+	OrderStatus_ORDER_STATUS_EXECUTED    OrderStatus = 2
+	OrderStatus_ORDER_STATUS_CANCELLED   OrderStatus = 3
+	OrderStatus_ORDER_STATUS_DELETED     OrderStatus = 4
+	OrderStatus_ORDER_STATUS_QUEUED      OrderStatus = 5
+	OrderStatus_ORDER_STATUS_REJECTED    OrderStatus = 6
+)
+
+// Enum value maps for OrderStatus.
+var (
+	OrderStatus_name = map[int32]string{
+		0: "ORDER_STATUS_UNSPECIFIED",
+		1: "ORDER_STATUS_PENDING",
+		2: "ORDER_STATUS_EXECUTED",
+		3: "ORDER_STATUS_CANCELLED",
+		4: "ORDER_STATUS_DELETED",
+		5: "ORDER_STATUS_QUEUED",
+		6: "ORDER_STATUS_REJECTED",
+	}
+	OrderStatus_value = map[string]int32{
+		"ORDER_STATUS_UNSPECIFIED": 0,
+		"ORDER_STATUS_PENDING":     1,
+		"ORDER_STATUS_EXECUTED":    2,
+		"ORDER_STATUS_CANCELLED":   3,
+		"ORDER_STATUS_DELETED":     4,
+		"ORDER_STATUS_QUEUED":      5,
+		"ORDER_STATUS_REJECTED":    6,
+	}
+)
+
+func (x OrderStatus) Enum() *OrderStatus {
+	p := new(OrderStatus)
+	*p = x
+	return p
+}
+
+func (x OrderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_oms_v1_oms_proto_enumTypes[2].Descriptor()
+}
+
+func (OrderStatus) Type() protoreflect.EnumType {
+	return &file_oms_v1_oms_proto_enumTypes[2]
+}
+
+func (x OrderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderStatus.Descriptor instead.
+func (OrderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{2}
+}
+
+type Product int32
+
+const (
+	Product_PRODUCT_UNSPECIFIED Product = 0
+	Product_PRODUCT_MIS         Product = 1
+	Product_PRODUCT_CNC         Product = 2
+	Product_PRODUCT_NRML        Product = 3
+)
+
+// Enum value maps for Product.
+var (
+	Product_name = map[int32]string{
+		0: "PRODUCT_UNSPECIFIED",
+		1: "PRODUCT_MIS",
+		2: "PRODUCT_CNC",
+		3: "PRODUCT_NRML",
+	}
+	Product_value = map[string]int32{
+		"PRODUCT_UNSPECIFIED": 0,
+		"PRODUCT_MIS":         1,
+		"PRODUCT_CNC":         2,
+		"PRODUCT_NRML":        3,
+	}
+)
+
+func (x Product) Enum() *Product {
+	p := new(Product)
+	*p = x
+	return p
+}
+
+func (x Product) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Product) Descriptor() protoreflect.EnumDescriptor {
+	return file_oms_v1_oms_proto_enumTypes[3].Descriptor()
+}
+
+func (Product) Type() protoreflect.EnumType {
+	return &file_oms_v1_oms_proto_enumTypes[3]
+}
+
+func (x Product) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Product.Descriptor instead.
+func (Product) EnumDescriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{3}
+}
+
+type Segment int32
+
+const (
+	Segment_SEGMENT_UNSPECIFIED Segment = 0
+	Segment_SEGMENT_EQ          Segment = 1
+	Segment_SEGMENT_FO          Segment = 2
+	Segment_SEGMENT_CDS         Segment = 3
+	Segment_SEGMENT_COM         Segment = 4
+)
+
+// Enum value maps for Segment.
+var (
+	Segment_name = map[int32]string{
+		0: "SEGMENT_UNSPECIFIED",
+		1: "SEGMENT_EQ",
+		2: "SEGMENT_FO",
+		3: "SEGMENT_CDS",
+		4: "SEGMENT_COM",
+	}
+	Segment_value = map[string]int32{
+		"SEGMENT_UNSPECIFIED": 0,
+		"SEGMENT_EQ":          1,
+		"SEGMENT_FO":          2,
+		"SEGMENT_CDS":         3,
+		"SEGMENT_COM":         4,
+	}
+)
+
+func (x Segment) Enum() *Segment {
+	p := new(Segment)
+	*p = x
+	return p
+}
+
+func (x Segment) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Segment) Descriptor() protoreflect.EnumDescriptor {
+	return file_oms_v1_oms_proto_enumTypes[4].Descriptor()
+}
+
+func (Segment) Type() protoreflect.EnumType {
+	return &file_oms_v1_oms_proto_enumTypes[4]
+}
+
+func (x Segment) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Segment.Descriptor instead.
+func (Segment) EnumDescriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{4}
+}
+
+// Error is attached to every failed call as a status detail. http_status is
+// the status the OMS HTTP API answers the same failure with.
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HttpStatus    int32                  `protobuf:"varint,1,opt,name=http_status,json=httpStatus,proto3" json:"http_status,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_oms_v1_oms_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Error) GetHttpStatus() int32 {
+	if x != nil {
+		return x.HttpStatus
+	}
+	return 0
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Contract holds the terms of a futures or options contract
+type Contract struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	InstrumentType string                 `protobuf:"bytes,1,opt,name=instrument_type,json=instrumentType,proto3" json:"instrument_type,omitempty"` // FUT, CE or PE
+	Underlying     string                 `protobuf:"bytes,2,opt,name=underlying,proto3" json:"underlying,omitempty"`
+	Expiry         string                 `protobuf:"bytes,3,opt,name=expiry,proto3" json:"expiry,omitempty"` // YYYY-MM-DD
+	Strike         float64                `protobuf:"fixed64,4,opt,name=strike,proto3" json:"strike,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Contract) Reset() {
+	*x = Contract{}
+	mi := &file_oms_v1_oms_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Contract) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Contract) ProtoMessage() {}
+
+func (x *Contract) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Contract.ProtoReflect.Descriptor instead.
+func (*Contract) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Contract) GetInstrumentType() string {
+	if x != nil {
+		return x.InstrumentType
+	}
+	return ""
+}
+
+func (x *Contract) GetUnderlying() string {
+	if x != nil {
+		return x.Underlying
+	}
+	return ""
+}
+
+func (x *Contract) GetExpiry() string {
+	if x != nil {
+		return x.Expiry
+	}
+	return ""
+}
+
+func (x *Contract) GetStrike() float64 {
+	if x != nil {
+		return x.Strike
+	}
+	return 0
+}
+
+type Order struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ParentId          string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Symbol            string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Quantity          int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price             float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Side              Side                   `protobuf:"varint,6,opt,name=side,proto3,enum=laabhum.oms.v1.Side" json:"side,omitempty"`
+	Type              OrderType              `protobuf:"varint,7,opt,name=type,proto3,enum=laabhum.oms.v1.OrderType" json:"type,omitempty"`
+	Status            OrderStatus            `protobuf:"varint,8,opt,name=status,proto3,enum=laabhum.oms.v1.OrderStatus" json:"status,omitempty"`
+	StopPrice         float64                `protobuf:"fixed64,9,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`
+	Strategy          string                 `protobuf:"bytes,10,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Product           Product                `protobuf:"varint,11,opt,name=product,proto3,enum=laabhum.oms.v1.Product" json:"product,omitempty"`
+	Segment           Segment                `protobuf:"varint,12,opt,name=segment,proto3,enum=laabhum.oms.v1.Segment" json:"segment,omitempty"`
+	Amo               bool                   `protobuf:"varint,13,opt,name=amo,proto3" json:"amo,omitempty"`
+	Contract          *Contract              `protobuf:"bytes,14,opt,name=contract,proto3" json:"contract,omitempty"`
+	RiskPercentage    float64                `protobuf:"fixed64,15,opt,name=risk_percentage,json=riskPercentage,proto3" json:"risk_percentage,omitempty"`
+	StopLossActivated bool                   `protobuf:"varint,16,opt,name=stop_loss_activated,json=stopLossActivated,proto3" json:"stop_loss_activated,omitempty"`
+	TakeProfit        float64                `protobuf:"fixed64,17,opt,name=take_profit,json=takeProfit,proto3" json:"take_profit,omitempty"`
+	CreatedAt         int64                  `protobuf:"varint,18,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix seconds
+	ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	ClientOrderId     string                 `protobuf:"bytes,20,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"` // Caller's own ID, unique per user within the dedupe window
+	LegIndex          int32                  `protobuf:"varint,21,opt,name=leg_index,json=legIndex,proto3" json:"leg_index,omitempty"`                 // Place of a multi-leg child among its parent's legs, from 1
+	UserId            string                 `protobuf:"bytes,22,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                        // Who placed the order, set by the OMS from the authenticated caller
+	AccountId         string                 `protobuf:"bytes,23,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`               // Account the order was placed for, set alongside user_id
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_oms_v1_oms_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Order) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Order) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Order) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Order) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Order) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Order) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_SIDE_UNSPECIFIED
+}
+
+func (x *Order) GetType() OrderType {
+	if x != nil {
+		return x.Type
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *Order) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *Order) GetStopPrice() float64 {
+	if x != nil {
+		return x.StopPrice
+	}
+	return 0
+}
+
+func (x *Order) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *Order) GetProduct() Product {
+	if x != nil {
+		return x.Product
+	}
+	return Product_PRODUCT_UNSPECIFIED
+}
+
+func (x *Order) GetSegment() Segment {
+	if x != nil {
+		return x.Segment
+	}
+	return Segment_SEGMENT_UNSPECIFIED
+}
+
+func (x *Order) GetAmo() bool {
+	if x != nil {
+		return x.Amo
+	}
+	return false
+}
+
+func (x *Order) GetContract() *Contract {
+	if x != nil {
+		return x.Contract
+	}
+	return nil
+}
+
+func (x *Order) GetRiskPercentage() float64 {
+	if x != nil {
+		return x.RiskPercentage
+	}
+	return 0
+}
+
+func (x *Order) GetStopLossActivated() bool {
+	if x != nil {
+		return x.StopLossActivated
+	}
+	return false
+}
+
+func (x *Order) GetTakeProfit() float64 {
+	if x != nil {
+		return x.TakeProfit
+	}
+	return 0
+}
+
+func (x *Order) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Order) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Order) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *Order) GetLegIndex() int32 {
+	if x != nil {
+		return x.LegIndex
+	}
+	return 0
+}
+
+func (x *Order) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Order) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type Position struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	EntryPrice    float64                `protobuf:"fixed64,5,opt,name=entry_price,json=entryPrice,proto3" json:"entry_price,omitempty"`
+	CurrentPrice  float64                `protobuf:"fixed64,6,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
+	StopLoss      float64                `protobuf:"fixed64,7,opt,name=stop_loss,json=stopLoss,proto3" json:"stop_loss,omitempty"`
+	TakeProfit    float64                `protobuf:"fixed64,8,opt,name=take_profit,json=takeProfit,proto3" json:"take_profit,omitempty"`
+	Strategy      string                 `protobuf:"bytes,9,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Product       Product                `protobuf:"varint,10,opt,name=product,proto3,enum=laabhum.oms.v1.Product" json:"product,omitempty"`
+	Contract      *Contract              `protobuf:"bytes,11,opt,name=contract,proto3" json:"contract,omitempty"`
+	OpenedAt      *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=opened_at,json=openedAt,proto3" json:"opened_at,omitempty"`
+	LastUpdatedAt *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=last_updated_at,json=lastUpdatedAt,proto3" json:"last_updated_at,omitempty"`
+	Status        string                 `protobuf:"bytes,14,opt,name=status,proto3" json:"status,omitempty"`
+	UserId        string                 `protobuf:"bytes,15,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`          // Who placed the order that opened the position
+	AccountId     string                 `protobuf:"bytes,16,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"` // Account the position is held in
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Position) Reset() {
+	*x = Position{}
+	mi := &file_oms_v1_oms_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Position) ProtoMessage() {}
+
+func (x *Position) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Position) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Position) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Position) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Position) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Position) GetEntryPrice() float64 {
+	if x != nil {
+		return x.EntryPrice
+	}
+	return 0
+}
+
+func (x *Position) GetCurrentPrice() float64 {
+	if x != nil {
+		return x.CurrentPrice
+	}
+	return 0
+}
+
+func (x *Position) GetStopLoss() float64 {
+	if x != nil {
+		return x.StopLoss
+	}
+	return 0
+}
+
+func (x *Position) GetTakeProfit() float64 {
+	if x != nil {
+		return x.TakeProfit
+	}
+	return 0
+}
+
+func (x *Position) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *Position) GetProduct() Product {
+	if x != nil {
+		return x.Product
+	}
+	return Product_PRODUCT_UNSPECIFIED
+}
+
+func (x *Position) GetContract() *Contract {
+	if x != nil {
+		return x.Contract
+	}
+	return nil
+}
+
+func (x *Position) GetOpenedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OpenedAt
+	}
+	return nil
+}
+
+func (x *Position) GetLastUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdatedAt
+	}
+	return nil
+}
+
+func (x *Position) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Position) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Position) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type Trade struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	TradeTime     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=trade_time,json=tradeTime,proto3" json:"trade_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Trade) Reset() {
+	*x = Trade{}
+	mi := &file_oms_v1_oms_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trade) ProtoMessage() {}
+
+func (x *Trade) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
+func (*Trade) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Trade) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Trade) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Trade) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Trade) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Trade) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Trade) GetTradeTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TradeTime
+	}
+	return nil
+}
+
+type Ack struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ack) Reset() {
+	*x = Ack{}
+	mi := &file_oms_v1_oms_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ack) ProtoMessage() {}
+
+func (x *Ack) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ack.ProtoReflect.Descriptor instead.
+func (*Ack) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Ack) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type OrderRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderRef) Reset() {
+	*x = OrderRef{}
+	mi := &file_oms_v1_oms_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderRef) ProtoMessage() {}
+
+func (x *OrderRef) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderRef.ProtoReflect.Descriptor instead.
+func (*OrderRef) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *OrderRef) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ParentRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParentId      string                 `protobuf:"bytes,1,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParentRef) Reset() {
+	*x = ParentRef{}
+	mi := &file_oms_v1_oms_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParentRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParentRef) ProtoMessage() {}
+
+func (x *ParentRef) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParentRef.ProtoReflect.Descriptor instead.
+func (*ParentRef) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ParentRef) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+type ChildRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParentId      string                 `protobuf:"bytes,1,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	ChildId       string                 `protobuf:"bytes,2,opt,name=child_id,json=childId,proto3" json:"child_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChildRef) Reset() {
+	*x = ChildRef{}
+	mi := &file_oms_v1_oms_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChildRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChildRef) ProtoMessage() {}
+
+func (x *ChildRef) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChildRef.ProtoReflect.Descriptor instead.
+func (*ChildRef) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ChildRef) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *ChildRef) GetChildId() string {
+	if x != nil {
+		return x.ChildId
+	}
+	return ""
+}
+
+// CreateOrderRequest places order once per idempotency_key, or per the
+// order's client_order_id when no key is given. A retry within the OMS's
+// dedupe window returns the original order; one with a different order fails
+// with 409.
+type CreateOrderRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Order          *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateOrderRequest) Reset() {
+	*x = CreateOrderRequest{}
+	mi := &file_oms_v1_oms_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateOrderRequest) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type OrderReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Order         *Order                 `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	Replayed      bool                   `protobuf:"varint,3,opt,name=replayed,proto3" json:"replayed,omitempty"` // The order was created by an earlier request with the same key
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderReply) Reset() {
+	*x = OrderReply{}
+	mi := &file_oms_v1_oms_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderReply) ProtoMessage() {}
+
+func (x *OrderReply) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderReply.ProtoReflect.Descriptor instead.
+func (*OrderReply) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OrderReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *OrderReply) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *OrderReply) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
+type GetOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParentId      string                 `protobuf:"bytes,1,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Status        OrderStatus            `protobuf:"varint,3,opt,name=status,proto3,enum=laabhum.oms.v1.OrderStatus" json:"status,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Only orders placed by this user
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersRequest) Reset() {
+	*x = GetOrdersRequest{}
+	mi := &file_oms_v1_oms_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersRequest) ProtoMessage() {}
+
+func (x *GetOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersRequest.ProtoReflect.Descriptor instead.
+func (*GetOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetOrdersRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *GetOrdersRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetOrdersRequest) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *GetOrdersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type OrderList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderList) Reset() {
+	*x = OrderList{}
+	mi := &file_oms_v1_oms_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderList) ProtoMessage() {}
+
+func (x *OrderList) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderList.ProtoReflect.Descriptor instead.
+func (*OrderList) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OrderList) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+type ExecuteOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteOrderRequest) Reset() {
+	*x = ExecuteOrderRequest{}
+	mi := &file_oms_v1_oms_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteOrderRequest) ProtoMessage() {}
+
+func (x *ExecuteOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteOrderRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteOrderRequest) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExecuteOrderRequest) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// ModifyOrderRequest amends order_id. Zero fields in changes keep their
+// current values; a non-empty parent_id requires the order to be its child.
+type ModifyOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParentId      string                 `protobuf:"bytes,1,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Changes       *Order                 `protobuf:"bytes,3,opt,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModifyOrderRequest) Reset() {
+	*x = ModifyOrderRequest{}
+	mi := &file_oms_v1_oms_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModifyOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifyOrderRequest) ProtoMessage() {}
+
+func (x *ModifyOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifyOrderRequest.ProtoReflect.Descriptor instead.
+func (*ModifyOrderRequest) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ModifyOrderRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *ModifyOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ModifyOrderRequest) GetChanges() *Order {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type SetStopLossRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParentId      string                 `protobuf:"bytes,1,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Active        bool                   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetStopLossRequest) Reset() {
+	*x = SetStopLossRequest{}
+	mi := &file_oms_v1_oms_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetStopLossRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStopLossRequest) ProtoMessage() {}
+
+func (x *SetStopLossRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStopLossRequest.ProtoReflect.Descriptor instead.
+func (*SetStopLossRequest) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetStopLossRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *SetStopLossRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *SetStopLossRequest) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type TimelineEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seq           uint64                 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	PositionId    string                 `protobuf:"bytes,4,opt,name=position_id,json=positionId,proto3" json:"position_id,omitempty"`
+	Action        string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	Previous      []byte                 `protobuf:"bytes,6,opt,name=previous,proto3" json:"previous,omitempty"` // JSON
+	Current       []byte                 `protobuf:"bytes,7,opt,name=current,proto3" json:"current,omitempty"`   // JSON
+	Actor         string                 `protobuf:"bytes,8,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason        string                 `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
+	RequestId     string                 `protobuf:"bytes,10,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimelineEntry) Reset() {
+	*x = TimelineEntry{}
+	mi := &file_oms_v1_oms_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimelineEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimelineEntry) ProtoMessage() {}
+
+func (x *TimelineEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimelineEntry.ProtoReflect.Descriptor instead.
+func (*TimelineEntry) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TimelineEntry) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *TimelineEntry) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *TimelineEntry) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetPositionId() string {
+	if x != nil {
+		return x.PositionId
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetPrevious() []byte {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+func (x *TimelineEntry) GetCurrent() []byte {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *TimelineEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type Timeline struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*TimelineEntry       `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Timeline) Reset() {
+	*x = Timeline{}
+	mi := &file_oms_v1_oms_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Timeline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Timeline) ProtoMessage() {}
+
+func (x *Timeline) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Timeline.ProtoReflect.Descriptor instead.
+func (*Timeline) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Timeline) GetEntries() []*TimelineEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type ScalperOrder struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Symbol         string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Quantity       int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price          float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	StopLoss       float64                `protobuf:"fixed64,5,opt,name=stop_loss,json=stopLoss,proto3" json:"stop_loss,omitempty"`
+	TakeProfit     float64                `protobuf:"fixed64,6,opt,name=take_profit,json=takeProfit,proto3" json:"take_profit,omitempty"`
+	RiskPercentage float64                `protobuf:"fixed64,7,opt,name=risk_percentage,json=riskPercentage,proto3" json:"risk_percentage,omitempty"`
+	CreatedAt      int64                  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ScalperOrder) Reset() {
+	*x = ScalperOrder{}
+	mi := &file_oms_v1_oms_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScalperOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScalperOrder) ProtoMessage() {}
+
+func (x *ScalperOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScalperOrder.ProtoReflect.Descriptor instead.
+func (*ScalperOrder) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ScalperOrder) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScalperOrder) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ScalperOrder) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ScalperOrder) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ScalperOrder) GetStopLoss() float64 {
+	if x != nil {
+		return x.StopLoss
+	}
+	return 0
+}
+
+func (x *ScalperOrder) GetTakeProfit() float64 {
+	if x != nil {
+		return x.TakeProfit
+	}
+	return 0
+}
+
+func (x *ScalperOrder) GetRiskPercentage() float64 {
+	if x != nil {
+		return x.RiskPercentage
+	}
+	return 0
+}
+
+func (x *ScalperOrder) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ScalperOrder) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ScalperOrderReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Order         *ScalperOrder          `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScalperOrderReply) Reset() {
+	*x = ScalperOrderReply{}
+	mi := &file_oms_v1_oms_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScalperOrderReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScalperOrderReply) ProtoMessage() {}
+
+func (x *ScalperOrderReply) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScalperOrderReply.ProtoReflect.Descriptor instead.
+func (*ScalperOrderReply) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ScalperOrderReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ScalperOrderReply) GetOrder() *ScalperOrder {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type CTCOrder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ParentId      string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	OrderType     string                 `protobuf:"bytes,6,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CTCOrder) Reset() {
+	*x = CTCOrder{}
+	mi := &file_oms_v1_oms_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CTCOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CTCOrder) ProtoMessage() {}
+
+func (x *CTCOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CTCOrder.ProtoReflect.Descriptor instead.
+func (*CTCOrder) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CTCOrder) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CTCOrder) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *CTCOrder) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *CTCOrder) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CTCOrder) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CTCOrder) GetOrderType() string {
+	if x != nil {
+		return x.OrderType
+	}
+	return ""
+}
+
+func (x *CTCOrder) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type CTCOrderReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Order         *CTCOrder              `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CTCOrderReply) Reset() {
+	*x = CTCOrderReply{}
+	mi := &file_oms_v1_oms_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CTCOrderReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CTCOrderReply) ProtoMessage() {}
+
+func (x *CTCOrderReply) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CTCOrderReply.ProtoReflect.Descriptor instead.
+func (*CTCOrderReply) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CTCOrderReply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CTCOrderReply) GetOrder() *CTCOrder {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type ExitAllTradesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExitAllTradesRequest) Reset() {
+	*x = ExitAllTradesRequest{}
+	mi := &file_oms_v1_oms_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExitAllTradesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExitAllTradesRequest) ProtoMessage() {}
+
+func (x *ExitAllTradesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExitAllTradesRequest.ProtoReflect.Descriptor instead.
+func (*ExitAllTradesRequest) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{22}
+}
+
+type TradeList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trades        []*Trade               `protobuf:"bytes,1,rep,name=trades,proto3" json:"trades,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TradeList) Reset() {
+	*x = TradeList{}
+	mi := &file_oms_v1_oms_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TradeList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradeList) ProtoMessage() {}
+
+func (x *TradeList) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TradeList.ProtoReflect.Descriptor instead.
+func (*TradeList) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TradeList) GetTrades() []*Trade {
+	if x != nil {
+		return x.Trades
+	}
+	return nil
+}
+
+// StrategyLeg is one leg of a multi-leg order, sized as quantity * ratio
+type StrategyLeg struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side          Side                   `protobuf:"varint,2,opt,name=side,proto3,enum=laabhum.oms.v1.Side" json:"side,omitempty"`
+	Ratio         int32                  `protobuf:"varint,3,opt,name=ratio,proto3" json:"ratio,omitempty"`
+	Type          OrderType              `protobuf:"varint,4,opt,name=type,proto3,enum=laabhum.oms.v1.OrderType" json:"type,omitempty"`
+	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StrategyLeg) Reset() {
+	*x = StrategyLeg{}
+	mi := &file_oms_v1_oms_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StrategyLeg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StrategyLeg) ProtoMessage() {}
+
+func (x *StrategyLeg) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StrategyLeg.ProtoReflect.Descriptor instead.
+func (*StrategyLeg) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StrategyLeg) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *StrategyLeg) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_SIDE_UNSPECIFIED
+}
+
+func (x *StrategyLeg) GetRatio() int32 {
+	if x != nil {
+		return x.Ratio
+	}
+	return 0
+}
+
+func (x *StrategyLeg) GetType() OrderType {
+	if x != nil {
+		return x.Type
+	}
+	return OrderType_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *StrategyLeg) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+type MultiLegOrder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Product       Product                `protobuf:"varint,3,opt,name=product,proto3,enum=laabhum.oms.v1.Product" json:"product,omitempty"`
+	Strategy      string                 `protobuf:"bytes,4,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Legs          []*StrategyLeg         `protobuf:"bytes,5,rep,name=legs,proto3" json:"legs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MultiLegOrder) Reset() {
+	*x = MultiLegOrder{}
+	mi := &file_oms_v1_oms_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultiLegOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiLegOrder) ProtoMessage() {}
+
+func (x *MultiLegOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiLegOrder.ProtoReflect.Descriptor instead.
+func (*MultiLegOrder) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *MultiLegOrder) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MultiLegOrder) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *MultiLegOrder) GetProduct() Product {
+	if x != nil {
+		return x.Product
+	}
+	return Product_PRODUCT_UNSPECIFIED
+}
+
+func (x *MultiLegOrder) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *MultiLegOrder) GetLegs() []*StrategyLeg {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
+}
+
+type LegPosition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"` // Positive long, negative short
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LegPosition) Reset() {
+	*x = LegPosition{}
+	mi := &file_oms_v1_oms_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LegPosition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LegPosition) ProtoMessage() {}
+
+func (x *LegPosition) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LegPosition.ProtoReflect.Descriptor instead.
+func (*LegPosition) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *LegPosition) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *LegPosition) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type MultiLegSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Parent        *Order                 `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	Legs          []*Order               `protobuf:"bytes,2,rep,name=legs,proto3" json:"legs,omitempty"`
+	NetPremium    float64                `protobuf:"fixed64,3,opt,name=net_premium,json=netPremium,proto3" json:"net_premium,omitempty"`
+	Position      []*LegPosition         `protobuf:"bytes,4,rep,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MultiLegSummary) Reset() {
+	*x = MultiLegSummary{}
+	mi := &file_oms_v1_oms_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultiLegSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiLegSummary) ProtoMessage() {}
+
+func (x *MultiLegSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiLegSummary.ProtoReflect.Descriptor instead.
+func (*MultiLegSummary) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *MultiLegSummary) GetParent() *Order {
+	if x != nil {
+		return x.Parent
+	}
+	return nil
+}
+
+func (x *MultiLegSummary) GetLegs() []*Order {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
+}
+
+func (x *MultiLegSummary) GetNetPremium() float64 {
+	if x != nil {
+		return x.NetPremium
+	}
+	return 0
+}
+
+func (x *MultiLegSummary) GetPosition() []*LegPosition {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+type SyncPositionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Only positions opened by this user's orders
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncPositionsRequest) Reset() {
+	*x = SyncPositionsRequest{}
+	mi := &file_oms_v1_oms_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncPositionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncPositionsRequest) ProtoMessage() {}
+
+func (x *SyncPositionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncPositionsRequest.ProtoReflect.Descriptor instead.
+func (*SyncPositionsRequest) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SyncPositionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type PositionRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PositionId    string                 `protobuf:"bytes,1,opt,name=position_id,json=positionId,proto3" json:"position_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PositionRef) Reset() {
+	*x = PositionRef{}
+	mi := &file_oms_v1_oms_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PositionRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PositionRef) ProtoMessage() {}
+
+func (x *PositionRef) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PositionRef.ProtoReflect.Descriptor instead.
+func (*PositionRef) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PositionRef) GetPositionId() string {
+	if x != nil {
+		return x.PositionId
+	}
+	return ""
+}
+
+type PositionList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Positions     []*Position            `protobuf:"bytes,1,rep,name=positions,proto3" json:"positions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PositionList) Reset() {
+	*x = PositionList{}
+	mi := &file_oms_v1_oms_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PositionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PositionList) ProtoMessage() {}
+
+func (x *PositionList) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PositionList.ProtoReflect.Descriptor instead.
+func (*PositionList) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PositionList) GetPositions() []*Position {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+type PositionConversion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PositionId    string                 `protobuf:"bytes,1,opt,name=position_id,json=positionId,proto3" json:"position_id,omitempty"`
+	FromProduct   Product                `protobuf:"varint,2,opt,name=from_product,json=fromProduct,proto3,enum=laabhum.oms.v1.Product" json:"from_product,omitempty"`
+	ToProduct     Product                `protobuf:"varint,3,opt,name=to_product,json=toProduct,proto3,enum=laabhum.oms.v1.Product" json:"to_product,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"` // 0 converts the whole position
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PositionConversion) Reset() {
+	*x = PositionConversion{}
+	mi := &file_oms_v1_oms_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PositionConversion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PositionConversion) ProtoMessage() {}
+
+func (x *PositionConversion) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PositionConversion.ProtoReflect.Descriptor instead.
+func (*PositionConversion) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PositionConversion) GetPositionId() string {
+	if x != nil {
+		return x.PositionId
+	}
+	return ""
+}
+
+func (x *PositionConversion) GetFromProduct() Product {
+	if x != nil {
+		return x.FromProduct
+	}
+	return Product_PRODUCT_UNSPECIFIED
+}
+
+func (x *PositionConversion) GetToProduct() Product {
+	if x != nil {
+		return x.ToProduct
+	}
+	return Product_PRODUCT_UNSPECIFIED
+}
+
+func (x *PositionConversion) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type SquareOffRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SquareOffRequest) Reset() {
+	*x = SquareOffRequest{}
+	mi := &file_oms_v1_oms_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SquareOffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SquareOffRequest) ProtoMessage() {}
+
+func (x *SquareOffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SquareOffRequest.ProtoReflect.Descriptor instead.
+func (*SquareOffRequest) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{32}
+}
+
+type SquareOffReport struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RanAt           *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=ran_at,json=ranAt,proto3" json:"ran_at,omitempty"`
+	CancelledOrders []string               `protobuf:"bytes,2,rep,name=cancelled_orders,json=cancelledOrders,proto3" json:"cancelled_orders,omitempty"`
+	ClosedPositions []string               `protobuf:"bytes,3,rep,name=closed_positions,json=closedPositions,proto3" json:"closed_positions,omitempty"`
+	Errors          []string               `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SquareOffReport) Reset() {
+	*x = SquareOffReport{}
+	mi := &file_oms_v1_oms_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SquareOffReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SquareOffReport) ProtoMessage() {}
+
+func (x *SquareOffReport) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SquareOffReport.ProtoReflect.Descriptor instead.
+func (*SquareOffReport) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SquareOffReport) GetRanAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RanAt
+	}
+	return nil
+}
+
+func (x *SquareOffReport) GetCancelledOrders() []string {
+	if x != nil {
+		return x.CancelledOrders
+	}
+	return nil
+}
+
+func (x *SquareOffReport) GetClosedPositions() []string {
+	if x != nil {
+		return x.ClosedPositions
+	}
+	return nil
+}
+
+func (x *SquareOffReport) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type HandleExpiriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        string                 `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"` // close (default) or flag
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HandleExpiriesRequest) Reset() {
+	*x = HandleExpiriesRequest{}
+	mi := &file_oms_v1_oms_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HandleExpiriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandleExpiriesRequest) ProtoMessage() {}
+
+func (x *HandleExpiriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandleExpiriesRequest.ProtoReflect.Descriptor instead.
+func (*HandleExpiriesRequest) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *HandleExpiriesRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+type ExpiryReport struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	RanAt            *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=ran_at,json=ranAt,proto3" json:"ran_at,omitempty"`
+	Action           string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	ClosedPositions  []string               `protobuf:"bytes,3,rep,name=closed_positions,json=closedPositions,proto3" json:"closed_positions,omitempty"`
+	FlaggedPositions []string               `protobuf:"bytes,4,rep,name=flagged_positions,json=flaggedPositions,proto3" json:"flagged_positions,omitempty"`
+	Errors           []string               `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ExpiryReport) Reset() {
+	*x = ExpiryReport{}
+	mi := &file_oms_v1_oms_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpiryReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpiryReport) ProtoMessage() {}
+
+func (x *ExpiryReport) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpiryReport.ProtoReflect.Descriptor instead.
+func (*ExpiryReport) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ExpiryReport) GetRanAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RanAt
+	}
+	return nil
+}
+
+func (x *ExpiryReport) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ExpiryReport) GetClosedPositions() []string {
+	if x != nil {
+		return x.ClosedPositions
+	}
+	return nil
+}
+
+func (x *ExpiryReport) GetFlaggedPositions() []string {
+	if x != nil {
+		return x.FlaggedPositions
+	}
+	return nil
+}
+
+func (x *ExpiryReport) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type InstrumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstrumentRequest) Reset() {
+	*x = InstrumentRequest{}
+	mi := &file_oms_v1_oms_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstrumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstrumentRequest) ProtoMessage() {}
+
+func (x *InstrumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstrumentRequest.ProtoReflect.Descriptor instead.
+func (*InstrumentRequest) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *InstrumentRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type Instrument struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Exchange       string                 `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Tradingsymbol  string                 `protobuf:"bytes,2,opt,name=tradingsymbol,proto3" json:"tradingsymbol,omitempty"`
+	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Segment        Segment                `protobuf:"varint,4,opt,name=segment,proto3,enum=laabhum.oms.v1.Segment" json:"segment,omitempty"`
+	InstrumentType string                 `protobuf:"bytes,5,opt,name=instrument_type,json=instrumentType,proto3" json:"instrument_type,omitempty"`
+	Underlying     string                 `protobuf:"bytes,6,opt,name=underlying,proto3" json:"underlying,omitempty"`
+	Expiry         string                 `protobuf:"bytes,7,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	Strike         float64                `protobuf:"fixed64,8,opt,name=strike,proto3" json:"strike,omitempty"`
+	TickSize       float64                `protobuf:"fixed64,9,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	LotSize        int32                  `protobuf:"varint,10,opt,name=lot_size,json=lotSize,proto3" json:"lot_size,omitempty"`
+	FreezeQuantity int32                  `protobuf:"varint,11,opt,name=freeze_quantity,json=freezeQuantity,proto3" json:"freeze_quantity,omitempty"`
+	LowerCircuit   float64                `protobuf:"fixed64,12,opt,name=lower_circuit,json=lowerCircuit,proto3" json:"lower_circuit,omitempty"`
+	UpperCircuit   float64                `protobuf:"fixed64,13,opt,name=upper_circuit,json=upperCircuit,proto3" json:"upper_circuit,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Instrument) Reset() {
+	*x = Instrument{}
+	mi := &file_oms_v1_oms_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Instrument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Instrument) ProtoMessage() {}
+
+func (x *Instrument) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Instrument.ProtoReflect.Descriptor instead.
+func (*Instrument) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Instrument) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *Instrument) GetTradingsymbol() string {
+	if x != nil {
+		return x.Tradingsymbol
+	}
+	return ""
+}
+
+func (x *Instrument) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Instrument) GetSegment() Segment {
+	if x != nil {
+		return x.Segment
+	}
+	return Segment_SEGMENT_UNSPECIFIED
+}
+
+func (x *Instrument) GetInstrumentType() string {
+	if x != nil {
+		return x.InstrumentType
+	}
+	return ""
+}
+
+func (x *Instrument) GetUnderlying() string {
+	if x != nil {
+		return x.Underlying
+	}
+	return ""
+}
+
+func (x *Instrument) GetExpiry() string {
+	if x != nil {
+		return x.Expiry
+	}
+	return ""
+}
+
+func (x *Instrument) GetStrike() float64 {
+	if x != nil {
+		return x.Strike
+	}
+	return 0
+}
+
+func (x *Instrument) GetTickSize() float64 {
+	if x != nil {
+		return x.TickSize
+	}
+	return 0
+}
+
+func (x *Instrument) GetLotSize() int32 {
+	if x != nil {
+		return x.LotSize
+	}
+	return 0
+}
+
+func (x *Instrument) GetFreezeQuantity() int32 {
+	if x != nil {
+		return x.FreezeQuantity
+	}
+	return 0
+}
+
+func (x *Instrument) GetLowerCircuit() float64 {
+	if x != nil {
+		return x.LowerCircuit
+	}
+	return 0
+}
+
+func (x *Instrument) GetUpperCircuit() float64 {
+	if x != nil {
+		return x.UpperCircuit
+	}
+	return 0
+}
+
+type ExpiriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Underlying    string                 `protobuf:"bytes,1,opt,name=underlying,proto3" json:"underlying,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpiriesRequest) Reset() {
+	*x = ExpiriesRequest{}
+	mi := &file_oms_v1_oms_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpiriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpiriesRequest) ProtoMessage() {}
+
+func (x *ExpiriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpiriesRequest.ProtoReflect.Descriptor instead.
+func (*ExpiriesRequest) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ExpiriesRequest) GetUnderlying() string {
+	if x != nil {
+		return x.Underlying
+	}
+	return ""
+}
+
+type ExpiryList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Expiries      []string               `protobuf:"bytes,1,rep,name=expiries,proto3" json:"expiries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpiryList) Reset() {
+	*x = ExpiryList{}
+	mi := &file_oms_v1_oms_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpiryList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpiryList) ProtoMessage() {}
+
+func (x *ExpiryList) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpiryList.ProtoReflect.Descriptor instead.
+func (*ExpiryList) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ExpiryList) GetExpiries() []string {
+	if x != nil {
+		return x.Expiries
+	}
+	return nil
+}
+
+type OptionChainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Underlying    string                 `protobuf:"bytes,1,opt,name=underlying,proto3" json:"underlying,omitempty"`
+	Expiry        string                 `protobuf:"bytes,2,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OptionChainRequest) Reset() {
+	*x = OptionChainRequest{}
+	mi := &file_oms_v1_oms_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptionChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptionChainRequest) ProtoMessage() {}
+
+func (x *OptionChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptionChainRequest.ProtoReflect.Descriptor instead.
+func (*OptionChainRequest) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *OptionChainRequest) GetUnderlying() string {
+	if x != nil {
+		return x.Underlying
+	}
+	return ""
+}
+
+func (x *OptionChainRequest) GetExpiry() string {
+	if x != nil {
+		return x.Expiry
+	}
+	return ""
+}
+
+type OptionChainRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Strike        float64                `protobuf:"fixed64,1,opt,name=strike,proto3" json:"strike,omitempty"`
+	Call          *Instrument            `protobuf:"bytes,2,opt,name=call,proto3" json:"call,omitempty"`
+	Put           *Instrument            `protobuf:"bytes,3,opt,name=put,proto3" json:"put,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OptionChainRow) Reset() {
+	*x = OptionChainRow{}
+	mi := &file_oms_v1_oms_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptionChainRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptionChainRow) ProtoMessage() {}
+
+func (x *OptionChainRow) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptionChainRow.ProtoReflect.Descriptor instead.
+func (*OptionChainRow) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *OptionChainRow) GetStrike() float64 {
+	if x != nil {
+		return x.Strike
+	}
+	return 0
+}
+
+func (x *OptionChainRow) GetCall() *Instrument {
+	if x != nil {
+		return x.Call
+	}
+	return nil
+}
+
+func (x *OptionChainRow) GetPut() *Instrument {
+	if x != nil {
+		return x.Put
+	}
+	return nil
+}
+
+type OptionChain struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Underlying    string                 `protobuf:"bytes,1,opt,name=underlying,proto3" json:"underlying,omitempty"`
+	Expiry        string                 `protobuf:"bytes,2,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	Rows          []*OptionChainRow      `protobuf:"bytes,3,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OptionChain) Reset() {
+	*x = OptionChain{}
+	mi := &file_oms_v1_oms_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptionChain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptionChain) ProtoMessage() {}
+
+func (x *OptionChain) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptionChain.ProtoReflect.Descriptor instead.
+func (*OptionChain) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *OptionChain) GetUnderlying() string {
+	if x != nil {
+		return x.Underlying
+	}
+	return ""
+}
+
+func (x *OptionChain) GetExpiry() string {
+	if x != nil {
+		return x.Expiry
+	}
+	return ""
+}
+
+func (x *OptionChain) GetRows() []*OptionChainRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type MarketStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Segment       Segment                `protobuf:"varint,1,opt,name=segment,proto3,enum=laabhum.oms.v1.Segment" json:"segment,omitempty"` // EQ when unspecified
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarketStatusRequest) Reset() {
+	*x = MarketStatusRequest{}
+	mi := &file_oms_v1_oms_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarketStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketStatusRequest) ProtoMessage() {}
+
+func (x *MarketStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketStatusRequest.ProtoReflect.Descriptor instead.
+func (*MarketStatusRequest) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *MarketStatusRequest) GetSegment() Segment {
+	if x != nil {
+		return x.Segment
+	}
+	return Segment_SEGMENT_UNSPECIFIED
+}
+
+type MarketStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Segment       Segment                `protobuf:"varint,1,opt,name=segment,proto3,enum=laabhum.oms.v1.Segment" json:"segment,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	TradingDay    bool                   `protobuf:"varint,3,opt,name=trading_day,json=tradingDay,proto3" json:"trading_day,omitempty"`
+	Phase         string                 `protobuf:"bytes,4,opt,name=phase,proto3" json:"phase,omitempty"`
+	NextOpen      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=next_open,json=nextOpen,proto3" json:"next_open,omitempty"`
+	Holiday       string                 `protobuf:"bytes,6,opt,name=holiday,proto3" json:"holiday,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarketStatus) Reset() {
+	*x = MarketStatus{}
+	mi := &file_oms_v1_oms_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarketStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketStatus) ProtoMessage() {}
+
+func (x *MarketStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketStatus.ProtoReflect.Descriptor instead.
+func (*MarketStatus) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *MarketStatus) GetSegment() Segment {
+	if x != nil {
+		return x.Segment
+	}
+	return Segment_SEGMENT_UNSPECIFIED
+}
+
+func (x *MarketStatus) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *MarketStatus) GetTradingDay() bool {
+	if x != nil {
+		return x.TradingDay
+	}
+	return false
+}
+
+func (x *MarketStatus) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *MarketStatus) GetNextOpen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextOpen
+	}
+	return nil
+}
+
+func (x *MarketStatus) GetHoliday() string {
+	if x != nil {
+		return x.Holiday
+	}
+	return ""
+}
+
+type HolidaysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HolidaysRequest) Reset() {
+	*x = HolidaysRequest{}
+	mi := &file_oms_v1_oms_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HolidaysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HolidaysRequest) ProtoMessage() {}
+
+func (x *HolidaysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HolidaysRequest.ProtoReflect.Descriptor instead.
+func (*HolidaysRequest) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{45}
+}
+
+type Holiday struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"` // YYYY-MM-DD
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Segments      []Segment              `protobuf:"varint,3,rep,packed,name=segments,proto3,enum=laabhum.oms.v1.Segment" json:"segments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Holiday) Reset() {
+	*x = Holiday{}
+	mi := &file_oms_v1_oms_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Holiday) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Holiday) ProtoMessage() {}
+
+func (x *Holiday) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Holiday.ProtoReflect.Descriptor instead.
+func (*Holiday) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *Holiday) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *Holiday) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Holiday) GetSegments() []Segment {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+type HolidayList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Holidays      []*Holiday             `protobuf:"bytes,1,rep,name=holidays,proto3" json:"holidays,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HolidayList) Reset() {
+	*x = HolidayList{}
+	mi := &file_oms_v1_oms_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HolidayList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HolidayList) ProtoMessage() {}
+
+func (x *HolidayList) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HolidayList.ProtoReflect.Descriptor instead.
+func (*HolidayList) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *HolidayList) GetHolidays() []*Holiday {
+	if x != nil {
+		return x.Holidays
+	}
+	return nil
+}
+
+type Tick struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Price         float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	Volume        int32                  `protobuf:"varint,3,opt,name=volume,proto3" json:"volume,omitempty"`
+	Volatility    float64                `protobuf:"fixed64,4,opt,name=volatility,proto3" json:"volatility,omitempty"`
+	Trend         string                 `protobuf:"bytes,5,opt,name=trend,proto3" json:"trend,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tick) Reset() {
+	*x = Tick{}
+	mi := &file_oms_v1_oms_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tick) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tick) ProtoMessage() {}
+
+func (x *Tick) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tick.ProtoReflect.Descriptor instead.
+func (*Tick) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *Tick) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Tick) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Tick) GetVolume() int32 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *Tick) GetVolatility() float64 {
+	if x != nil {
+		return x.Volatility
+	}
+	return 0
+}
+
+func (x *Tick) GetTrend() string {
+	if x != nil {
+		return x.Trend
+	}
+	return ""
+}
+
+func (x *Tick) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type StreamEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Resume after this sequence; 0 follows new events only
+	Since         uint64 `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	mi := &file_oms_v1_oms_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsRequest) ProtoMessage() {}
+
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *StreamEventsRequest) GetSince() uint64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+type Event struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Sequence  uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Type      string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Data      []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"` // JSON
+	// gap is set on the event sent instead of ones lost since the request's since
+	Gap           bool `protobuf:"varint,5,opt,name=gap,proto3" json:"gap,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_oms_v1_oms_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_v1_oms_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_oms_v1_oms_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *Event) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Event) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Event) GetGap() bool {
+	if x != nil {
+		return x.Gap
+	}
+	return false
+}
+
+var File_oms_v1_oms_proto protoreflect.FileDescriptor
+
+var file_oms_v1_oms_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x6f, 0x6d, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x6d, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e,
+	0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x42, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b,
+	0x68, 0x74, 0x74, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x68, 0x74, 0x74, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x08, 0x43, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69,
+	0x6e, 0x73, 0x74, 0x72, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a,
+	0x0a, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x6c, 0x79, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x6c, 0x79, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a,
+	0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6b, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6b, 0x65, 0x22, 0xc6, 0x06,
+	0x0a, 0x05, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1a, 0x0a, 0x08,
+	0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x28,
+	0x0a, 0x04, 0x73, 0x69, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6c,
+	0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69,
+	0x64, 0x65, 0x52, 0x04, 0x73, 0x69, 0x64, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d,
+	0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x33, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75,
+	0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x74, 0x6f, 0x70, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x09, 0x73, 0x74, 0x6f, 0x70, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x31, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x64, 0x75,
+	0x63, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68,
+	0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x31, 0x0a, 0x07, 0x73, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x6c, 0x61,
+	0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x61, 0x6d, 0x6f, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x61, 0x6d, 0x6f, 0x12,
+	0x34, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x52, 0x08, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x69, 0x73, 0x6b, 0x5f, 0x70, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e,
+	0x72, 0x69, 0x73, 0x6b, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x2e,
+	0x0a, 0x13, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x6c, 0x6f, 0x73, 0x73, 0x5f, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x61, 0x74, 0x65, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x73, 0x74, 0x6f,
+	0x70, 0x4c, 0x6f, 0x73, 0x73, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1f,
+	0x0a, 0x0b, 0x74, 0x61, 0x6b, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x18, 0x11, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0a, 0x74, 0x61, 0x6b, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x12, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39,
+	0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x14, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x65, 0x67, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x15,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6c, 0x65, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x22, 0xbf, 0x04, 0x0a, 0x08, 0x50, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x50, 0x72,
+	0x69, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x74, 0x6f, 0x70,
+	0x5f, 0x6c, 0x6f, 0x73, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x73, 0x74, 0x6f,
+	0x70, 0x4c, 0x6f, 0x73, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x6b, 0x65, 0x5f, 0x70, 0x72,
+	0x6f, 0x66, 0x69, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x74, 0x61, 0x6b, 0x65,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65,
+	0x67, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65,
+	0x67, 0x79, 0x12, 0x31, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x07, 0x70, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x34, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63,
+	0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75,
+	0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63,
+	0x74, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x37, 0x0a, 0x09, 0x6f,
+	0x70, 0x65, 0x6e, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x6e,
+	0x65, 0x64, 0x41, 0x74, 0x12, 0x42, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x22, 0xb7, 0x01, 0x0a, 0x05, 0x54, 0x72, 0x61,
+	0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x64, 0x65,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x72, 0x61, 0x64, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x22, 0x1f, 0x0a, 0x03, 0x41, 0x63, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0x25, 0x0a, 0x08, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x66, 0x12,
+	0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x28, 0x0a, 0x09, 0x50, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x49, 0x64, 0x22, 0x42, 0x0a, 0x08, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x66,
+	0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x19, 0x0a,
+	0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x22, 0x6a, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b,
+	0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x69,
+	0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x4b, 0x65, 0x79, 0x22, 0x6f, 0x0a, 0x0a, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2b, 0x0a, 0x05,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x61,
+	0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x64, 0x22, 0x95, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12,
+	0x33, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1b, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x3a, 0x0a,
+	0x09, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x06, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x61, 0x61,
+	0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x52, 0x06, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x22, 0x42, 0x0a, 0x13, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x2b, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x22, 0x7d, 0x0a,
+	0x12, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64,
+	0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x07, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c,
+	0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x64, 0x0a, 0x12,
+	0x53, 0x65, 0x74, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12,
+	0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x22, 0xb2, 0x02, 0x0a, 0x0d, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x63,
+	0x74, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x43, 0x0a, 0x08, 0x54, 0x69, 0x6d, 0x65, 0x6c,
+	0x69, 0x6e, 0x65, 0x12, 0x37, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f,
+	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0xa9, 0x02, 0x0a,
+	0x0c, 0x53, 0x63, 0x61, 0x6c, 0x70, 0x65, 0x72, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x74, 0x6f, 0x70, 0x5f,
+	0x6c, 0x6f, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x73, 0x74, 0x6f, 0x70,
+	0x4c, 0x6f, 0x73, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x6b, 0x65, 0x5f, 0x70, 0x72, 0x6f,
+	0x66, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x74, 0x61, 0x6b, 0x65, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x69, 0x73, 0x6b, 0x5f, 0x70, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e,
+	0x72, 0x69, 0x73, 0x6b, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a,
+	0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x61, 0x0a, 0x11, 0x53, 0x63, 0x61, 0x6c,
+	0x70, 0x65, 0x72, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d,
+	0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x61, 0x6c, 0x70, 0x65, 0x72, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x22, 0xbf, 0x01, 0x0a, 0x08,
+	0x43, 0x54, 0x43, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1a, 0x0a,
+	0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x59, 0x0a,
+	0x0d, 0x43, 0x54, 0x43, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75,
+	0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x54, 0x43, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x22, 0x16, 0x0a, 0x14, 0x45, 0x78, 0x69, 0x74,
+	0x41, 0x6c, 0x6c, 0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x3a, 0x0a, 0x09, 0x54, 0x72, 0x61, 0x64, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2d, 0x0a,
+	0x06, 0x74, 0x72, 0x61, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x72, 0x61, 0x64, 0x65, 0x52, 0x06, 0x74, 0x72, 0x61, 0x64, 0x65, 0x73, 0x22, 0xaa, 0x01, 0x0a,
+	0x0b, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x4c, 0x65, 0x67, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x69, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x64, 0x65, 0x52, 0x04, 0x73, 0x69, 0x64, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x22, 0xbf, 0x01, 0x0a, 0x0d, 0x4d, 0x75,
+	0x6c, 0x74, 0x69, 0x4c, 0x65, 0x67, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x31, 0x0a, 0x07, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x6c,
+	0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x2f, 0x0a, 0x04, 0x6c, 0x65,
+	0x67, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68,
+	0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65,
+	0x67, 0x79, 0x4c, 0x65, 0x67, 0x52, 0x04, 0x6c, 0x65, 0x67, 0x73, 0x22, 0x41, 0x0a, 0x0b, 0x4c,
+	0x65, 0x67, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0xc5,
+	0x01, 0x0a, 0x0f, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x4c, 0x65, 0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x79, 0x12, 0x2d, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x12, 0x29, 0x0a, 0x04, 0x6c, 0x65, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x04, 0x6c, 0x65, 0x67, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6e, 0x65, 0x74, 0x5f, 0x70, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0a, 0x6e, 0x65, 0x74, 0x50, 0x72, 0x65, 0x6d, 0x69, 0x75, 0x6d, 0x12, 0x37, 0x0a,
+	0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x65, 0x67, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2f, 0x0a, 0x14, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x2e, 0x0a, 0x0b, 0x50, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x66, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x46, 0x0a, 0x0c, 0x50, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x61, 0x61,
+	0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22,
+	0xc5, 0x01, 0x0a, 0x12, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x3a, 0x0a, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x5f,
+	0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e,
+	0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x50, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x12, 0x36, 0x0a, 0x0a, 0x74, 0x6f, 0x5f, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75,
+	0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x52, 0x09, 0x74, 0x6f, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x71,
+	0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x71,
+	0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0x12, 0x0a, 0x10, 0x53, 0x71, 0x75, 0x61, 0x72,
+	0x65, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xb2, 0x01, 0x0a, 0x0f,
+	0x53, 0x71, 0x75, 0x61, 0x72, 0x65, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12,
+	0x31, 0x0a, 0x06, 0x72, 0x61, 0x6e, 0x5f, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x72, 0x61, 0x6e,
+	0x41, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64, 0x5f,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x12, 0x29, 0x0a,
+	0x10, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x50,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73,
+	0x22, 0x2f, 0x0a, 0x15, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x45, 0x78, 0x70, 0x69, 0x72, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0xc9, 0x01, 0x0a, 0x0c, 0x45, 0x78, 0x70, 0x69, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x12, 0x31, 0x0a, 0x06, 0x72, 0x61, 0x6e, 0x5f, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05,
+	0x72, 0x61, 0x6e, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a,
+	0x10, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x50,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x66, 0x6c, 0x61, 0x67,
+	0x67, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x10, 0x66, 0x6c, 0x61, 0x67, 0x67, 0x65, 0x64, 0x50, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x22, 0x2b, 0x0a,
+	0x11, 0x49, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x22, 0xb9, 0x03, 0x0a, 0x0a, 0x49,
+	0x6e, 0x73, 0x74, 0x72, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x72,
+	0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x31, 0x0a, 0x07, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x17, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x73, 0x65, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x6e, 0x73,
+	0x74, 0x72, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x75,
+	0x6e, 0x64, 0x65, 0x72, 0x6c, 0x79, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x6c, 0x79, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6b, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6b, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74,
+	0x69, 0x63, 0x6b, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08,
+	0x74, 0x69, 0x63, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x74, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6c, 0x6f, 0x74, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x5f, 0x71, 0x75,
+	0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x66, 0x72,
+	0x65, 0x65, 0x7a, 0x65, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x23, 0x0a, 0x0d,
+	0x6c, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x63, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69,
+	0x74, 0x12, 0x23, 0x0a, 0x0d, 0x75, 0x70, 0x70, 0x65, 0x72, 0x5f, 0x63, 0x69, 0x72, 0x63, 0x75,
+	0x69, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x75, 0x70, 0x70, 0x65, 0x72, 0x43,
+	0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x22, 0x31, 0x0a, 0x0f, 0x45, 0x78, 0x70, 0x69, 0x72, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x75, 0x6e, 0x64,
+	0x65, 0x72, 0x6c, 0x79, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x75,
+	0x6e, 0x64, 0x65, 0x72, 0x6c, 0x79, 0x69, 0x6e, 0x67, 0x22, 0x28, 0x0a, 0x0a, 0x45, 0x78, 0x70,
+	0x69, 0x72, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x69, 0x65, 0x73, 0x22, 0x4c, 0x0a, 0x12, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61,
+	0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x75, 0x6e, 0x64,
+	0x65, 0x72, 0x6c, 0x79, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x75,
+	0x6e, 0x64, 0x65, 0x72, 0x6c, 0x79, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x79, 0x22, 0x86, 0x01, 0x0a, 0x0e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x69,
+	0x6e, 0x52, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6b, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6b, 0x65, 0x12, 0x2e, 0x0a, 0x04,
+	0x63, 0x61, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6c, 0x61, 0x61,
+	0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74,
+	0x72, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x04, 0x63, 0x61, 0x6c, 0x6c, 0x12, 0x2c, 0x0a, 0x03,
+	0x70, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6c, 0x61, 0x61, 0x62,
+	0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x72,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x03, 0x70, 0x75, 0x74, 0x22, 0x79, 0x0a, 0x0b, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x75, 0x6e, 0x64,
+	0x65, 0x72, 0x6c, 0x79, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x75,
+	0x6e, 0x64, 0x65, 0x72, 0x6c, 0x79, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x79, 0x12, 0x32, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x6f, 0x77, 0x52,
+	0x04, 0x72, 0x6f, 0x77, 0x73, 0x22, 0x48, 0x0a, 0x13, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x07,
+	0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e,
+	0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x22,
+	0xfb, 0x01, 0x0a, 0x0c, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x31, 0x0a, 0x07, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x17, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x73, 0x65, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x64,
+	0x61, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x64, 0x69, 0x6e,
+	0x67, 0x44, 0x61, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x6e, 0x65,
+	0x78, 0x74, 0x5f, 0x6f, 0x70, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x6e, 0x65, 0x78, 0x74, 0x4f,
+	0x70, 0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x6f, 0x6c, 0x69, 0x64, 0x61, 0x79, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x68, 0x6f, 0x6c, 0x69, 0x64, 0x61, 0x79, 0x22, 0x11, 0x0a,
+	0x0f, 0x48, 0x6f, 0x6c, 0x69, 0x64, 0x61, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x74, 0x0a, 0x07, 0x48, 0x6f, 0x6c, 0x69, 0x64, 0x61, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x33, 0x0a, 0x08, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x73, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x42, 0x0a, 0x0b, 0x48, 0x6f, 0x6c, 0x69, 0x64, 0x61,
+	0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x08, 0x68, 0x6f, 0x6c, 0x69, 0x64, 0x61, 0x79,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75,
+	0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x6c, 0x69, 0x64, 0x61, 0x79,
+	0x52, 0x08, 0x68, 0x6f, 0x6c, 0x69, 0x64, 0x61, 0x79, 0x73, 0x22, 0xbc, 0x01, 0x0a, 0x04, 0x54,
+	0x69, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x6f, 0x6c,
+	0x61, 0x74, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x76,
+	0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x72, 0x65,
+	0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x72, 0x65, 0x6e, 0x64, 0x12,
+	0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x2b, 0x0a, 0x13, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x22, 0x97, 0x01, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x10,
+	0x0a, 0x03, 0x67, 0x61, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x67, 0x61, 0x70,
+	0x2a, 0x39, 0x0a, 0x04, 0x53, 0x69, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x49, 0x44, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c,
+	0x0a, 0x08, 0x53, 0x49, 0x44, 0x45, 0x5f, 0x42, 0x55, 0x59, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09,
+	0x53, 0x49, 0x44, 0x45, 0x5f, 0x53, 0x45, 0x4c, 0x4c, 0x10, 0x02, 0x2a, 0x97, 0x01, 0x0a, 0x09,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x4f, 0x52, 0x44,
+	0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x4f,
+	0x52, 0x44, 0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x41, 0x52, 0x4b, 0x45, 0x54,
+	0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x53, 0x54, 0x4f, 0x50, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x4f, 0x52, 0x44, 0x45, 0x52,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x54, 0x43, 0x10, 0x04, 0x12, 0x18, 0x0a, 0x14, 0x4f,
+	0x52, 0x44, 0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x55, 0x4c, 0x54, 0x49, 0x5f,
+	0x4c, 0x45, 0x47, 0x10, 0x05, 0x2a, 0xca, 0x01, 0x0a, 0x0b, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x18, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x55, 0x53, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x19, 0x0a,
+	0x15, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x45, 0x58,
+	0x45, 0x43, 0x55, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x4f, 0x52, 0x44, 0x45,
+	0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x4c,
+	0x45, 0x44, 0x10, 0x03, 0x12, 0x18, 0x0a, 0x14, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x55, 0x53, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x04, 0x12, 0x17,
+	0x0a, 0x13, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x51,
+	0x55, 0x45, 0x55, 0x45, 0x44, 0x10, 0x05, 0x12, 0x19, 0x0a, 0x15, 0x4f, 0x52, 0x44, 0x45, 0x52,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44,
+	0x10, 0x06, 0x2a, 0x56, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x17, 0x0a,
+	0x13, 0x50, 0x52, 0x4f, 0x44, 0x55, 0x43, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x52, 0x4f, 0x44, 0x55, 0x43,
+	0x54, 0x5f, 0x4d, 0x49, 0x53, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x52, 0x4f, 0x44, 0x55,
+	0x43, 0x54, 0x5f, 0x43, 0x4e, 0x43, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x52, 0x4f, 0x44,
+	0x55, 0x43, 0x54, 0x5f, 0x4e, 0x52, 0x4d, 0x4c, 0x10, 0x03, 0x2a, 0x64, 0x0a, 0x07, 0x53, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x45, 0x47, 0x4d, 0x45, 0x4e, 0x54,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0e,
+	0x0a, 0x0a, 0x53, 0x45, 0x47, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x45, 0x51, 0x10, 0x01, 0x12, 0x0e,
+	0x0a, 0x0a, 0x53, 0x45, 0x47, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x46, 0x4f, 0x10, 0x02, 0x12, 0x0f,
+	0x0a, 0x0b, 0x53, 0x45, 0x47, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x44, 0x53, 0x10, 0x03, 0x12,
+	0x0f, 0x0a, 0x0b, 0x53, 0x45, 0x47, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x4f, 0x4d, 0x10, 0x04,
+	0x32, 0xcb, 0x13, 0x0a, 0x0f, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x4d, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x12, 0x22, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75,
+	0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x48, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73,
+	0x12, 0x20, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x3b, 0x0a,
+	0x08, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x18, 0x2e, 0x6c, 0x61, 0x61, 0x62,
+	0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x52, 0x65, 0x66, 0x1a, 0x15, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x48, 0x0a, 0x0c, 0x45, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x23, 0x2e, 0x6c, 0x61, 0x61,
+	0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x13, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x63, 0x6b, 0x12, 0x3c, 0x0a, 0x0b, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x12, 0x18, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x66, 0x1a, 0x13, 0x2e,
+	0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x63, 0x6b, 0x12, 0x4d, 0x0a, 0x0b, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x12, 0x22, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e,
+	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x4d, 0x0a, 0x0b, 0x53, 0x65, 0x74, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x6f, 0x73, 0x73,
+	0x12, 0x22, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x74, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f,
+	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x46, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x12, 0x18, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f,
+	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x66, 0x1a, 0x18,
+	0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x55, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x53, 0x63, 0x61, 0x6c, 0x70, 0x65, 0x72, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1c,
+	0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x63, 0x61, 0x6c, 0x70, 0x65, 0x72, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x1a, 0x21, 0x2e, 0x6c,
+	0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63,
+	0x61, 0x6c, 0x70, 0x65, 0x72, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x47, 0x0a, 0x15, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x41, 0x6c, 0x6c, 0x43, 0x68, 0x69,
+	0x6c, 0x64, 0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68,
+	0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x66, 0x1a, 0x13, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x6b, 0x12, 0x45, 0x0a, 0x14, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x65, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x43, 0x68, 0x69, 0x6c, 0x64,
+	0x12, 0x18, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x66, 0x1a, 0x13, 0x2e, 0x6c, 0x61, 0x61,
+	0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x6b, 0x12,
+	0x44, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x54, 0x43, 0x12, 0x18, 0x2e, 0x6c,
+	0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x54,
+	0x43, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x1a, 0x1d, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d,
+	0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x54, 0x43, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x4a, 0x0a, 0x0d, 0x45, 0x78, 0x69, 0x74, 0x41, 0x6c, 0x6c,
+	0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x12, 0x24, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d,
+	0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x69, 0x74, 0x41, 0x6c, 0x6c, 0x54,
+	0x72, 0x61, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6c,
+	0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63,
+	0x6b, 0x12, 0x41, 0x0a, 0x0f, 0x45, 0x78, 0x69, 0x74, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x54, 0x72,
+	0x61, 0x64, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f,
+	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x1a,
+	0x13, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x63, 0x6b, 0x12, 0x42, 0x0a, 0x11, 0x45, 0x78, 0x69, 0x74, 0x53, 0x70, 0x65, 0x63,
+	0x69, 0x66, 0x69, 0x63, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x12, 0x18, 0x2e, 0x6c, 0x61, 0x61, 0x62,
+	0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x69, 0x6c, 0x64,
+	0x52, 0x65, 0x66, 0x1a, 0x13, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x6b, 0x12, 0x46, 0x0a, 0x14, 0x43, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x41, 0x6c, 0x6c, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73,
+	0x12, 0x19, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x1a, 0x13, 0x2e, 0x6c, 0x61,
+	0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x6b,
+	0x12, 0x49, 0x0a, 0x18, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66,
+	0x69, 0x63, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x18, 0x2e, 0x6c,
+	0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68,
+	0x69, 0x6c, 0x64, 0x52, 0x65, 0x66, 0x1a, 0x13, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d,
+	0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x6b, 0x12, 0x41, 0x0a, 0x09, 0x47,
+	0x65, 0x74, 0x54, 0x72, 0x61, 0x64, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68,
+	0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x66, 0x1a, 0x19, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x43,
+	0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x12, 0x19, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x1a, 0x13,
+	0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x63, 0x6b, 0x12, 0x55, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4d, 0x75, 0x6c,
+	0x74, 0x69, 0x4c, 0x65, 0x67, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1d, 0x2e, 0x6c, 0x61, 0x61,
+	0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x75, 0x6c, 0x74,
+	0x69, 0x4c, 0x65, 0x67, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x1a, 0x1f, 0x2e, 0x6c, 0x61, 0x61, 0x62,
+	0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69,
+	0x4c, 0x65, 0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x4e, 0x0a, 0x10, 0x47, 0x65,
+	0x74, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x4c, 0x65, 0x67, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x19,
+	0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x1a, 0x1f, 0x2e, 0x6c, 0x61, 0x61, 0x62,
+	0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69,
+	0x4c, 0x65, 0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x53, 0x0a, 0x0d, 0x53, 0x79,
+	0x6e, 0x63, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x2e, 0x6c, 0x61,
+	0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6e,
+	0x63, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x44, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b,
+	0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x66, 0x1a, 0x18, 0x2e, 0x6c, 0x61,
+	0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4f, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x74,
+	0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68,
+	0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x18, 0x2e, 0x6c,
+	0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x56, 0x0a, 0x11, 0x53, 0x71, 0x75, 0x61, 0x72, 0x65,
+	0x4f, 0x66, 0x66, 0x49, 0x6e, 0x74, 0x72, 0x61, 0x64, 0x61, 0x79, 0x12, 0x20, 0x2e, 0x6c, 0x61,
+	0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x71, 0x75,
+	0x61, 0x72, 0x65, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x71, 0x75, 0x61, 0x72, 0x65, 0x4f, 0x66, 0x66, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x55,
+	0x0a, 0x0e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x45, 0x78, 0x70, 0x69, 0x72, 0x69, 0x65, 0x73,
+	0x12, 0x25, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x45, 0x78, 0x70, 0x69, 0x72, 0x69, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75,
+	0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x79, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x4e, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x73, 0x74,
+	0x72, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d,
+	0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6c, 0x61, 0x61, 0x62,
+	0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x72,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x4a, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70, 0x69,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0x1f, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f,
+	0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e,
+	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x79, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x51, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68,
+	0x61, 0x69, 0x6e, 0x12, 0x22, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75,
+	0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x68, 0x61, 0x69, 0x6e, 0x12, 0x54, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75,
+	0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6c,
+	0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61,
+	0x72, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4b, 0x0a, 0x0b, 0x47, 0x65,
+	0x74, 0x48, 0x6f, 0x6c, 0x69, 0x64, 0x61, 0x79, 0x73, 0x12, 0x1f, 0x2e, 0x6c, 0x61, 0x61, 0x62,
+	0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x6c, 0x69, 0x64,
+	0x61, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6c, 0x61, 0x61,
+	0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x6c, 0x69,
+	0x64, 0x61, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x0a, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x54, 0x69, 0x63, 0x6b, 0x12, 0x14, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e,
+	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x1a, 0x13, 0x2e, 0x6c, 0x61,
+	0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x6b,
+	0x12, 0x4c, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x23, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e, 0x6f, 0x6d, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2e,
+	0x6f, 0x6d, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42, 0x32,
+	0x5a, 0x30, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4d, 0x75, 0x6b,
+	0x69, 0x6c, 0x61, 0x6e, 0x2d, 0x54, 0x2f, 0x6c, 0x61, 0x61, 0x62, 0x68, 0x75, 0x6d, 0x2d, 0x61,
+	0x70, 0x69, 0x2d, 0x67, 0x6f, 0x2f, 0x6f, 0x6d, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x6f, 0x6d, 0x73,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_oms_v1_oms_proto_rawDescOnce sync.Once
+	file_oms_v1_oms_proto_rawDescData = file_oms_v1_oms_proto_rawDesc
+)
+
+func file_oms_v1_oms_proto_rawDescGZIP() []byte {
+	file_oms_v1_oms_proto_rawDescOnce.Do(func() {
+		file_oms_v1_oms_proto_rawDescData = protoimpl.X.CompressGZIP(file_oms_v1_oms_proto_rawDescData)
+	})
+	return file_oms_v1_oms_proto_rawDescData
+}
+
+var file_oms_v1_oms_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_oms_v1_oms_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
+var file_oms_v1_oms_proto_goTypes = []any{
+	(Side)(0),                     // 0: laabhum.oms.v1.Side
+	(OrderType)(0),                // 1: laabhum.oms.v1.OrderType
+	(OrderStatus)(0),              // 2: laabhum.oms.v1.OrderStatus
+	(Product)(0),                  // 3: laabhum.oms.v1.Product
+	(Segment)(0),                  // 4: laabhum.oms.v1.Segment
+	(*Error)(nil),                 // 5: laabhum.oms.v1.Error
+	(*Contract)(nil),              // 6: laabhum.oms.v1.Contract
+	(*Order)(nil),                 // 7: laabhum.oms.v1.Order
+	(*Position)(nil),              // 8: laabhum.oms.v1.Position
+	(*Trade)(nil),                 // 9: laabhum.oms.v1.Trade
+	(*Ack)(nil),                   // 10: laabhum.oms.v1.Ack
+	(*OrderRef)(nil),              // 11: laabhum.oms.v1.OrderRef
+	(*ParentRef)(nil),             // 12: laabhum.oms.v1.ParentRef
+	(*ChildRef)(nil),              // 13: laabhum.oms.v1.ChildRef
+	(*CreateOrderRequest)(nil),    // 14: laabhum.oms.v1.CreateOrderRequest
+	(*OrderReply)(nil),            // 15: laabhum.oms.v1.OrderReply
+	(*GetOrdersRequest)(nil),      // 16: laabhum.oms.v1.GetOrdersRequest
+	(*OrderList)(nil),             // 17: laabhum.oms.v1.OrderList
+	(*ExecuteOrderRequest)(nil),   // 18: laabhum.oms.v1.ExecuteOrderRequest
+	(*ModifyOrderRequest)(nil),    // 19: laabhum.oms.v1.ModifyOrderRequest
+	(*SetStopLossRequest)(nil),    // 20: laabhum.oms.v1.SetStopLossRequest
+	(*TimelineEntry)(nil),         // 21: laabhum.oms.v1.TimelineEntry
+	(*Timeline)(nil),              // 22: laabhum.oms.v1.Timeline
+	(*ScalperOrder)(nil),          // 23: laabhum.oms.v1.ScalperOrder
+	(*ScalperOrderReply)(nil),     // 24: laabhum.oms.v1.ScalperOrderReply
+	(*CTCOrder)(nil),              // 25: laabhum.oms.v1.CTCOrder
+	(*CTCOrderReply)(nil),         // 26: laabhum.oms.v1.CTCOrderReply
+	(*ExitAllTradesRequest)(nil),  // 27: laabhum.oms.v1.ExitAllTradesRequest
+	(*TradeList)(nil),             // 28: laabhum.oms.v1.TradeList
+	(*StrategyLeg)(nil),           // 29: laabhum.oms.v1.StrategyLeg
+	(*MultiLegOrder)(nil),         // 30: laabhum.oms.v1.MultiLegOrder
+	(*LegPosition)(nil),           // 31: laabhum.oms.v1.LegPosition
+	(*MultiLegSummary)(nil),       // 32: laabhum.oms.v1.MultiLegSummary
+	(*SyncPositionsRequest)(nil),  // 33: laabhum.oms.v1.SyncPositionsRequest
+	(*PositionRef)(nil),           // 34: laabhum.oms.v1.PositionRef
+	(*PositionList)(nil),          // 35: laabhum.oms.v1.PositionList
+	(*PositionConversion)(nil),    // 36: laabhum.oms.v1.PositionConversion
+	(*SquareOffRequest)(nil),      // 37: laabhum.oms.v1.SquareOffRequest
+	(*SquareOffReport)(nil),       // 38: laabhum.oms.v1.SquareOffReport
+	(*HandleExpiriesRequest)(nil), // 39: laabhum.oms.v1.HandleExpiriesRequest
+	(*ExpiryReport)(nil),          // 40: laabhum.oms.v1.ExpiryReport
+	(*InstrumentRequest)(nil),     // 41: laabhum.oms.v1.InstrumentRequest
+	(*Instrument)(nil),            // 42: laabhum.oms.v1.Instrument
+	(*ExpiriesRequest)(nil),       // 43: laabhum.oms.v1.ExpiriesRequest
+	(*ExpiryList)(nil),            // 44: laabhum.oms.v1.ExpiryList
+	(*OptionChainRequest)(nil),    // 45: laabhum.oms.v1.OptionChainRequest
+	(*OptionChainRow)(nil),        // 46: laabhum.oms.v1.OptionChainRow
+	(*OptionChain)(nil),           // 47: laabhum.oms.v1.OptionChain
+	(*MarketStatusRequest)(nil),   // 48: laabhum.oms.v1.MarketStatusRequest
+	(*MarketStatus)(nil),          // 49: laabhum.oms.v1.MarketStatus
+	(*HolidaysRequest)(nil),       // 50: laabhum.oms.v1.HolidaysRequest
+	(*Holiday)(nil),               // 51: laabhum.oms.v1.Holiday
+	(*HolidayList)(nil),           // 52: laabhum.oms.v1.HolidayList
+	(*Tick)(nil),                  // 53: laabhum.oms.v1.Tick
+	(*StreamEventsRequest)(nil),   // 54: laabhum.oms.v1.StreamEventsRequest
+	(*Event)(nil),                 // 55: laabhum.oms.v1.Event
+	(*timestamppb.Timestamp)(nil), // 56: google.protobuf.Timestamp
+}
+var file_oms_v1_oms_proto_depIdxs = []int32{
+	0,  // 0: laabhum.oms.v1.Order.side:type_name -> laabhum.oms.v1.Side
+	1,  // 1: laabhum.oms.v1.Order.type:type_name -> laabhum.oms.v1.OrderType
+	2,  // 2: laabhum.oms.v1.Order.status:type_name -> laabhum.oms.v1.OrderStatus
+	3,  // 3: laabhum.oms.v1.Order.product:type_name -> laabhum.oms.v1.Product
+	4,  // 4: laabhum.oms.v1.Order.segment:type_name -> laabhum.oms.v1.Segment
+	6,  // 5: laabhum.oms.v1.Order.contract:type_name -> laabhum.oms.v1.Contract
+	56, // 6: laabhum.oms.v1.Order.expires_at:type_name -> google.protobuf.Timestamp
+	3,  // 7: laabhum.oms.v1.Position.product:type_name -> laabhum.oms.v1.Product
+	6,  // 8: laabhum.oms.v1.Position.contract:type_name -> laabhum.oms.v1.Contract
+	56, // 9: laabhum.oms.v1.Position.opened_at:type_name -> google.protobuf.Timestamp
+	56, // 10: laabhum.oms.v1.Position.last_updated_at:type_name -> google.protobuf.Timestamp
+	56, // 11: laabhum.oms.v1.Trade.trade_time:type_name -> google.protobuf.Timestamp
+	7,  // 12: laabhum.oms.v1.CreateOrderRequest.order:type_name -> laabhum.oms.v1.Order
+	7,  // 13: laabhum.oms.v1.OrderReply.order:type_name -> laabhum.oms.v1.Order
+	2,  // 14: laabhum.oms.v1.GetOrdersRequest.status:type_name -> laabhum.oms.v1.OrderStatus
+	7,  // 15: laabhum.oms.v1.OrderList.orders:type_name -> laabhum.oms.v1.Order
+	7,  // 16: laabhum.oms.v1.ExecuteOrderRequest.order:type_name -> laabhum.oms.v1.Order
+	7,  // 17: laabhum.oms.v1.ModifyOrderRequest.changes:type_name -> laabhum.oms.v1.Order
+	56, // 18: laabhum.oms.v1.TimelineEntry.timestamp:type_name -> google.protobuf.Timestamp
+	21, // 19: laabhum.oms.v1.Timeline.entries:type_name -> laabhum.oms.v1.TimelineEntry
+	56, // 20: laabhum.oms.v1.ScalperOrder.expires_at:type_name -> google.protobuf.Timestamp
+	23, // 21: laabhum.oms.v1.ScalperOrderReply.order:type_name -> laabhum.oms.v1.ScalperOrder
+	25, // 22: laabhum.oms.v1.CTCOrderReply.order:type_name -> laabhum.oms.v1.CTCOrder
+	9,  // 23: laabhum.oms.v1.TradeList.trades:type_name -> laabhum.oms.v1.Trade
+	0,  // 24: laabhum.oms.v1.StrategyLeg.side:type_name -> laabhum.oms.v1.Side
+	1,  // 25: laabhum.oms.v1.StrategyLeg.type:type_name -> laabhum.oms.v1.OrderType
+	3,  // 26: laabhum.oms.v1.MultiLegOrder.product:type_name -> laabhum.oms.v1.Product
+	29, // 27: laabhum.oms.v1.MultiLegOrder.legs:type_name -> laabhum.oms.v1.StrategyLeg
+	7,  // 28: laabhum.oms.v1.MultiLegSummary.parent:type_name -> laabhum.oms.v1.Order
+	7,  // 29: laabhum.oms.v1.MultiLegSummary.legs:type_name -> laabhum.oms.v1.Order
+	31, // 30: laabhum.oms.v1.MultiLegSummary.position:type_name -> laabhum.oms.v1.LegPosition
+	8,  // 31: laabhum.oms.v1.PositionList.positions:type_name -> laabhum.oms.v1.Position
+	3,  // 32: laabhum.oms.v1.PositionConversion.from_product:type_name -> laabhum.oms.v1.Product
+	3,  // 33: laabhum.oms.v1.PositionConversion.to_product:type_name -> laabhum.oms.v1.Product
+	56, // 34: laabhum.oms.v1.SquareOffReport.ran_at:type_name -> google.protobuf.Timestamp
+	56, // 35: laabhum.oms.v1.ExpiryReport.ran_at:type_name -> google.protobuf.Timestamp
+	4,  // 36: laabhum.oms.v1.Instrument.segment:type_name -> laabhum.oms.v1.Segment
+	42, // 37: laabhum.oms.v1.OptionChainRow.call:type_name -> laabhum.oms.v1.Instrument
+	42, // 38: laabhum.oms.v1.OptionChainRow.put:type_name -> laabhum.oms.v1.Instrument
+	46, // 39: laabhum.oms.v1.OptionChain.rows:type_name -> laabhum.oms.v1.OptionChainRow
+	4,  // 40: laabhum.oms.v1.MarketStatusRequest.segment:type_name -> laabhum.oms.v1.Segment
+	4,  // 41: laabhum.oms.v1.MarketStatus.segment:type_name -> laabhum.oms.v1.Segment
+	56, // 42: laabhum.oms.v1.MarketStatus.time:type_name -> google.protobuf.Timestamp
+	56, // 43: laabhum.oms.v1.MarketStatus.next_open:type_name -> google.protobuf.Timestamp
+	4,  // 44: laabhum.oms.v1.Holiday.segments:type_name -> laabhum.oms.v1.Segment
+	51, // 45: laabhum.oms.v1.HolidayList.holidays:type_name -> laabhum.oms.v1.Holiday
+	56, // 46: laabhum.oms.v1.Tick.timestamp:type_name -> google.protobuf.Timestamp
+	56, // 47: laabhum.oms.v1.Event.timestamp:type_name -> google.protobuf.Timestamp
+	14, // 48: laabhum.oms.v1.OrderManagement.CreateOrder:input_type -> laabhum.oms.v1.CreateOrderRequest
+	16, // 49: laabhum.oms.v1.OrderManagement.GetOrders:input_type -> laabhum.oms.v1.GetOrdersRequest
+	11, // 50: laabhum.oms.v1.OrderManagement.GetOrder:input_type -> laabhum.oms.v1.OrderRef
+	18, // 51: laabhum.oms.v1.OrderManagement.ExecuteOrder:input_type -> laabhum.oms.v1.ExecuteOrderRequest
+	11, // 52: laabhum.oms.v1.OrderManagement.CancelOrder:input_type -> laabhum.oms.v1.OrderRef
+	19, // 53: laabhum.oms.v1.OrderManagement.ModifyOrder:input_type -> laabhum.oms.v1.ModifyOrderRequest
+	20, // 54: laabhum.oms.v1.OrderManagement.SetStopLoss:input_type -> laabhum.oms.v1.SetStopLossRequest
+	11, // 55: laabhum.oms.v1.OrderManagement.GetOrderTimeline:input_type -> laabhum.oms.v1.OrderRef
+	23, // 56: laabhum.oms.v1.OrderManagement.CreateScalperOrder:input_type -> laabhum.oms.v1.ScalperOrder
+	12, // 57: laabhum.oms.v1.OrderManagement.ExecuteAllChildTrades:input_type -> laabhum.oms.v1.ParentRef
+	13, // 58: laabhum.oms.v1.OrderManagement.ExecuteSpecificChild:input_type -> laabhum.oms.v1.ChildRef
+	25, // 59: laabhum.oms.v1.OrderManagement.CreateCTC:input_type -> laabhum.oms.v1.CTCOrder
+	27, // 60: laabhum.oms.v1.OrderManagement.ExitAllTrades:input_type -> laabhum.oms.v1.ExitAllTradesRequest
+	12, // 61: laabhum.oms.v1.OrderManagement.ExitChildTrades:input_type -> laabhum.oms.v1.ParentRef
+	13, // 62: laabhum.oms.v1.OrderManagement.ExitSpecificChild:input_type -> laabhum.oms.v1.ChildRef
+	12, // 63: laabhum.oms.v1.OrderManagement.CancelAllChildOrders:input_type -> laabhum.oms.v1.ParentRef
+	13, // 64: laabhum.oms.v1.OrderManagement.CancelSpecificChildOrder:input_type -> laabhum.oms.v1.ChildRef
+	12, // 65: laabhum.oms.v1.OrderManagement.GetTrades:input_type -> laabhum.oms.v1.ParentRef
+	12, // 66: laabhum.oms.v1.OrderManagement.DeleteParentOrder:input_type -> laabhum.oms.v1.ParentRef
+	30, // 67: laabhum.oms.v1.OrderManagement.CreateMultiLegOrder:input_type -> laabhum.oms.v1.MultiLegOrder
+	12, // 68: laabhum.oms.v1.OrderManagement.GetMultiLegOrder:input_type -> laabhum.oms.v1.ParentRef
+	33, // 69: laabhum.oms.v1.OrderManagement.SyncPositions:input_type -> laabhum.oms.v1.SyncPositionsRequest
+	34, // 70: laabhum.oms.v1.OrderManagement.GetPosition:input_type -> laabhum.oms.v1.PositionRef
+	36, // 71: laabhum.oms.v1.OrderManagement.ConvertPosition:input_type -> laabhum.oms.v1.PositionConversion
+	37, // 72: laabhum.oms.v1.OrderManagement.SquareOffIntraday:input_type -> laabhum.oms.v1.SquareOffRequest
+	39, // 73: laabhum.oms.v1.OrderManagement.HandleExpiries:input_type -> laabhum.oms.v1.HandleExpiriesRequest
+	41, // 74: laabhum.oms.v1.OrderManagement.GetInstrument:input_type -> laabhum.oms.v1.InstrumentRequest
+	43, // 75: laabhum.oms.v1.OrderManagement.GetExpiries:input_type -> laabhum.oms.v1.ExpiriesRequest
+	45, // 76: laabhum.oms.v1.OrderManagement.GetOptionChain:input_type -> laabhum.oms.v1.OptionChainRequest
+	48, // 77: laabhum.oms.v1.OrderManagement.GetMarketStatus:input_type -> laabhum.oms.v1.MarketStatusRequest
+	50, // 78: laabhum.oms.v1.OrderManagement.GetHolidays:input_type -> laabhum.oms.v1.HolidaysRequest
+	53, // 79: laabhum.oms.v1.OrderManagement.RecordTick:input_type -> laabhum.oms.v1.Tick
+	54, // 80: laabhum.oms.v1.OrderManagement.StreamEvents:input_type -> laabhum.oms.v1.StreamEventsRequest
+	15, // 81: laabhum.oms.v1.OrderManagement.CreateOrder:output_type -> laabhum.oms.v1.OrderReply
+	17, // 82: laabhum.oms.v1.OrderManagement.GetOrders:output_type -> laabhum.oms.v1.OrderList
+	7,  // 83: laabhum.oms.v1.OrderManagement.GetOrder:output_type -> laabhum.oms.v1.Order
+	10, // 84: laabhum.oms.v1.OrderManagement.ExecuteOrder:output_type -> laabhum.oms.v1.Ack
+	10, // 85: laabhum.oms.v1.OrderManagement.CancelOrder:output_type -> laabhum.oms.v1.Ack
+	15, // 86: laabhum.oms.v1.OrderManagement.ModifyOrder:output_type -> laabhum.oms.v1.OrderReply
+	15, // 87: laabhum.oms.v1.OrderManagement.SetStopLoss:output_type -> laabhum.oms.v1.OrderReply
+	22, // 88: laabhum.oms.v1.OrderManagement.GetOrderTimeline:output_type -> laabhum.oms.v1.Timeline
+	24, // 89: laabhum.oms.v1.OrderManagement.CreateScalperOrder:output_type -> laabhum.oms.v1.ScalperOrderReply
+	10, // 90: laabhum.oms.v1.OrderManagement.ExecuteAllChildTrades:output_type -> laabhum.oms.v1.Ack
+	10, // 91: laabhum.oms.v1.OrderManagement.ExecuteSpecificChild:output_type -> laabhum.oms.v1.Ack
+	26, // 92: laabhum.oms.v1.OrderManagement.CreateCTC:output_type -> laabhum.oms.v1.CTCOrderReply
+	10, // 93: laabhum.oms.v1.OrderManagement.ExitAllTrades:output_type -> laabhum.oms.v1.Ack
+	10, // 94: laabhum.oms.v1.OrderManagement.ExitChildTrades:output_type -> laabhum.oms.v1.Ack
+	10, // 95: laabhum.oms.v1.OrderManagement.ExitSpecificChild:output_type -> laabhum.oms.v1.Ack
+	10, // 96: laabhum.oms.v1.OrderManagement.CancelAllChildOrders:output_type -> laabhum.oms.v1.Ack
+	10, // 97: laabhum.oms.v1.OrderManagement.CancelSpecificChildOrder:output_type -> laabhum.oms.v1.Ack
+	28, // 98: laabhum.oms.v1.OrderManagement.GetTrades:output_type -> laabhum.oms.v1.TradeList
+	10, // 99: laabhum.oms.v1.OrderManagement.DeleteParentOrder:output_type -> laabhum.oms.v1.Ack
+	32, // 100: laabhum.oms.v1.OrderManagement.CreateMultiLegOrder:output_type -> laabhum.oms.v1.MultiLegSummary
+	32, // 101: laabhum.oms.v1.OrderManagement.GetMultiLegOrder:output_type -> laabhum.oms.v1.MultiLegSummary
+	35, // 102: laabhum.oms.v1.OrderManagement.SyncPositions:output_type -> laabhum.oms.v1.PositionList
+	8,  // 103: laabhum.oms.v1.OrderManagement.GetPosition:output_type -> laabhum.oms.v1.Position
+	8,  // 104: laabhum.oms.v1.OrderManagement.ConvertPosition:output_type -> laabhum.oms.v1.Position
+	38, // 105: laabhum.oms.v1.OrderManagement.SquareOffIntraday:output_type -> laabhum.oms.v1.SquareOffReport
+	40, // 106: laabhum.oms.v1.OrderManagement.HandleExpiries:output_type -> laabhum.oms.v1.ExpiryReport
+	42, // 107: laabhum.oms.v1.OrderManagement.GetInstrument:output_type -> laabhum.oms.v1.Instrument
+	44, // 108: laabhum.oms.v1.OrderManagement.GetExpiries:output_type -> laabhum.oms.v1.ExpiryList
+	47, // 109: laabhum.oms.v1.OrderManagement.GetOptionChain:output_type -> laabhum.oms.v1.OptionChain
+	49, // 110: laabhum.oms.v1.OrderManagement.GetMarketStatus:output_type -> laabhum.oms.v1.MarketStatus
+	52, // 111: laabhum.oms.v1.OrderManagement.GetHolidays:output_type -> laabhum.oms.v1.HolidayList
+	10, // 112: laabhum.oms.v1.OrderManagement.RecordTick:output_type -> laabhum.oms.v1.Ack
+	55, // 113: laabhum.oms.v1.OrderManagement.StreamEvents:output_type -> laabhum.oms.v1.Event
+	81, // [81:114] is the sub-list for method output_type
+	48, // [48:81] is the sub-list for method input_type
+	48, // [48:48] is the sub-list for extension type_name
+	48, // [48:48] is the sub-list for extension extendee
+	0,  // [0:48] is the sub-list for field type_name
+}
+
+func init() { file_oms_v1_oms_proto_init() }
+func file_oms_v1_oms_proto_init() {
+	if File_oms_v1_oms_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_oms_v1_oms_proto_rawDesc,
+			NumEnums:      5,
+			NumMessages:   51,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_oms_v1_oms_proto_goTypes,
+		DependencyIndexes: file_oms_v1_oms_proto_depIdxs,
+		EnumInfos:         file_oms_v1_oms_proto_enumTypes,
+		MessageInfos:      file_oms_v1_oms_proto_msgTypes,
+	}.Build()
+	File_oms_v1_oms_proto = out.File
+	file_oms_v1_oms_proto_rawDesc = nil
+	file_oms_v1_oms_proto_goTypes = nil
+	file_oms_v1_oms_proto_depIdxs = nil
+}
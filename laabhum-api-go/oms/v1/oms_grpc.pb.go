@@ -0,0 +1,1365 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: oms/v1/oms.proto
+
+package omsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	OrderManagement_CreateOrder_FullMethodName              = "/laabhum.oms.v1.OrderManagement/CreateOrder"
+	OrderManagement_GetOrders_FullMethodName                = "/laabhum.oms.v1.OrderManagement/GetOrders"
+	OrderManagement_GetOrder_FullMethodName                 = "/laabhum.oms.v1.OrderManagement/GetOrder"
+	OrderManagement_ExecuteOrder_FullMethodName             = "/laabhum.oms.v1.OrderManagement/ExecuteOrder"
+	OrderManagement_CancelOrder_FullMethodName              = "/laabhum.oms.v1.OrderManagement/CancelOrder"
+	OrderManagement_ModifyOrder_FullMethodName              = "/laabhum.oms.v1.OrderManagement/ModifyOrder"
+	OrderManagement_SetStopLoss_FullMethodName              = "/laabhum.oms.v1.OrderManagement/SetStopLoss"
+	OrderManagement_GetOrderTimeline_FullMethodName         = "/laabhum.oms.v1.OrderManagement/GetOrderTimeline"
+	OrderManagement_CreateScalperOrder_FullMethodName       = "/laabhum.oms.v1.OrderManagement/CreateScalperOrder"
+	OrderManagement_ExecuteAllChildTrades_FullMethodName    = "/laabhum.oms.v1.OrderManagement/ExecuteAllChildTrades"
+	OrderManagement_ExecuteSpecificChild_FullMethodName     = "/laabhum.oms.v1.OrderManagement/ExecuteSpecificChild"
+	OrderManagement_CreateCTC_FullMethodName                = "/laabhum.oms.v1.OrderManagement/CreateCTC"
+	OrderManagement_ExitAllTrades_FullMethodName            = "/laabhum.oms.v1.OrderManagement/ExitAllTrades"
+	OrderManagement_ExitChildTrades_FullMethodName          = "/laabhum.oms.v1.OrderManagement/ExitChildTrades"
+	OrderManagement_ExitSpecificChild_FullMethodName        = "/laabhum.oms.v1.OrderManagement/ExitSpecificChild"
+	OrderManagement_CancelAllChildOrders_FullMethodName     = "/laabhum.oms.v1.OrderManagement/CancelAllChildOrders"
+	OrderManagement_CancelSpecificChildOrder_FullMethodName = "/laabhum.oms.v1.OrderManagement/CancelSpecificChildOrder"
+	OrderManagement_GetTrades_FullMethodName                = "/laabhum.oms.v1.OrderManagement/GetTrades"
+	OrderManagement_DeleteParentOrder_FullMethodName        = "/laabhum.oms.v1.OrderManagement/DeleteParentOrder"
+	OrderManagement_CreateMultiLegOrder_FullMethodName      = "/laabhum.oms.v1.OrderManagement/CreateMultiLegOrder"
+	OrderManagement_GetMultiLegOrder_FullMethodName         = "/laabhum.oms.v1.OrderManagement/GetMultiLegOrder"
+	OrderManagement_SyncPositions_FullMethodName            = "/laabhum.oms.v1.OrderManagement/SyncPositions"
+	OrderManagement_GetPosition_FullMethodName              = "/laabhum.oms.v1.OrderManagement/GetPosition"
+	OrderManagement_ConvertPosition_FullMethodName          = "/laabhum.oms.v1.OrderManagement/ConvertPosition"
+	OrderManagement_SquareOffIntraday_FullMethodName        = "/laabhum.oms.v1.OrderManagement/SquareOffIntraday"
+	OrderManagement_HandleExpiries_FullMethodName           = "/laabhum.oms.v1.OrderManagement/HandleExpiries"
+	OrderManagement_GetInstrument_FullMethodName            = "/laabhum.oms.v1.OrderManagement/GetInstrument"
+	OrderManagement_GetExpiries_FullMethodName              = "/laabhum.oms.v1.OrderManagement/GetExpiries"
+	OrderManagement_GetOptionChain_FullMethodName           = "/laabhum.oms.v1.OrderManagement/GetOptionChain"
+	OrderManagement_GetMarketStatus_FullMethodName          = "/laabhum.oms.v1.OrderManagement/GetMarketStatus"
+	OrderManagement_GetHolidays_FullMethodName              = "/laabhum.oms.v1.OrderManagement/GetHolidays"
+	OrderManagement_RecordTick_FullMethodName               = "/laabhum.oms.v1.OrderManagement/RecordTick"
+	OrderManagement_StreamEvents_FullMethodName             = "/laabhum.oms.v1.OrderManagement/StreamEvents"
+)
+
+// OrderManagementClient is the client API for OrderManagement service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// OrderManagement is the OMS API served to the gateway. Field names follow the
+// JSON names of the OMS HTTP API so both surfaces describe orders the same way.
+type OrderManagementClient interface {
+	// Orders
+	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*OrderReply, error)
+	GetOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (*OrderList, error)
+	GetOrder(ctx context.Context, in *OrderRef, opts ...grpc.CallOption) (*Order, error)
+	ExecuteOrder(ctx context.Context, in *ExecuteOrderRequest, opts ...grpc.CallOption) (*Ack, error)
+	CancelOrder(ctx context.Context, in *OrderRef, opts ...grpc.CallOption) (*Ack, error)
+	// ModifyOrder changes the quantity, price or stop price of an unfilled order
+	ModifyOrder(ctx context.Context, in *ModifyOrderRequest, opts ...grpc.CallOption) (*OrderReply, error)
+	SetStopLoss(ctx context.Context, in *SetStopLossRequest, opts ...grpc.CallOption) (*OrderReply, error)
+	GetOrderTimeline(ctx context.Context, in *OrderRef, opts ...grpc.CallOption) (*Timeline, error)
+	// Scalper orders and their children
+	CreateScalperOrder(ctx context.Context, in *ScalperOrder, opts ...grpc.CallOption) (*ScalperOrderReply, error)
+	ExecuteAllChildTrades(ctx context.Context, in *ParentRef, opts ...grpc.CallOption) (*Ack, error)
+	ExecuteSpecificChild(ctx context.Context, in *ChildRef, opts ...grpc.CallOption) (*Ack, error)
+	CreateCTC(ctx context.Context, in *CTCOrder, opts ...grpc.CallOption) (*CTCOrderReply, error)
+	ExitAllTrades(ctx context.Context, in *ExitAllTradesRequest, opts ...grpc.CallOption) (*Ack, error)
+	ExitChildTrades(ctx context.Context, in *ParentRef, opts ...grpc.CallOption) (*Ack, error)
+	ExitSpecificChild(ctx context.Context, in *ChildRef, opts ...grpc.CallOption) (*Ack, error)
+	CancelAllChildOrders(ctx context.Context, in *ParentRef, opts ...grpc.CallOption) (*Ack, error)
+	CancelSpecificChildOrder(ctx context.Context, in *ChildRef, opts ...grpc.CallOption) (*Ack, error)
+	GetTrades(ctx context.Context, in *ParentRef, opts ...grpc.CallOption) (*TradeList, error)
+	DeleteParentOrder(ctx context.Context, in *ParentRef, opts ...grpc.CallOption) (*Ack, error)
+	// Multi-leg strategy orders
+	CreateMultiLegOrder(ctx context.Context, in *MultiLegOrder, opts ...grpc.CallOption) (*MultiLegSummary, error)
+	GetMultiLegOrder(ctx context.Context, in *ParentRef, opts ...grpc.CallOption) (*MultiLegSummary, error)
+	// Positions
+	SyncPositions(ctx context.Context, in *SyncPositionsRequest, opts ...grpc.CallOption) (*PositionList, error)
+	GetPosition(ctx context.Context, in *PositionRef, opts ...grpc.CallOption) (*Position, error)
+	ConvertPosition(ctx context.Context, in *PositionConversion, opts ...grpc.CallOption) (*Position, error)
+	SquareOffIntraday(ctx context.Context, in *SquareOffRequest, opts ...grpc.CallOption) (*SquareOffReport, error)
+	HandleExpiries(ctx context.Context, in *HandleExpiriesRequest, opts ...grpc.CallOption) (*ExpiryReport, error)
+	// Instruments and the trading calendar
+	GetInstrument(ctx context.Context, in *InstrumentRequest, opts ...grpc.CallOption) (*Instrument, error)
+	GetExpiries(ctx context.Context, in *ExpiriesRequest, opts ...grpc.CallOption) (*ExpiryList, error)
+	GetOptionChain(ctx context.Context, in *OptionChainRequest, opts ...grpc.CallOption) (*OptionChain, error)
+	GetMarketStatus(ctx context.Context, in *MarketStatusRequest, opts ...grpc.CallOption) (*MarketStatus, error)
+	GetHolidays(ctx context.Context, in *HolidaysRequest, opts ...grpc.CallOption) (*HolidayList, error)
+	// Market data and the event stream
+	RecordTick(ctx context.Context, in *Tick, opts ...grpc.CallOption) (*Ack, error)
+	// StreamEvents sends every OMS event after since, then follows new ones.
+	// A gap event means events were lost and the caller must resynchronise.
+	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+type orderManagementClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrderManagementClient(cc grpc.ClientConnInterface) OrderManagementClient {
+	return &orderManagementClient{cc}
+}
+
+func (c *orderManagementClient) CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*OrderReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderReply)
+	err := c.cc.Invoke(ctx, OrderManagement_CreateOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) GetOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (*OrderList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderList)
+	err := c.cc.Invoke(ctx, OrderManagement_GetOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) GetOrder(ctx context.Context, in *OrderRef, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, OrderManagement_GetOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) ExecuteOrder(ctx context.Context, in *ExecuteOrderRequest, opts ...grpc.CallOption) (*Ack, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ack)
+	err := c.cc.Invoke(ctx, OrderManagement_ExecuteOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) CancelOrder(ctx context.Context, in *OrderRef, opts ...grpc.CallOption) (*Ack, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ack)
+	err := c.cc.Invoke(ctx, OrderManagement_CancelOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) ModifyOrder(ctx context.Context, in *ModifyOrderRequest, opts ...grpc.CallOption) (*OrderReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderReply)
+	err := c.cc.Invoke(ctx, OrderManagement_ModifyOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) SetStopLoss(ctx context.Context, in *SetStopLossRequest, opts ...grpc.CallOption) (*OrderReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderReply)
+	err := c.cc.Invoke(ctx, OrderManagement_SetStopLoss_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) GetOrderTimeline(ctx context.Context, in *OrderRef, opts ...grpc.CallOption) (*Timeline, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Timeline)
+	err := c.cc.Invoke(ctx, OrderManagement_GetOrderTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) CreateScalperOrder(ctx context.Context, in *ScalperOrder, opts ...grpc.CallOption) (*ScalperOrderReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScalperOrderReply)
+	err := c.cc.Invoke(ctx, OrderManagement_CreateScalperOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) ExecuteAllChildTrades(ctx context.Context, in *ParentRef, opts ...grpc.CallOption) (*Ack, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ack)
+	err := c.cc.Invoke(ctx, OrderManagement_ExecuteAllChildTrades_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) ExecuteSpecificChild(ctx context.Context, in *ChildRef, opts ...grpc.CallOption) (*Ack, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ack)
+	err := c.cc.Invoke(ctx, OrderManagement_ExecuteSpecificChild_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) CreateCTC(ctx context.Context, in *CTCOrder, opts ...grpc.CallOption) (*CTCOrderReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CTCOrderReply)
+	err := c.cc.Invoke(ctx, OrderManagement_CreateCTC_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) ExitAllTrades(ctx context.Context, in *ExitAllTradesRequest, opts ...grpc.CallOption) (*Ack, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ack)
+	err := c.cc.Invoke(ctx, OrderManagement_ExitAllTrades_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) ExitChildTrades(ctx context.Context, in *ParentRef, opts ...grpc.CallOption) (*Ack, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ack)
+	err := c.cc.Invoke(ctx, OrderManagement_ExitChildTrades_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) ExitSpecificChild(ctx context.Context, in *ChildRef, opts ...grpc.CallOption) (*Ack, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ack)
+	err := c.cc.Invoke(ctx, OrderManagement_ExitSpecificChild_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) CancelAllChildOrders(ctx context.Context, in *ParentRef, opts ...grpc.CallOption) (*Ack, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ack)
+	err := c.cc.Invoke(ctx, OrderManagement_CancelAllChildOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) CancelSpecificChildOrder(ctx context.Context, in *ChildRef, opts ...grpc.CallOption) (*Ack, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ack)
+	err := c.cc.Invoke(ctx, OrderManagement_CancelSpecificChildOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) GetTrades(ctx context.Context, in *ParentRef, opts ...grpc.CallOption) (*TradeList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TradeList)
+	err := c.cc.Invoke(ctx, OrderManagement_GetTrades_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) DeleteParentOrder(ctx context.Context, in *ParentRef, opts ...grpc.CallOption) (*Ack, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ack)
+	err := c.cc.Invoke(ctx, OrderManagement_DeleteParentOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) CreateMultiLegOrder(ctx context.Context, in *MultiLegOrder, opts ...grpc.CallOption) (*MultiLegSummary, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MultiLegSummary)
+	err := c.cc.Invoke(ctx, OrderManagement_CreateMultiLegOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) GetMultiLegOrder(ctx context.Context, in *ParentRef, opts ...grpc.CallOption) (*MultiLegSummary, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MultiLegSummary)
+	err := c.cc.Invoke(ctx, OrderManagement_GetMultiLegOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) SyncPositions(ctx context.Context, in *SyncPositionsRequest, opts ...grpc.CallOption) (*PositionList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PositionList)
+	err := c.cc.Invoke(ctx, OrderManagement_SyncPositions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) GetPosition(ctx context.Context, in *PositionRef, opts ...grpc.CallOption) (*Position, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Position)
+	err := c.cc.Invoke(ctx, OrderManagement_GetPosition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) ConvertPosition(ctx context.Context, in *PositionConversion, opts ...grpc.CallOption) (*Position, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Position)
+	err := c.cc.Invoke(ctx, OrderManagement_ConvertPosition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) SquareOffIntraday(ctx context.Context, in *SquareOffRequest, opts ...grpc.CallOption) (*SquareOffReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SquareOffReport)
+	err := c.cc.Invoke(ctx, OrderManagement_SquareOffIntraday_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) HandleExpiries(ctx context.Context, in *HandleExpiriesRequest, opts ...grpc.CallOption) (*ExpiryReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExpiryReport)
+	err := c.cc.Invoke(ctx, OrderManagement_HandleExpiries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) GetInstrument(ctx context.Context, in *InstrumentRequest, opts ...grpc.CallOption) (*Instrument, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Instrument)
+	err := c.cc.Invoke(ctx, OrderManagement_GetInstrument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) GetExpiries(ctx context.Context, in *ExpiriesRequest, opts ...grpc.CallOption) (*ExpiryList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExpiryList)
+	err := c.cc.Invoke(ctx, OrderManagement_GetExpiries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) GetOptionChain(ctx context.Context, in *OptionChainRequest, opts ...grpc.CallOption) (*OptionChain, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OptionChain)
+	err := c.cc.Invoke(ctx, OrderManagement_GetOptionChain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) GetMarketStatus(ctx context.Context, in *MarketStatusRequest, opts ...grpc.CallOption) (*MarketStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarketStatus)
+	err := c.cc.Invoke(ctx, OrderManagement_GetMarketStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) GetHolidays(ctx context.Context, in *HolidaysRequest, opts ...grpc.CallOption) (*HolidayList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HolidayList)
+	err := c.cc.Invoke(ctx, OrderManagement_GetHolidays_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) RecordTick(ctx context.Context, in *Tick, opts ...grpc.CallOption) (*Ack, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ack)
+	err := c.cc.Invoke(ctx, OrderManagement_RecordTick_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OrderManagement_ServiceDesc.Streams[0], OrderManagement_StreamEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamEventsRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrderManagement_StreamEventsClient = grpc.ServerStreamingClient[Event]
+
+// OrderManagementServer is the server API for OrderManagement service.
+// All implementations must embed UnimplementedOrderManagementServer
+// for forward compatibility.
+//
+// OrderManagement is the OMS API served to the gateway. Field names follow the
+// JSON names of the OMS HTTP API so both surfaces describe orders the same way.
+type OrderManagementServer interface {
+	// Orders
+	CreateOrder(context.Context, *CreateOrderRequest) (*OrderReply, error)
+	GetOrders(context.Context, *GetOrdersRequest) (*OrderList, error)
+	GetOrder(context.Context, *OrderRef) (*Order, error)
+	ExecuteOrder(context.Context, *ExecuteOrderRequest) (*Ack, error)
+	CancelOrder(context.Context, *OrderRef) (*Ack, error)
+	// ModifyOrder changes the quantity, price or stop price of an unfilled order
+	ModifyOrder(context.Context, *ModifyOrderRequest) (*OrderReply, error)
+	SetStopLoss(context.Context, *SetStopLossRequest) (*OrderReply, error)
+	GetOrderTimeline(context.Context, *OrderRef) (*Timeline, error)
+	// Scalper orders and their children
+	CreateScalperOrder(context.Context, *ScalperOrder) (*ScalperOrderReply, error)
+	ExecuteAllChildTrades(context.Context, *ParentRef) (*Ack, error)
+	ExecuteSpecificChild(context.Context, *ChildRef) (*Ack, error)
+	CreateCTC(context.Context, *CTCOrder) (*CTCOrderReply, error)
+	ExitAllTrades(context.Context, *ExitAllTradesRequest) (*Ack, error)
+	ExitChildTrades(context.Context, *ParentRef) (*Ack, error)
+	ExitSpecificChild(context.Context, *ChildRef) (*Ack, error)
+	CancelAllChildOrders(context.Context, *ParentRef) (*Ack, error)
+	CancelSpecificChildOrder(context.Context, *ChildRef) (*Ack, error)
+	GetTrades(context.Context, *ParentRef) (*TradeList, error)
+	DeleteParentOrder(context.Context, *ParentRef) (*Ack, error)
+	// Multi-leg strategy orders
+	CreateMultiLegOrder(context.Context, *MultiLegOrder) (*MultiLegSummary, error)
+	GetMultiLegOrder(context.Context, *ParentRef) (*MultiLegSummary, error)
+	// Positions
+	SyncPositions(context.Context, *SyncPositionsRequest) (*PositionList, error)
+	GetPosition(context.Context, *PositionRef) (*Position, error)
+	ConvertPosition(context.Context, *PositionConversion) (*Position, error)
+	SquareOffIntraday(context.Context, *SquareOffRequest) (*SquareOffReport, error)
+	HandleExpiries(context.Context, *HandleExpiriesRequest) (*ExpiryReport, error)
+	// Instruments and the trading calendar
+	GetInstrument(context.Context, *InstrumentRequest) (*Instrument, error)
+	GetExpiries(context.Context, *ExpiriesRequest) (*ExpiryList, error)
+	GetOptionChain(context.Context, *OptionChainRequest) (*OptionChain, error)
+	GetMarketStatus(context.Context, *MarketStatusRequest) (*MarketStatus, error)
+	GetHolidays(context.Context, *HolidaysRequest) (*HolidayList, error)
+	// Market data and the event stream
+	RecordTick(context.Context, *Tick) (*Ack, error)
+	// StreamEvents sends every OMS event after since, then follows new ones.
+	// A gap event means events were lost and the caller must resynchronise.
+	StreamEvents(*StreamEventsRequest, grpc.ServerStreamingServer[Event]) error
+	mustEmbedUnimplementedOrderManagementServer()
+}
+
+// UnimplementedOrderManagementServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOrderManagementServer struct{}
+
+func (UnimplementedOrderManagementServer) CreateOrder(context.Context, *CreateOrderRequest) (*OrderReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
+}
+func (UnimplementedOrderManagementServer) GetOrders(context.Context, *GetOrdersRequest) (*OrderList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrders not implemented")
+}
+func (UnimplementedOrderManagementServer) GetOrder(context.Context, *OrderRef) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (UnimplementedOrderManagementServer) ExecuteOrder(context.Context, *ExecuteOrderRequest) (*Ack, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteOrder not implemented")
+}
+func (UnimplementedOrderManagementServer) CancelOrder(context.Context, *OrderRef) (*Ack, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedOrderManagementServer) ModifyOrder(context.Context, *ModifyOrderRequest) (*OrderReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ModifyOrder not implemented")
+}
+func (UnimplementedOrderManagementServer) SetStopLoss(context.Context, *SetStopLossRequest) (*OrderReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetStopLoss not implemented")
+}
+func (UnimplementedOrderManagementServer) GetOrderTimeline(context.Context, *OrderRef) (*Timeline, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderTimeline not implemented")
+}
+func (UnimplementedOrderManagementServer) CreateScalperOrder(context.Context, *ScalperOrder) (*ScalperOrderReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateScalperOrder not implemented")
+}
+func (UnimplementedOrderManagementServer) ExecuteAllChildTrades(context.Context, *ParentRef) (*Ack, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteAllChildTrades not implemented")
+}
+func (UnimplementedOrderManagementServer) ExecuteSpecificChild(context.Context, *ChildRef) (*Ack, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteSpecificChild not implemented")
+}
+func (UnimplementedOrderManagementServer) CreateCTC(context.Context, *CTCOrder) (*CTCOrderReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCTC not implemented")
+}
+func (UnimplementedOrderManagementServer) ExitAllTrades(context.Context, *ExitAllTradesRequest) (*Ack, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExitAllTrades not implemented")
+}
+func (UnimplementedOrderManagementServer) ExitChildTrades(context.Context, *ParentRef) (*Ack, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExitChildTrades not implemented")
+}
+func (UnimplementedOrderManagementServer) ExitSpecificChild(context.Context, *ChildRef) (*Ack, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExitSpecificChild not implemented")
+}
+func (UnimplementedOrderManagementServer) CancelAllChildOrders(context.Context, *ParentRef) (*Ack, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelAllChildOrders not implemented")
+}
+func (UnimplementedOrderManagementServer) CancelSpecificChildOrder(context.Context, *ChildRef) (*Ack, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSpecificChildOrder not implemented")
+}
+func (UnimplementedOrderManagementServer) GetTrades(context.Context, *ParentRef) (*TradeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrades not implemented")
+}
+func (UnimplementedOrderManagementServer) DeleteParentOrder(context.Context, *ParentRef) (*Ack, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteParentOrder not implemented")
+}
+func (UnimplementedOrderManagementServer) CreateMultiLegOrder(context.Context, *MultiLegOrder) (*MultiLegSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMultiLegOrder not implemented")
+}
+func (UnimplementedOrderManagementServer) GetMultiLegOrder(context.Context, *ParentRef) (*MultiLegSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMultiLegOrder not implemented")
+}
+func (UnimplementedOrderManagementServer) SyncPositions(context.Context, *SyncPositionsRequest) (*PositionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncPositions not implemented")
+}
+func (UnimplementedOrderManagementServer) GetPosition(context.Context, *PositionRef) (*Position, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPosition not implemented")
+}
+func (UnimplementedOrderManagementServer) ConvertPosition(context.Context, *PositionConversion) (*Position, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertPosition not implemented")
+}
+func (UnimplementedOrderManagementServer) SquareOffIntraday(context.Context, *SquareOffRequest) (*SquareOffReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SquareOffIntraday not implemented")
+}
+func (UnimplementedOrderManagementServer) HandleExpiries(context.Context, *HandleExpiriesRequest) (*ExpiryReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HandleExpiries not implemented")
+}
+func (UnimplementedOrderManagementServer) GetInstrument(context.Context, *InstrumentRequest) (*Instrument, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstrument not implemented")
+}
+func (UnimplementedOrderManagementServer) GetExpiries(context.Context, *ExpiriesRequest) (*ExpiryList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExpiries not implemented")
+}
+func (UnimplementedOrderManagementServer) GetOptionChain(context.Context, *OptionChainRequest) (*OptionChain, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOptionChain not implemented")
+}
+func (UnimplementedOrderManagementServer) GetMarketStatus(context.Context, *MarketStatusRequest) (*MarketStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMarketStatus not implemented")
+}
+func (UnimplementedOrderManagementServer) GetHolidays(context.Context, *HolidaysRequest) (*HolidayList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHolidays not implemented")
+}
+func (UnimplementedOrderManagementServer) RecordTick(context.Context, *Tick) (*Ack, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordTick not implemented")
+}
+func (UnimplementedOrderManagementServer) StreamEvents(*StreamEventsRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
+}
+func (UnimplementedOrderManagementServer) mustEmbedUnimplementedOrderManagementServer() {}
+func (UnimplementedOrderManagementServer) testEmbeddedByValue()                         {}
+
+// UnsafeOrderManagementServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrderManagementServer will
+// result in compilation errors.
+type UnsafeOrderManagementServer interface {
+	mustEmbedUnimplementedOrderManagementServer()
+}
+
+func RegisterOrderManagementServer(s grpc.ServiceRegistrar, srv OrderManagementServer) {
+	// If the following call pancis, it indicates UnimplementedOrderManagementServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OrderManagement_ServiceDesc, srv)
+}
+
+func _OrderManagement_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).CreateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_CreateOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).CreateOrder(ctx, req.(*CreateOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_GetOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).GetOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_GetOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).GetOrders(ctx, req.(*GetOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_GetOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).GetOrder(ctx, req.(*OrderRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_ExecuteOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).ExecuteOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_ExecuteOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).ExecuteOrder(ctx, req.(*ExecuteOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_CancelOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).CancelOrder(ctx, req.(*OrderRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_ModifyOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModifyOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).ModifyOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_ModifyOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).ModifyOrder(ctx, req.(*ModifyOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_SetStopLoss_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStopLossRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).SetStopLoss(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_SetStopLoss_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).SetStopLoss(ctx, req.(*SetStopLossRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_GetOrderTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).GetOrderTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_GetOrderTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).GetOrderTimeline(ctx, req.(*OrderRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_CreateScalperOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScalperOrder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).CreateScalperOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_CreateScalperOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).CreateScalperOrder(ctx, req.(*ScalperOrder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_ExecuteAllChildTrades_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParentRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).ExecuteAllChildTrades(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_ExecuteAllChildTrades_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).ExecuteAllChildTrades(ctx, req.(*ParentRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_ExecuteSpecificChild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChildRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).ExecuteSpecificChild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_ExecuteSpecificChild_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).ExecuteSpecificChild(ctx, req.(*ChildRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_CreateCTC_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CTCOrder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).CreateCTC(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_CreateCTC_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).CreateCTC(ctx, req.(*CTCOrder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_ExitAllTrades_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExitAllTradesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).ExitAllTrades(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_ExitAllTrades_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).ExitAllTrades(ctx, req.(*ExitAllTradesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_ExitChildTrades_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParentRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).ExitChildTrades(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_ExitChildTrades_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).ExitChildTrades(ctx, req.(*ParentRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_ExitSpecificChild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChildRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).ExitSpecificChild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_ExitSpecificChild_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).ExitSpecificChild(ctx, req.(*ChildRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_CancelAllChildOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParentRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).CancelAllChildOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_CancelAllChildOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).CancelAllChildOrders(ctx, req.(*ParentRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_CancelSpecificChildOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChildRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).CancelSpecificChildOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_CancelSpecificChildOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).CancelSpecificChildOrder(ctx, req.(*ChildRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_GetTrades_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParentRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).GetTrades(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_GetTrades_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).GetTrades(ctx, req.(*ParentRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_DeleteParentOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParentRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).DeleteParentOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_DeleteParentOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).DeleteParentOrder(ctx, req.(*ParentRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_CreateMultiLegOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiLegOrder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).CreateMultiLegOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_CreateMultiLegOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).CreateMultiLegOrder(ctx, req.(*MultiLegOrder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_GetMultiLegOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParentRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).GetMultiLegOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_GetMultiLegOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).GetMultiLegOrder(ctx, req.(*ParentRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_SyncPositions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncPositionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).SyncPositions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_SyncPositions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).SyncPositions(ctx, req.(*SyncPositionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_GetPosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PositionRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).GetPosition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_GetPosition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).GetPosition(ctx, req.(*PositionRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_ConvertPosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PositionConversion)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).ConvertPosition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_ConvertPosition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).ConvertPosition(ctx, req.(*PositionConversion))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_SquareOffIntraday_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SquareOffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).SquareOffIntraday(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_SquareOffIntraday_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).SquareOffIntraday(ctx, req.(*SquareOffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_HandleExpiries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleExpiriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).HandleExpiries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_HandleExpiries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).HandleExpiries(ctx, req.(*HandleExpiriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_GetInstrument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstrumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).GetInstrument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_GetInstrument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).GetInstrument(ctx, req.(*InstrumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_GetExpiries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExpiriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).GetExpiries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_GetExpiries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).GetExpiries(ctx, req.(*ExpiriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_GetOptionChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OptionChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).GetOptionChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_GetOptionChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).GetOptionChain(ctx, req.(*OptionChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_GetMarketStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarketStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).GetMarketStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_GetMarketStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).GetMarketStatus(ctx, req.(*MarketStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_GetHolidays_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HolidaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).GetHolidays(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_GetHolidays_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).GetHolidays(ctx, req.(*HolidaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_RecordTick_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Tick)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServer).RecordTick(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagement_RecordTick_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServer).RecordTick(ctx, req.(*Tick))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagement_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrderManagementServer).StreamEvents(m, &grpc.GenericServerStream[StreamEventsRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrderManagement_StreamEventsServer = grpc.ServerStreamingServer[Event]
+
+// OrderManagement_ServiceDesc is the grpc.ServiceDesc for OrderManagement service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OrderManagement_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "laabhum.oms.v1.OrderManagement",
+	HandlerType: (*OrderManagementServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateOrder",
+			Handler:    _OrderManagement_CreateOrder_Handler,
+		},
+		{
+			MethodName: "GetOrders",
+			Handler:    _OrderManagement_GetOrders_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _OrderManagement_GetOrder_Handler,
+		},
+		{
+			MethodName: "ExecuteOrder",
+			Handler:    _OrderManagement_ExecuteOrder_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _OrderManagement_CancelOrder_Handler,
+		},
+		{
+			MethodName: "ModifyOrder",
+			Handler:    _OrderManagement_ModifyOrder_Handler,
+		},
+		{
+			MethodName: "SetStopLoss",
+			Handler:    _OrderManagement_SetStopLoss_Handler,
+		},
+		{
+			MethodName: "GetOrderTimeline",
+			Handler:    _OrderManagement_GetOrderTimeline_Handler,
+		},
+		{
+			MethodName: "CreateScalperOrder",
+			Handler:    _OrderManagement_CreateScalperOrder_Handler,
+		},
+		{
+			MethodName: "ExecuteAllChildTrades",
+			Handler:    _OrderManagement_ExecuteAllChildTrades_Handler,
+		},
+		{
+			MethodName: "ExecuteSpecificChild",
+			Handler:    _OrderManagement_ExecuteSpecificChild_Handler,
+		},
+		{
+			MethodName: "CreateCTC",
+			Handler:    _OrderManagement_CreateCTC_Handler,
+		},
+		{
+			MethodName: "ExitAllTrades",
+			Handler:    _OrderManagement_ExitAllTrades_Handler,
+		},
+		{
+			MethodName: "ExitChildTrades",
+			Handler:    _OrderManagement_ExitChildTrades_Handler,
+		},
+		{
+			MethodName: "ExitSpecificChild",
+			Handler:    _OrderManagement_ExitSpecificChild_Handler,
+		},
+		{
+			MethodName: "CancelAllChildOrders",
+			Handler:    _OrderManagement_CancelAllChildOrders_Handler,
+		},
+		{
+			MethodName: "CancelSpecificChildOrder",
+			Handler:    _OrderManagement_CancelSpecificChildOrder_Handler,
+		},
+		{
+			MethodName: "GetTrades",
+			Handler:    _OrderManagement_GetTrades_Handler,
+		},
+		{
+			MethodName: "DeleteParentOrder",
+			Handler:    _OrderManagement_DeleteParentOrder_Handler,
+		},
+		{
+			MethodName: "CreateMultiLegOrder",
+			Handler:    _OrderManagement_CreateMultiLegOrder_Handler,
+		},
+		{
+			MethodName: "GetMultiLegOrder",
+			Handler:    _OrderManagement_GetMultiLegOrder_Handler,
+		},
+		{
+			MethodName: "SyncPositions",
+			Handler:    _OrderManagement_SyncPositions_Handler,
+		},
+		{
+			MethodName: "GetPosition",
+			Handler:    _OrderManagement_GetPosition_Handler,
+		},
+		{
+			MethodName: "ConvertPosition",
+			Handler:    _OrderManagement_ConvertPosition_Handler,
+		},
+		{
+			MethodName: "SquareOffIntraday",
+			Handler:    _OrderManagement_SquareOffIntraday_Handler,
+		},
+		{
+			MethodName: "HandleExpiries",
+			Handler:    _OrderManagement_HandleExpiries_Handler,
+		},
+		{
+			MethodName: "GetInstrument",
+			Handler:    _OrderManagement_GetInstrument_Handler,
+		},
+		{
+			MethodName: "GetExpiries",
+			Handler:    _OrderManagement_GetExpiries_Handler,
+		},
+		{
+			MethodName: "GetOptionChain",
+			Handler:    _OrderManagement_GetOptionChain_Handler,
+		},
+		{
+			MethodName: "GetMarketStatus",
+			Handler:    _OrderManagement_GetMarketStatus_Handler,
+		},
+		{
+			MethodName: "GetHolidays",
+			Handler:    _OrderManagement_GetHolidays_Handler,
+		},
+		{
+			MethodName: "RecordTick",
+			Handler:    _OrderManagement_RecordTick_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _OrderManagement_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "oms/v1/oms.proto",
+}
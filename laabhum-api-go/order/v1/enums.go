@@ -0,0 +1,179 @@
+package orderv1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidValue is returned for enum values outside the contract
+var ErrInvalidValue = errors.New("invalid enum value")
+
+// Side is the direction of an order
+type Side string
+
+const (
+	SideBuy  Side = "buy"
+	SideSell Side = "sell"
+)
+
+// OrderType is how an order is priced
+type OrderType string
+
+const (
+	TypeLimit    OrderType = "LIMIT"
+	TypeMarket   OrderType = "MARKET"
+	TypeStop     OrderType = "STOP"
+	TypeCTC      OrderType = "CTC"       // Child order of a scalper parent
+	TypeMultiLeg OrderType = "MULTI_LEG" // Parent of a multi-leg strategy order
+)
+
+// OrderStatus is where an order is in its lifecycle
+type OrderStatus string
+
+const (
+	StatusPending   OrderStatus = "pending"
+	StatusExecuted  OrderStatus = "executed"
+	StatusCancelled OrderStatus = "cancelled"
+	StatusDeleted   OrderStatus = "deleted"
+	StatusQueued    OrderStatus = "queued"   // After-market order waiting for the next open
+	StatusRejected  OrderStatus = "rejected" // Multi-leg parent whose legs were rolled back
+)
+
+// TradeStrategy is the trading style an order belongs to
+type TradeStrategy string
+
+const (
+	StrategyDayTrading      TradeStrategy = "DAY_TRADING"
+	StrategyPositionTrading TradeStrategy = "POSITION_TRADING"
+	StrategyScalping        TradeStrategy = "SCALPING"
+)
+
+// ProductType decides how a position is margined and settled
+type ProductType string
+
+const (
+	ProductMIS  ProductType = "MIS"  // Intraday, squared off before market close
+	ProductCNC  ProductType = "CNC"  // Delivery, settled into holdings
+	ProductNRML ProductType = "NRML" // Carry-forward for derivatives
+)
+
+// Segment is the exchange segment an instrument trades in
+type Segment string
+
+const (
+	SegmentEquity    Segment = "EQ"
+	SegmentFNO       Segment = "FO"
+	SegmentCurrency  Segment = "CDS"
+	SegmentCommodity Segment = "COM"
+)
+
+// InstrumentType is the kind of instrument a contract is for
+type InstrumentType string
+
+const (
+	InstrumentEquity InstrumentType = "EQ"
+	InstrumentFuture InstrumentType = "FUT"
+	InstrumentCall   InstrumentType = "CE"
+	InstrumentPut    InstrumentType = "PE"
+)
+
+var (
+	sides           = []Side{SideBuy, SideSell}
+	orderTypes      = []OrderType{TypeLimit, TypeMarket, TypeStop, TypeCTC, TypeMultiLeg}
+	orderStatuses   = []OrderStatus{StatusPending, StatusExecuted, StatusCancelled, StatusDeleted, StatusQueued, StatusRejected}
+	strategies      = []TradeStrategy{StrategyDayTrading, StrategyPositionTrading, StrategyScalping}
+	products        = []ProductType{ProductMIS, ProductCNC, ProductNRML}
+	segments        = []Segment{SegmentEquity, SegmentFNO, SegmentCurrency, SegmentCommodity}
+	instrumentTypes = []InstrumentType{InstrumentEquity, InstrumentFuture, InstrumentCall, InstrumentPut}
+)
+
+// parse returns the value of values that s names, in any casing. Earlier
+// clients sent "PENDING" and "scalping" where the contract has "pending" and
+// "SCALPING", so casing alone never makes a value invalid.
+func parse[E ~string](kind string, values []E, s string) (E, error) {
+	for _, value := range values {
+		if strings.EqualFold(string(value), s) {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown %s %q", ErrInvalidValue, kind, s)
+}
+
+// unmarshal parses text into *e, leaving an empty value unset
+func unmarshal[E ~string](kind string, values []E, e *E, text []byte) error {
+	if len(text) == 0 {
+		*e = ""
+		return nil
+	}
+	parsed, err := parse(kind, values, string(text))
+	if err != nil {
+		return err
+	}
+	*e = parsed
+	return nil
+}
+
+func valid[E ~string](values []E, e E) bool {
+	for _, value := range values {
+		if value == e {
+			return true
+		}
+	}
+	return false
+}
+
+func ParseSide(s string) (Side, error) { return parse("side", sides, s) }
+
+func ParseOrderType(s string) (OrderType, error) { return parse("order type", orderTypes, s) }
+
+func ParseOrderStatus(s string) (OrderStatus, error) { return parse("order status", orderStatuses, s) }
+
+func ParseTradeStrategy(s string) (TradeStrategy, error) { return parse("strategy", strategies, s) }
+
+func ParseProductType(s string) (ProductType, error) { return parse("product", products, s) }
+
+func ParseSegment(s string) (Segment, error) { return parse("segment", segments, s) }
+
+func ParseInstrumentType(s string) (InstrumentType, error) {
+	return parse("instrument type", instrumentTypes, s)
+}
+
+// IsValid reports whether the value is one of the contract's, in its canonical casing
+func (s Side) IsValid() bool           { return valid(sides, s) }
+func (t OrderType) IsValid() bool      { return valid(orderTypes, t) }
+func (s OrderStatus) IsValid() bool    { return valid(orderStatuses, s) }
+func (s TradeStrategy) IsValid() bool  { return valid(strategies, s) }
+func (p ProductType) IsValid() bool    { return valid(products, p) }
+func (s Segment) IsValid() bool        { return valid(segments, s) }
+func (t InstrumentType) IsValid() bool { return valid(instrumentTypes, t) }
+
+// IsTerminal reports whether an order in status s is done with, so there is
+// nothing left to cancel
+func (s OrderStatus) IsTerminal() bool { return s != StatusPending && s != StatusQueued }
+
+// UnmarshalText accepts any casing of a contract value, and the empty string
+// for a value left to the OMS to default. encoding/json uses it for string fields.
+func (s *Side) UnmarshalText(text []byte) error { return unmarshal("side", sides, s, text) }
+
+func (t *OrderType) UnmarshalText(text []byte) error {
+	return unmarshal("order type", orderTypes, t, text)
+}
+
+func (s *OrderStatus) UnmarshalText(text []byte) error {
+	return unmarshal("order status", orderStatuses, s, text)
+}
+
+func (s *TradeStrategy) UnmarshalText(text []byte) error {
+	return unmarshal("strategy", strategies, s, text)
+}
+
+func (p *ProductType) UnmarshalText(text []byte) error {
+	return unmarshal("product", products, p, text)
+}
+
+func (s *Segment) UnmarshalText(text []byte) error { return unmarshal("segment", segments, s, text) }
+
+func (t *InstrumentType) UnmarshalText(text []byte) error {
+	return unmarshal("instrument type", instrumentTypes, t, text)
+}
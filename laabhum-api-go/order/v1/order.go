@@ -0,0 +1,122 @@
+// Package orderv1 is version 1 of the order contract shared by the OMS and
+// the gateway: the order shape, its enums and their JSON and gRPC encodings.
+// Additive changes keep the version; renaming or removing a field or enum
+// value means a new package.
+package orderv1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Version names this revision of the contract
+const Version = "v1"
+
+// Contract holds the terms of a futures or options contract
+type Contract struct {
+	Type       InstrumentType `json:"instrument_type"` // FUT, CE or PE
+	Underlying string         `json:"underlying"`
+	Expiry     string         `json:"expiry"`           // YYYY-MM-DD
+	Strike     float64        `json:"strike,omitempty"` // Options only
+}
+
+// Order is an order as the OMS stores it and the gateway submits it
+type Order struct {
+	ID                string        `json:"id"`
+	ParentID          string        `json:"parent_id"`
+	Symbol            string        `json:"symbol"`
+	Quantity          int           `json:"quantity"`
+	Price             float64       `json:"price"`
+	Side              Side          `json:"side"`
+	Type              OrderType     `json:"type"`
+	Status            OrderStatus   `json:"status"`
+	StopPrice         float64       `json:"stop_price,omitempty"` // Trigger price of stop orders
+	Strategy          TradeStrategy `json:"strategy"`
+	Product           ProductType   `json:"product"`
+	Segment           Segment       `json:"segment,omitempty"`  // EQ when empty
+	AMO               bool          `json:"amo,omitempty"`      // Queue as an after-market order when the market is closed
+	Contract          *Contract     `json:"contract,omitempty"` // Futures and options terms, filled from the instrument master
+	RiskPercentage    float64       `json:"risk_percentage"`    // % of capital risked
+	StopLossActivated bool          `json:"stop_loss_activated"`
+	TakeProfit        float64       `json:"take_profit"`
+	CreatedAt         int64         `json:"created_at"` // Unix seconds
+	ExpiresAt         time.Time     `json:"expires_at,omitempty"`
+	ClientOrderID     string        `json:"client_order_id,omitempty"` // Caller's own ID; a resubmission under it returns this order
+	LegIndex          int           `json:"leg_index,omitempty"`       // Place of a multi-leg child among its parent's legs, from 1
+	UserID            string        `json:"user_id,omitempty"`         // Who placed the order, set by the OMS from the authenticated caller
+	AccountID         string        `json:"account_id,omitempty"`      // Account the order was placed for, set alongside UserID
+	SubmissionHash    string        `json:"submission_hash,omitempty"` // Hash of the order as submitted under ClientOrderID, set by the OMS to tell retries from reuse
+}
+
+// DecodeOption changes how Decode reads an order
+type DecodeOption func(*json.Decoder)
+
+// DisallowUnknownFields makes Decode fail on keys outside the contract. A
+// json.Decoder's own option does not reach custom unmarshalers such as
+// Order's, so strict callers like contract verification decode with this.
+func DisallowUnknownFields() DecodeOption {
+	return (*json.Decoder).DisallowUnknownFields
+}
+
+// Decode reads an order as UnmarshalJSON does, with opts applied
+func Decode(data []byte, opts ...DecodeOption) (Order, error) {
+	var o Order
+	err := o.decode(data, opts)
+	return o, err
+}
+
+// UnmarshalJSON decodes an order, also accepting the untagged ParentID and
+// StopLossActivated keys written before the contract existed
+func (o *Order) UnmarshalJSON(data []byte) error {
+	return o.decode(data, nil)
+}
+
+func (o *Order) decode(data []byte, opts []DecodeOption) error {
+	type plain Order
+	var decoded struct {
+		plain
+		LegacyParentID          string `json:"ParentID"`
+		LegacyStopLossActivated bool   `json:"StopLossActivated"`
+	}
+	decoded.plain = plain(*o)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for _, opt := range opts {
+		opt(decoder)
+	}
+	if err := decoder.Decode(&decoded); err != nil {
+		return err
+	}
+	*o = Order(decoded.plain)
+	if o.ParentID == "" {
+		o.ParentID = decoded.LegacyParentID
+	}
+	o.StopLossActivated = o.StopLossActivated || decoded.LegacyStopLossActivated
+	return nil
+}
+
+// Validate checks that every enum of the order is a contract value in its
+// canonical casing, or left empty for the OMS to default
+func (o Order) Validate() error {
+	checks := []struct {
+		kind  string
+		value string
+		valid bool
+	}{
+		{"side", string(o.Side), o.Side.IsValid()},
+		{"order type", string(o.Type), o.Type.IsValid()},
+		{"order status", string(o.Status), o.Status.IsValid()},
+		{"strategy", string(o.Strategy), o.Strategy.IsValid()},
+		{"product", string(o.Product), o.Product.IsValid()},
+		{"segment", string(o.Segment), o.Segment.IsValid()},
+	}
+	var errs []error
+	for _, check := range checks {
+		if check.value != "" && !check.valid {
+			errs = append(errs, fmt.Errorf("%w: unknown %s %q", ErrInvalidValue, check.kind, check.value))
+		}
+	}
+	return errors.Join(errs...)
+}
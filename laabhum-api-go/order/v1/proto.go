@@ -0,0 +1,180 @@
+package orderv1
+
+import (
+	"time"
+
+	omsv1 "github.com/Mukilan-T/laabhum-api-go/oms/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Conversions to and from the gRPC messages of oms/v1. Values outside the
+// contract convert to the unspecified enum, which converts back to "".
+
+var (
+	sideProtos = map[Side]omsv1.Side{
+		SideBuy:  omsv1.Side_SIDE_BUY,
+		SideSell: omsv1.Side_SIDE_SELL,
+	}
+	orderTypeProtos = map[OrderType]omsv1.OrderType{
+		TypeLimit:    omsv1.OrderType_ORDER_TYPE_LIMIT,
+		TypeMarket:   omsv1.OrderType_ORDER_TYPE_MARKET,
+		TypeStop:     omsv1.OrderType_ORDER_TYPE_STOP,
+		TypeCTC:      omsv1.OrderType_ORDER_TYPE_CTC,
+		TypeMultiLeg: omsv1.OrderType_ORDER_TYPE_MULTI_LEG,
+	}
+	orderStatusProtos = map[OrderStatus]omsv1.OrderStatus{
+		StatusPending:   omsv1.OrderStatus_ORDER_STATUS_PENDING,
+		StatusExecuted:  omsv1.OrderStatus_ORDER_STATUS_EXECUTED,
+		StatusCancelled: omsv1.OrderStatus_ORDER_STATUS_CANCELLED,
+		StatusDeleted:   omsv1.OrderStatus_ORDER_STATUS_DELETED,
+		StatusQueued:    omsv1.OrderStatus_ORDER_STATUS_QUEUED,
+		StatusRejected:  omsv1.OrderStatus_ORDER_STATUS_REJECTED,
+	}
+	productProtos = map[ProductType]omsv1.Product{
+		ProductMIS:  omsv1.Product_PRODUCT_MIS,
+		ProductCNC:  omsv1.Product_PRODUCT_CNC,
+		ProductNRML: omsv1.Product_PRODUCT_NRML,
+	}
+	segmentProtos = map[Segment]omsv1.Segment{
+		SegmentEquity:    omsv1.Segment_SEGMENT_EQ,
+		SegmentFNO:       omsv1.Segment_SEGMENT_FO,
+		SegmentCurrency:  omsv1.Segment_SEGMENT_CDS,
+		SegmentCommodity: omsv1.Segment_SEGMENT_COM,
+	}
+)
+
+// reverse looks up the contract value of a message enum
+func reverse[K comparable, V comparable](m map[K]V, v V) K {
+	for key, value := range m {
+		if value == v {
+			return key
+		}
+	}
+	var zero K
+	return zero
+}
+
+func (s Side) Proto() omsv1.Side               { return sideProtos[s] }
+func (t OrderType) Proto() omsv1.OrderType     { return orderTypeProtos[t] }
+func (s OrderStatus) Proto() omsv1.OrderStatus { return orderStatusProtos[s] }
+func (p ProductType) Proto() omsv1.Product     { return productProtos[p] }
+func (s Segment) Proto() omsv1.Segment         { return segmentProtos[s] }
+
+func SideFromProto(s omsv1.Side) Side                      { return reverse(sideProtos, s) }
+func OrderTypeFromProto(t omsv1.OrderType) OrderType       { return reverse(orderTypeProtos, t) }
+func OrderStatusFromProto(s omsv1.OrderStatus) OrderStatus { return reverse(orderStatusProtos, s) }
+func ProductFromProto(p omsv1.Product) ProductType         { return reverse(productProtos, p) }
+func SegmentFromProto(s omsv1.Segment) Segment             { return reverse(segmentProtos, s) }
+
+// timestampProto converts t, leaving the zero time unset
+func timestampProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// timeFromProto converts ts, reading an unset timestamp as the zero time
+func timeFromProto(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func (c *Contract) Proto() *omsv1.Contract {
+	if c == nil {
+		return nil
+	}
+	return &omsv1.Contract{
+		InstrumentType: string(c.Type),
+		Underlying:     c.Underlying,
+		Expiry:         c.Expiry,
+		Strike:         c.Strike,
+	}
+}
+
+func ContractFromProto(contract *omsv1.Contract) *Contract {
+	if contract == nil {
+		return nil
+	}
+	return &Contract{
+		Type:       InstrumentType(contract.InstrumentType),
+		Underlying: contract.Underlying,
+		Expiry:     contract.Expiry,
+		Strike:     contract.Strike,
+	}
+}
+
+func (o Order) Proto() *omsv1.Order {
+	return &omsv1.Order{
+		Id:                o.ID,
+		ParentId:          o.ParentID,
+		Symbol:            o.Symbol,
+		Quantity:          int32(o.Quantity),
+		Price:             o.Price,
+		Side:              o.Side.Proto(),
+		Type:              o.Type.Proto(),
+		Status:            o.Status.Proto(),
+		StopPrice:         o.StopPrice,
+		Strategy:          string(o.Strategy),
+		Product:           o.Product.Proto(),
+		Segment:           o.Segment.Proto(),
+		Amo:               o.AMO,
+		Contract:          o.Contract.Proto(),
+		RiskPercentage:    o.RiskPercentage,
+		StopLossActivated: o.StopLossActivated,
+		TakeProfit:        o.TakeProfit,
+		CreatedAt:         o.CreatedAt,
+		ExpiresAt:         timestampProto(o.ExpiresAt),
+		ClientOrderId:     o.ClientOrderID,
+		LegIndex:          int32(o.LegIndex),
+		UserId:            o.UserID,
+		AccountId:         o.AccountID,
+	}
+}
+
+// TradeStrategyFromProto reads a strategy, which is a string on the wire,
+// refusing unknown ones as JSON does. An empty strategy is left unset.
+func TradeStrategyFromProto(strategy string) (TradeStrategy, error) {
+	if strategy == "" {
+		return "", nil
+	}
+	return ParseTradeStrategy(strategy)
+}
+
+// OrderFromProto reads an order, failing with ErrInvalidValue for an unknown strategy
+func OrderFromProto(order *omsv1.Order) (Order, error) {
+	if order == nil {
+		return Order{}, nil
+	}
+	strategy, err := TradeStrategyFromProto(order.Strategy)
+	if err != nil {
+		return Order{}, err
+	}
+	return Order{
+		ID:                order.Id,
+		ParentID:          order.ParentId,
+		Symbol:            order.Symbol,
+		Quantity:          int(order.Quantity),
+		Price:             order.Price,
+		Side:              SideFromProto(order.Side),
+		Type:              OrderTypeFromProto(order.Type),
+		Status:            OrderStatusFromProto(order.Status),
+		StopPrice:         order.StopPrice,
+		Strategy:          strategy,
+		Product:           ProductFromProto(order.Product),
+		Segment:           SegmentFromProto(order.Segment),
+		AMO:               order.Amo,
+		Contract:          ContractFromProto(order.Contract),
+		RiskPercentage:    order.RiskPercentage,
+		StopLossActivated: order.StopLossActivated,
+		TakeProfit:        order.TakeProfit,
+		CreatedAt:         order.CreatedAt,
+		ExpiresAt:         timeFromProto(order.ExpiresAt),
+		ClientOrderID:     order.ClientOrderId,
+		LegIndex:          int(order.LegIndex),
+		UserID:            order.UserId,
+		AccountID:         order.AccountId,
+	}, nil
+}
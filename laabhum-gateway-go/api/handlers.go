@@ -1,12 +1,19 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
-	"time"
+
+	omsv1 "github.com/Mukilan-T/laabhum-api-go/oms/v1"
+	orderv1 "github.com/Mukilan-T/laabhum-api-go/order/v1"
+	"github.com/Mukilan-T/laabhum-gateway-go/internal/auth"
 	"github.com/Mukilan-T/laabhum-gateway-go/internal/oms"
 	"github.com/Mukilan-T/laabhum-gateway-go/pkg/logger" // Add this line
 	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 type Handlers struct {
@@ -20,31 +27,140 @@ func NewHandlers(logger *logger.Logger, omsClient *oms.Client) *Handlers {
 		omsClient: omsClient,
 	}
 }
-// Order struct represents an order in the system.
-type Order struct {
-	ID                string               `json:"id"`
-	Symbol            string               `json:"symbol"`
-	Quantity          int                  `json:"quantity"`
-	Price             float64              `json:"price"`
-	Side              string               `json:"side"` 
-	Type              string               `json:"type"`
-	Status            string               `json:"status"` 
-	StopPrice         float64              `json:"stop_price,omitempty"` 
-	Strategy          string               `json:"strategy"`
-	RiskPercentage    float64              `json:"risk_percentage"` 
-	StopLossActivated bool                 `json:"stop_loss_activated"` 
-	TakeProfit        float64              `json:"take_profit"` 
-	CreatedAt         int64                `json:"created_at"` 
-	ExpiresAt         time.Time            `json:"expires_at,omitempty"` 
-	ParentID          string               `json:"parent_id"`
-}
 // Error handler utility function
 func (h *Handlers) handleError(c *gin.Context, statusCode int, err error, msg string) {
 	h.logger.Errorf("%s: %v", msg, err)
 	c.JSON(statusCode, gin.H{"error": msg})
 }
 
-// CreateOrder handles creating a new order
+// handleOMSError reports a failed OMS call. Requests the client could not
+// convert into OMS messages are the caller's fault, and so are the OMS's own
+// rejections of them: its 400, 404, 409 and 429 answers reach the caller
+// with the OMS's status and message. An unreachable OMS, or one whose
+// circuit is open, is reported as unavailable; anything else as a failure.
+func (h *Handlers) handleOMSError(c *gin.Context, err error, msg string) {
+	if errors.Is(err, oms.ErrInvalidRequest) {
+		h.handleError(c, http.StatusBadRequest, err, msg+": "+err.Error())
+		return
+	}
+	switch omsErr := omsv1.ErrorFrom(err); omsErr.HttpStatus {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusTooManyRequests:
+		h.handleError(c, int(omsErr.HttpStatus), err, omsErr.Message)
+		return
+	case http.StatusServiceUnavailable:
+		h.handleError(c, http.StatusServiceUnavailable, err, msg+": OMS unavailable")
+		return
+	}
+	h.handleError(c, http.StatusInternalServerError, err, msg)
+}
+
+// replyJSON renders OMS replies with their proto field names, matching the OMS HTTP API
+var replyJSON = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// contractReply renders replies carrying orders in the shared order contract,
+// so the gateway answers with the same enums and field types as the OMS
+func contractReply(reply proto.Message) (interface{}, bool, error) {
+	switch reply := reply.(type) {
+	case *omsv1.OrderReply:
+		body := gin.H{"message": reply.Message, "order": nil}
+		if reply.Order != nil {
+			order, err := orderv1.OrderFromProto(reply.Order)
+			if err != nil {
+				return nil, true, err
+			}
+			body["order"] = order
+		}
+		if reply.Replayed {
+			body["replayed"] = true
+		}
+		return body, true, nil
+	case *omsv1.OrderList:
+		orders := make([]orderv1.Order, 0, len(reply.Orders))
+		for _, reply := range reply.Orders {
+			order, err := orderv1.OrderFromProto(reply)
+			if err != nil {
+				return nil, true, err
+			}
+			orders = append(orders, order)
+		}
+		return orders, true, nil
+	}
+	return nil, false, nil
+}
+
+// respond writes an OMS reply as JSON
+func (h *Handlers) respond(c *gin.Context, statusCode int, reply proto.Message) {
+	if body, ok, err := contractReply(reply); ok {
+		if err != nil {
+			h.handleError(c, http.StatusBadGateway, err, "Invalid order from the OMS")
+			return
+		}
+		c.JSON(statusCode, body)
+		return
+	}
+	body, err := replyJSON.Marshal(reply)
+	if err != nil {
+		h.handleError(c, http.StatusInternalServerError, err, "Failed to encode OMS reply")
+		return
+	}
+	c.Data(statusCode, "application/json; charset=utf-8", body)
+}
+
+// context returns the context OMS calls for c are made with, carrying the
+// authenticated caller, request ID and reason into the OMS audit trail
+func (h *Handlers) context(c *gin.Context) context.Context {
+	ctx := oms.WithRequestID(c.Request.Context(), c.GetHeader("X-Request-ID"))
+	if principal, ok := auth.FromContext(ctx); ok {
+		ctx = oms.WithUser(ctx, principal.User, principal.Account)
+	}
+	return oms.WithReason(ctx, c.GetHeader("X-Reason"))
+}
+
+// OrderOwner returns the user who placed the order, as the OMS stored it
+// with the order
+func (h *Handlers) OrderOwner(ctx context.Context, orderID string) (string, bool, error) {
+	order, err := h.omsClient.GetOrder(ctx, orderID)
+	if omsv1.ErrorFrom(err).GetHttpStatus() == http.StatusNotFound {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return order.UserId, true, nil
+}
+
+// PositionOwner returns the user who placed the order that opened the
+// position, as the OMS stored it with the position
+func (h *Handlers) PositionOwner(ctx context.Context, positionID string) (string, bool, error) {
+	position, err := h.omsClient.GetPosition(ctx, positionID)
+	if omsv1.ErrorFrom(err).GetHttpStatus() == http.StatusNotFound {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return position.UserId, true, nil
+}
+
+// ownerFilter returns the user whose orders and positions the caller may
+// list, or "" when they may list everyone's; false when there is no caller
+func ownerFilter(c *gin.Context) (string, bool) {
+	principal, ok := auth.FromContext(c.Request.Context())
+	if !ok {
+		return "", false
+	}
+	if principal.Can(auth.PermAnyOrder) {
+		return "", true
+	}
+	return principal.User, true
+}
+
+// IdempotencyKeyHeader names an order submission, so a client retrying it
+// after a timeout gets the original order back instead of a duplicate
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// CreateOrder handles creating a new order. A replay of an earlier
+// submission answers 200 with the original order.
 func (h *Handlers) CreateOrder(c *gin.Context) {
 	var order oms.Order
 	if err := c.ShouldBindJSON(&order); err != nil {
@@ -52,13 +168,17 @@ func (h *Handlers) CreateOrder(c *gin.Context) {
 		return
 	}
 
-	response, err := h.omsClient.CreateOrder(order)
+	response, err := h.omsClient.CreateOrder(h.context(c), c.GetHeader(IdempotencyKeyHeader), order)
 	if err != nil {
-		h.handleError(c, http.StatusInternalServerError, err, "Failed to create order")
+		h.handleOMSError(c, err, "Failed to create order")
 		return
 	}
 
-	c.JSON(http.StatusCreated, response)
+	statusCode := http.StatusCreated
+	if response.Replayed {
+		statusCode = http.StatusOK
+	}
+	h.respond(c, statusCode, response)
 }
 // CancelSpecificOrder cancels a specific order
 func (h *Handlers) CancelSpecificOrder(c *gin.Context) {
@@ -68,29 +188,31 @@ func (h *Handlers) CancelSpecificOrder(c *gin.Context) {
         return
     }
 
-    response, err := h.omsClient.CancelOrder(orderID)
+    response, err := h.omsClient.CancelOrder(h.context(c), orderID)
     if err != nil {
-        h.handleError(c, http.StatusInternalServerError, err, "Failed to cancel order")
+        h.handleOMSError(c, err, "Failed to cancel order")
         return
     }
 
-    c.JSON(http.StatusOK, response)
+    h.respond(c, http.StatusOK, response)
 }
-// CancelOrder cancels an order
+// CancelOrder cancels the order named by the id in the request body
 func (h *Handlers) CancelOrder(c *gin.Context) {
-	orderID := c.Param("orderID")
-	if orderID == "" {
-		h.handleError(c, http.StatusBadRequest, nil, "Order ID is required")
+	var req struct {
+		ID string `json:"id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || req.ID == "" {
+		h.handleError(c, http.StatusBadRequest, err, "Order ID is required")
 		return
 	}
 
-	response, err := h.omsClient.CancelOrder(orderID)
+	response, err := h.omsClient.CancelOrder(h.context(c), req.ID)
 	if err != nil {
-		h.handleError(c, http.StatusInternalServerError, err, "Failed to cancel order")
+		h.handleOMSError(c, err, "Failed to cancel order")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.respond(c, http.StatusOK, response)
 }
 // ActivateStopLoss activates a stop loss for a parent order
 func (h *Handlers) ActivateStopLoss(c *gin.Context) {
@@ -100,19 +222,19 @@ func (h *Handlers) ActivateStopLoss(c *gin.Context) {
         return
     }
 
-    var stopLoss oms.StopLoss
-    if err := c.ShouldBindJSON(&stopLoss); err != nil {
-        h.handleError(c, http.StatusBadRequest, err, "Invalid stop loss data")
+    childID := c.Param("childID")
+    if childID == "" {
+        h.handleError(c, http.StatusBadRequest, nil, "Child ID is required")
         return
     }
 
-	response, err := h.omsClient.ActivateStopLoss(parentID, stopLoss.ID) // Assuming stopLoss has an ID field of type string
+	response, err := h.omsClient.ActivateStopLoss(h.context(c), parentID, childID)
     if err != nil {
-        h.handleError(c, http.StatusInternalServerError, err, "Failed to activate stop loss")
+        h.handleOMSError(c, err, "Failed to activate stop loss")
         return
     }
 
-    c.JSON(http.StatusOK, response)
+    h.respond(c, http.StatusOK, response)
 }
 
 // CancelStopLoss cancels a stop loss for a parent order
@@ -129,13 +251,13 @@ func (h *Handlers) CancelStopLoss(c *gin.Context) {
         return
     }
 
-	response, err := h.omsClient.CancelStopLoss(parentID, childID)
+	response, err := h.omsClient.CancelStopLoss(h.context(c), parentID, childID)
 	if err != nil {
-		h.handleError(c, http.StatusInternalServerError, err, "Failed to cancel stop loss")
+		h.handleOMSError(c, err, "Failed to cancel stop loss")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.respond(c, http.StatusOK, response)
 }
 func (h *Handlers) CreateScalperOrder(c *gin.Context) {
 	var order oms.Order // Assuming ScalperOrder should be of type Order
@@ -158,15 +280,65 @@ func (h *Handlers) CreateScalperOrder(c *gin.Context) {
     }
 
     // Create the scalper order via service layer
-	createdOrder, err := h.omsClient.CreateScalperOrder(order)
+	createdOrder, err := h.omsClient.CreateScalperOrder(h.context(c), order)
     if err != nil {
-        h.logger.Printf("Order creation failed: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Order creation failed: " + err.Error()})
+        h.handleOMSError(c, err, "Order creation failed")
         return
     }
 
-    c.JSON(http.StatusCreated, gin.H{"message": "Scalper order created successfully", "order": createdOrder})
+    h.respond(c, http.StatusCreated, createdOrder)
+}
+// CreateMultiLegOrder places a multi-leg strategy order
+func (h *Handlers) CreateMultiLegOrder(c *gin.Context) {
+	var order oms.MultiLegOrder
+	if err := c.ShouldBindJSON(&order); err != nil {
+		h.handleError(c, http.StatusBadRequest, err, "Invalid multi-leg order data")
+		return
+	}
+
+	response, err := h.omsClient.CreateMultiLegOrder(h.context(c), order)
+	if err != nil {
+		h.handleOMSError(c, err, "Failed to place multi-leg order")
+		return
+	}
+
+	h.respond(c, http.StatusCreated, response)
 }
+
+// GetMultiLegOrder reports a multi-leg order
+func (h *Handlers) GetMultiLegOrder(c *gin.Context) {
+	parentID := c.Param("parentID")
+	if parentID == "" {
+		h.handleError(c, http.StatusBadRequest, nil, "Parent ID is required")
+		return
+	}
+
+	response, err := h.omsClient.GetMultiLegOrder(h.context(c), parentID)
+	if err != nil {
+		h.handleOMSError(c, err, "Failed to get multi-leg order")
+		return
+	}
+
+	h.respond(c, http.StatusOK, response)
+}
+
+// GetOrderTimeline reports who changed an order, when and why
+func (h *Handlers) GetOrderTimeline(c *gin.Context) {
+	orderID := c.Param("id")
+	if orderID == "" {
+		h.handleError(c, http.StatusBadRequest, nil, "Order ID is required")
+		return
+	}
+
+	response, err := h.omsClient.GetOrderTimeline(h.context(c), orderID)
+	if err != nil {
+		h.handleOMSError(c, err, "Failed to get order timeline")
+		return
+	}
+
+	h.respond(c, http.StatusOK, response)
+}
+
 // validateOrder checks the order fields for validity
 func validateOrder(order oms.Order) error {
     if order.Quantity <= 0 || order.Price <= 0 || order.Symbol == "" || order.Type == "" || order.Side == "" || order.Strategy == "" || order.RiskPercentage <= 0 {
@@ -188,13 +360,13 @@ func (h *Handlers) ExecuteAllChildTrades(c *gin.Context) {
         return
     }
 
-    response, err := h.omsClient.ExecuteAllChildTrades(parentID)
+    response, err := h.omsClient.ExecuteAllChildTrades(h.context(c), parentID)
     if err != nil {
-        h.handleError(c, http.StatusInternalServerError, err, "Failed to execute all child trades")
+        h.handleOMSError(c, err, "Failed to execute all child trades")
         return
     }
 
-    c.JSON(http.StatusOK, response)
+    h.respond(c, http.StatusOK, response)
 }
 
 // ExecuteSpecificChild executes a specific child trade
@@ -206,13 +378,13 @@ func (h *Handlers) ExecuteSpecificChild(c *gin.Context) {
 		return
 	}
 
-	response, err := h.omsClient.ExecuteSpecificChild(parentID, childID)
+	response, err := h.omsClient.ExecuteSpecificChild(h.context(c), parentID, childID)
 	if err != nil {
-		h.handleError(c, http.StatusInternalServerError, err, "Failed to execute specific child")
+		h.handleOMSError(c, err, "Failed to execute specific child")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.respond(c, http.StatusOK, response)
 }
 
 // CreateCTC creates a CTC for a parent order
@@ -229,13 +401,13 @@ func (h *Handlers) CreateCTC(c *gin.Context) {
 		return
 	}
 
-	response, err := h.omsClient.CreateCTC(parentID, ctcOrder)
+	response, err := h.omsClient.CreateCTC(h.context(c), parentID, ctcOrder)
 	if err != nil {
-		h.handleError(c, http.StatusInternalServerError, err, "Failed to create CTC")
+		h.handleOMSError(c, err, "Failed to create CTC")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.respond(c, http.StatusOK, response)
 }
 
 // ModifyOrder modifies an existing order based on the type
@@ -253,13 +425,13 @@ func (h *Handlers) ModifyOrder(c *gin.Context) {
 		return
 	}
 
-	response, err := h.omsClient.ModifyOrder(parentID, orderType, order)
+	response, err := h.omsClient.ModifyOrder(h.context(c), parentID, order)
 	if err != nil {
-		h.handleError(c, http.StatusInternalServerError, err, "Failed to modify order")
+		h.handleOMSError(c, err, "Failed to modify order")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.respond(c, http.StatusOK, response)
 }
 
 // ModifyChildOrder modifies an existing child order
@@ -278,24 +450,24 @@ func (h *Handlers) ModifyChildOrder(c *gin.Context) {
 		return
 	}
 
-	response, err := h.omsClient.ModifyChildOrder(parentID, childID, orderType, order)
+	response, err := h.omsClient.ModifyChildOrder(h.context(c), parentID, childID, order)
 	if err != nil {
-		h.handleError(c, http.StatusInternalServerError, err, "Failed to modify child order")
+		h.handleOMSError(c, err, "Failed to modify child order")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.respond(c, http.StatusOK, response)
 }
 
 // ExitAllTrades exits all trades for scalper
 func (h *Handlers) ExitAllTrades(c *gin.Context) {
-	response, err := h.omsClient.ExitAllTrades()
+	response, err := h.omsClient.ExitAllTrades(h.context(c))
 	if err != nil {
-		h.handleError(c, http.StatusInternalServerError, err, "Failed to exit all trades")
+		h.handleOMSError(c, err, "Failed to exit all trades")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.respond(c, http.StatusOK, response)
 }
 
 // ExitChildTrades exits all child trades for a parent order
@@ -306,13 +478,13 @@ func (h *Handlers) ExitChildTrades(c *gin.Context) {
 		return
 	}
 
-	response, err := h.omsClient.ExitChildTrades(parentID)
+	response, err := h.omsClient.ExitChildTrades(h.context(c), parentID)
 	if err != nil {
-		h.handleError(c, http.StatusInternalServerError, err, "Failed to exit child trades")
+		h.handleOMSError(c, err, "Failed to exit child trades")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.respond(c, http.StatusOK, response)
 }
 
 // ExitSpecificChild exits a specific child trade
@@ -324,13 +496,13 @@ func (h *Handlers) ExitSpecificChild(c *gin.Context) {
 		return
 	}
 
-	response, err := h.omsClient.ExitSpecificChild(parentID, childID)
+	response, err := h.omsClient.ExitSpecificChild(h.context(c), parentID, childID)
 	if err != nil {
-		h.handleError(c, http.StatusInternalServerError, err, "Failed to exit specific child")
+		h.handleOMSError(c, err, "Failed to exit specific child")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.respond(c, http.StatusOK, response)
 }
 
 // CancelAllChildOrders cancels all child orders for a parent order
@@ -341,13 +513,13 @@ func (h *Handlers) CancelAllChildOrders(c *gin.Context) {
 		return
 	}
 
-	response, err := h.omsClient.CancelAllChildOrders(parentID)
+	response, err := h.omsClient.CancelAllChildOrders(h.context(c), parentID)
 	if err != nil {
-		h.handleError(c, http.StatusInternalServerError, err, "Failed to cancel all child orders")
+		h.handleOMSError(c, err, "Failed to cancel all child orders")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.respond(c, http.StatusOK, response)
 }
 
 // CancelSpecificChildOrder cancels a specific child order
@@ -359,13 +531,13 @@ func (h *Handlers) CancelSpecificChildOrder(c *gin.Context) {
 		return
 	}
 
-	response, err := h.omsClient.CancelSpecificChildOrder(parentID, childID)
+	response, err := h.omsClient.CancelSpecificChildOrder(h.context(c), parentID, childID)
 	if err != nil {
-		h.handleError(c, http.StatusInternalServerError, err, "Failed to cancel specific child order")
+		h.handleOMSError(c, err, "Failed to cancel specific child order")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.respond(c, http.StatusOK, response)
 }
 
 // GetTrades retrieves trades based on parentID
@@ -376,13 +548,13 @@ func (h *Handlers) GetTrades(c *gin.Context) {
 		return
 	}
 
-	response, err := h.omsClient.GetTrades(parentID)
+	response, err := h.omsClient.GetTrades(h.context(c), parentID)
 	if err != nil {
-		h.handleError(c, http.StatusInternalServerError, err, "Failed to get trades")
+		h.handleOMSError(c, err, "Failed to get trades")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.respond(c, http.StatusOK, response)
 }
 
 // DeleteParentOrder deletes a parent order
@@ -393,35 +565,120 @@ func (h *Handlers) DeleteParentOrder(c *gin.Context) {
 		return
 	}
 
-	response, err := h.omsClient.DeleteParentOrder(parentID)
+	response, err := h.omsClient.DeleteParentOrder(h.context(c), parentID)
 	if err != nil {
-		h.handleError(c, http.StatusInternalServerError, err, "Failed to delete parent order")
+		h.handleOMSError(c, err, "Failed to delete parent order")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.respond(c, http.StatusOK, response)
 }
 
-// SyncPositions syncs the current positions
+// SyncPositions syncs the current positions, returning the caller's, or
+// everyone's for callers who may act on any order
 func (h *Handlers) SyncPositions(c *gin.Context) {
-	response, err := h.omsClient.SyncPositions()
+	userID, ok := ownerFilter(c)
+	if !ok {
+		h.handleError(c, http.StatusUnauthorized, nil, "Invalid or missing credentials")
+		return
+	}
+	response, err := h.omsClient.SyncPositions(h.context(c), userID)
 	if err != nil {
-		h.handleError(c, http.StatusInternalServerError, err, "Failed to sync positions")
+		h.handleOMSError(c, err, "Failed to sync positions")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.respond(c, http.StatusOK, response)
 }
 
-// GetOrders retrieves all orders from the OMS
+// ConvertPosition converts an open position to another product type
+func (h *Handlers) ConvertPosition(c *gin.Context) {
+	var req oms.PositionConversion
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.handleError(c, http.StatusBadRequest, err, "Invalid position conversion data")
+		return
+	}
+
+	response, err := h.omsClient.ConvertPosition(h.context(c), req)
+	if err != nil {
+		h.handleOMSError(c, err, "Failed to convert position")
+		return
+	}
+
+	h.respond(c, http.StatusOK, response)
+}
+
+// GetOptionChain returns the option chain for an underlying and expiry
+func (h *Handlers) GetOptionChain(c *gin.Context) {
+	underlying, expiry := c.Query("underlying"), c.Query("expiry")
+	if underlying == "" || expiry == "" {
+		h.handleError(c, http.StatusBadRequest, nil, "Underlying and expiry are required")
+		return
+	}
+
+	response, err := h.omsClient.GetOptionChain(h.context(c), underlying, expiry)
+	if err != nil {
+		h.handleOMSError(c, err, "Failed to get option chain")
+		return
+	}
+
+	h.respond(c, http.StatusOK, response)
+}
+
+// GetExpiries lists contract expiries for an underlying
+func (h *Handlers) GetExpiries(c *gin.Context) {
+	underlying := c.Query("underlying")
+	if underlying == "" {
+		h.handleError(c, http.StatusBadRequest, nil, "Underlying is required")
+		return
+	}
+
+	response, err := h.omsClient.GetExpiries(h.context(c), underlying)
+	if err != nil {
+		h.handleOMSError(c, err, "Failed to get expiries")
+		return
+	}
+
+	h.respond(c, http.StatusOK, response)
+}
+
+// GetMarketStatus reports whether a segment is trading right now
+func (h *Handlers) GetMarketStatus(c *gin.Context) {
+	response, err := h.omsClient.GetMarketStatus(h.context(c), c.DefaultQuery("segment", "EQ"))
+	if err != nil {
+		h.handleOMSError(c, err, "Failed to get market status")
+		return
+	}
+
+	h.respond(c, http.StatusOK, response)
+}
+
+// GetHolidays lists exchange holidays
+func (h *Handlers) GetHolidays(c *gin.Context) {
+	response, err := h.omsClient.GetHolidays(h.context(c))
+	if err != nil {
+		h.handleOMSError(c, err, "Failed to get holidays")
+		return
+	}
+
+	h.respond(c, http.StatusOK, response)
+}
+
+// GetOrders retrieves the caller's orders from the OMS, or every order for
+// callers who may act on any order
 func (h *Handlers) GetOrders(c *gin.Context) {
-	response, err := h.omsClient.GetAllOrders()
+	userID, ok := ownerFilter(c)
+	if !ok {
+		h.handleError(c, http.StatusUnauthorized, nil, "Invalid or missing credentials")
+		return
+	}
+	response, err := h.omsClient.GetOrders(h.context(c), userID)
 	if err != nil {
-		h.handleError(c, http.StatusInternalServerError, err, "Failed to retrieve orders")
+		h.handleOMSError(c, err, "Failed to retrieve orders")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.respond(c, http.StatusOK, response)
 }
 
 // ExecuteOrder executes an order
@@ -432,11 +689,11 @@ func (h *Handlers) ExecuteOrder(c *gin.Context) {
 		return
 	}
 
-	response, err := h.omsClient.ExecuteOrder(order)
+	response, err := h.omsClient.ExecuteOrder(h.context(c), order)
 	if err != nil {
-		h.handleError(c, http.StatusInternalServerError, err, "Failed to execute order")
+		h.handleOMSError(c, err, "Failed to execute order")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.respond(c, http.StatusOK, response)
 }
\ No newline at end of file
@@ -0,0 +1,357 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-gateway-go/internal/auth"
+	"github.com/Mukilan-T/laabhum-gateway-go/internal/stream"
+	"github.com/Mukilan-T/laabhum-gateway-go/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// DefaultHeartbeat is how often an idle WebSocket client is sent a heartbeat
+const DefaultHeartbeat = 15 * time.Second
+
+const (
+	wsWriteTimeout = 10 * time.Second
+	wsBuffer       = 256
+	wsMaxMessage   = 64 * 1024
+)
+
+// Client actions on the WebSocket
+const (
+	actionSubscribe   = "subscribe"
+	actionUnsubscribe = "unsubscribe"
+	actionPing        = "ping"
+)
+
+// Server message types on the WebSocket
+const (
+	messageEvent        = "event"
+	messageHeartbeat    = "heartbeat"
+	messageSubscribed   = "subscribed"
+	messageUnsubscribed = "unsubscribed"
+	messageResync       = "resync"
+	messagePong         = "pong"
+	messageError        = "error"
+)
+
+// clientMessage is a request from a WebSocket client. Since resumes the
+// subscription after the last sequence the client received.
+type clientMessage struct {
+	Action   string   `json:"action"`
+	Channels []string `json:"channels,omitempty"`
+	Symbols  []string `json:"symbols,omitempty"`
+	Since    *uint64  `json:"since,omitempty"`
+}
+
+type serverMessage struct {
+	Type     string        `json:"type"`
+	Seq      uint64        `json:"seq,omitempty"`
+	Event    *stream.Event `json:"event,omitempty"`
+	Channels []string      `json:"channels,omitempty"`
+	Symbols  []string      `json:"symbols,omitempty"`
+	Time     string        `json:"time,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// StreamHandler serves OMS events to WebSocket clients. Clients are
+// authenticated by the Guard before the upgrade.
+type StreamHandler struct {
+	logger    *logger.Logger
+	feed      *stream.Feed
+	origins   map[string]bool
+	heartbeat time.Duration
+	upgrader  websocket.Upgrader
+}
+
+// NewStreamHandler returns a StreamHandler accepting browser connections
+// from allowedOrigins only
+func NewStreamHandler(logger *logger.Logger, feed *stream.Feed, allowedOrigins []string, heartbeat time.Duration) *StreamHandler {
+	if heartbeat <= 0 {
+		heartbeat = DefaultHeartbeat
+	}
+	h := &StreamHandler{
+		logger:    logger,
+		feed:      feed,
+		origins:   make(map[string]bool),
+		heartbeat: heartbeat,
+	}
+	for _, origin := range allowedOrigins {
+		h.origins[strings.ToLower(strings.TrimRight(origin, "/"))] = true
+	}
+	h.upgrader = websocket.Upgrader{CheckOrigin: h.checkOrigin}
+	return h
+}
+
+// checkOrigin accepts non-browser clients, which send no Origin, and
+// browsers on an allowed origin
+func (h *StreamHandler) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	return origin == "" || h.origins[strings.ToLower(origin)]
+}
+
+// ServeWS upgrades the request to a WebSocket streaming order updates, fills,
+// position changes and price ticks for the channels and symbols the client
+// subscribes to. Callers that may not act on any order only receive events
+// about their own orders.
+func (h *StreamHandler) ServeWS(c *gin.Context) {
+	principal, ok := auth.FromContext(c.Request.Context())
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or missing credentials"})
+		return
+	}
+	if !h.checkOrigin(c.Request) {
+		h.logger.Warnf("WebSocket from %s refused for origin %q", principal.User, c.GetHeader("Origin"))
+		c.JSON(http.StatusForbidden, gin.H{"error": "Origin not allowed"})
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already replied with an error
+		h.logger.Warnf("WebSocket upgrade failed: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	newSession(h, conn, principal).run()
+}
+
+// session is one WebSocket connection. Only run writes to the connection and
+// touches the subscription; readLoop hands client messages over to it.
+type session struct {
+	handler   *StreamHandler
+	conn      *websocket.Conn
+	principal *auth.Principal
+	channels  map[string]bool
+	symbols   map[string]bool
+	// last is the latest feed sequence processed, delivered or filtered out
+	last      uint64
+	following bool
+}
+
+func newSession(h *StreamHandler, conn *websocket.Conn, principal *auth.Principal) *session {
+	return &session{
+		handler:   h,
+		conn:      conn,
+		principal: principal,
+		channels:  make(map[string]bool),
+		symbols:   make(map[string]bool),
+	}
+}
+
+func (s *session) run() {
+	feed := s.handler.feed
+	events := feed.Subscribe(wsBuffer)
+	defer feed.Unsubscribe(events)
+
+	requests := make(chan clientMessage)
+	done := make(chan struct{})
+	go s.readLoop(requests, done)
+
+	heartbeat := time.NewTicker(s.handler.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		select {
+		case <-done:
+			return
+		case request := <-requests:
+			err = s.handle(request)
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			err = s.deliverLive(event)
+		case <-heartbeat.C:
+			err = s.send(serverMessage{Type: messageHeartbeat, Seq: feed.Sequence(), Time: time.Now().UTC().Format(time.RFC3339Nano)})
+			if err == nil {
+				err = s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout))
+			}
+		}
+		if err != nil {
+			s.handler.logger.Infof("WebSocket client disconnected: %v", err)
+			return
+		}
+	}
+}
+
+// readLoop decodes client messages until the connection fails. A client that
+// answers neither heartbeat pings nor sends anything is dropped after two
+// missed heartbeats.
+func (s *session) readLoop(requests chan<- clientMessage, done chan<- struct{}) {
+	defer close(done)
+
+	deadline := 2*s.handler.heartbeat + wsWriteTimeout
+	s.conn.SetReadLimit(wsMaxMessage)
+	s.conn.SetReadDeadline(time.Now().Add(deadline))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(deadline))
+	})
+
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		s.conn.SetReadDeadline(time.Now().Add(deadline))
+		var request clientMessage
+		if err := json.Unmarshal(data, &request); err != nil {
+			request = clientMessage{}
+		}
+		select {
+		case requests <- request:
+		case <-time.After(deadline):
+			return
+		}
+	}
+}
+
+func (s *session) handle(request clientMessage) error {
+	switch request.Action {
+	case actionPing:
+		return s.send(serverMessage{Type: messagePong, Seq: s.last, Time: time.Now().UTC().Format(time.RFC3339Nano)})
+	case actionSubscribe:
+		return s.subscribe(request)
+	case actionUnsubscribe:
+		return s.unsubscribe(request)
+	case "":
+		return s.send(serverMessage{Type: messageError, Error: "invalid message, expected a JSON object with an action"})
+	default:
+		return s.send(serverMessage{Type: messageError, Error: "unknown action " + request.Action})
+	}
+}
+
+func (s *session) subscribe(request clientMessage) error {
+	channels := request.Channels
+	if len(channels) == 0 {
+		channels = stream.Channels
+	}
+	for _, channel := range channels {
+		if !validChannel(channel) {
+			return s.send(serverMessage{Type: messageError, Error: "unknown channel " + channel})
+		}
+	}
+	for _, channel := range channels {
+		s.channels[channel] = true
+	}
+	for _, symbol := range request.Symbols {
+		s.symbols[strings.ToUpper(symbol)] = true
+	}
+
+	if err := s.send(serverMessage{Type: messageSubscribed, Seq: s.handler.feed.Sequence(), Channels: keys(s.channels), Symbols: keys(s.symbols)}); err != nil {
+		return err
+	}
+	if request.Since != nil {
+		s.last, s.following = *request.Since, true
+		return s.catchUp()
+	}
+	return nil
+}
+
+func (s *session) unsubscribe(request clientMessage) error {
+	if len(request.Channels) == 0 && len(request.Symbols) == 0 {
+		s.channels = make(map[string]bool)
+		s.symbols = make(map[string]bool)
+	}
+	for _, channel := range request.Channels {
+		delete(s.channels, channel)
+	}
+	for _, symbol := range request.Symbols {
+		delete(s.symbols, strings.ToUpper(symbol))
+	}
+	return s.send(serverMessage{Type: messageUnsubscribed, Channels: keys(s.channels), Symbols: keys(s.symbols)})
+}
+
+// catchUp delivers the retained events after s.last, telling the client to
+// resynchronise first if some of them are gone
+func (s *session) catchUp() error {
+	backlog, complete := s.handler.feed.Since(s.last)
+	if !complete {
+		if err := s.send(serverMessage{Type: messageResync, Seq: s.last}); err != nil {
+			return err
+		}
+		s.last = 0
+	}
+	for i := range backlog {
+		if err := s.deliver(backlog[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *session) deliverLive(event stream.Event) error {
+	switch {
+	case s.following && event.Sequence <= s.last:
+		// Already sent while catching up
+		return nil
+	case s.following && event.Sequence > s.last+1:
+		// Events were dropped while this client was slow
+		return s.catchUp()
+	default:
+		s.following = true
+		return s.deliver(event)
+	}
+}
+
+func (s *session) deliver(event stream.Event) error {
+	s.last = event.Sequence
+	if event.Type == stream.TypeResync {
+		return s.send(serverMessage{Type: messageResync, Seq: event.Sequence})
+	}
+	if !s.wants(event) {
+		return nil
+	}
+	return s.send(serverMessage{Type: messageEvent, Seq: event.Sequence, Event: &event})
+}
+
+// wants reports whether the event is on a subscribed channel, about one of
+// the caller's orders unless they may see any order and, when the client
+// chose symbols, for one of them. System events are not per symbol.
+func (s *session) wants(event stream.Event) bool {
+	if !s.channels[event.Channel] {
+		return false
+	}
+	switch event.Channel {
+	case stream.ChannelOrders, stream.ChannelFills, stream.ChannelPositions:
+		// Events about orders placed without a user are for admins only
+		if !s.principal.Can(auth.PermAnyOrder) && (event.UserID == "" || event.UserID != s.principal.User) {
+			return false
+		}
+	}
+	if len(s.symbols) == 0 || event.Channel == stream.ChannelSystem {
+		return true
+	}
+	return s.symbols[strings.ToUpper(event.Symbol)]
+}
+
+func (s *session) send(message serverMessage) error {
+	s.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return s.conn.WriteJSON(message)
+}
+
+func validChannel(channel string) bool {
+	for _, known := range stream.Channels {
+		if channel == known {
+			return true
+		}
+	}
+	return false
+}
+
+func keys(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for key := range set {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return out
+}
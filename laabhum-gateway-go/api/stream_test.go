@@ -0,0 +1,339 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-gateway-go/internal/auth"
+	"github.com/Mukilan-T/laabhum-gateway-go/internal/stream"
+	"github.com/Mukilan-T/laabhum-gateway-go/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// Credentials the WebSocket tests connect with
+const (
+	adminKey      = "stream-admin-key"
+	traderKey     = "stream-trader-key"
+	jwtSecret     = "stream-test-secret-of-at-least-32-bytes"
+	allowedOrigin = "https://trade.example.com"
+)
+
+// bearer returns an HS256 token for user signed with jwtSecret
+func bearer(user string, roles ...auth.Role) string {
+	encode := func(v interface{}) string {
+		data, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	input := encode(map[string]string{"alg": "HS256", "typ": "JWT"}) + "." +
+		encode(map[string]interface{}{"sub": user, "exp": time.Now().Add(time.Hour).Unix(), "roles": roles})
+	mac := hmac.New(sha256.New, []byte(jwtSecret))
+	mac.Write([]byte(input))
+	return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// omsStream is a fake OMS event stream serving the events sent to it
+type omsStream struct {
+	events chan string
+}
+
+func (o *omsStream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+	w.(http.Flusher).Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event := <-o.events:
+			fmt.Fprint(w, event)
+			w.(http.Flusher).Flush()
+		}
+	}
+}
+
+// send writes an OMS domain event to the stream once the feed is reading it
+func (o *omsStream) send(sequence uint64, eventType, symbol string) {
+	o.sendFor(sequence, eventType, symbol, "")
+}
+
+// sendFor writes an OMS domain event about an order placed by user
+func (o *omsStream) sendFor(sequence uint64, eventType, symbol, user string) {
+	data, _ := json.Marshal(map[string]interface{}{
+		"sequence":  sequence,
+		"type":      eventType,
+		"timestamp": time.Now().UTC(),
+		"data": map[string]interface{}{
+			"schema_version": 1,
+			"symbol":         symbol,
+			"user_id":        user,
+			"data":           map[string]string{"symbol": symbol},
+		},
+	})
+	o.events <- "data: " + string(data) + "\n\n"
+}
+
+// startStream serves the WebSocket endpoint for a feed following a fake OMS
+func startStream(t *testing.T, heartbeat time.Duration) (*omsStream, *stream.Feed, string) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	log := logger.New("error")
+
+	oms := &omsStream{events: make(chan string)}
+	omsServer := httptest.NewServer(oms)
+	t.Cleanup(omsServer.Close)
+
+	feed := stream.NewFeed(log, omsServer.URL, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go feed.Run(ctx)
+
+	authenticator, err := auth.New(auth.Config{
+		APIKeys: []auth.APIKey{
+			{Name: "admin", Hash: auth.HashKey(adminKey), User: "admin-1", Roles: []auth.Role{auth.RoleAdmin}},
+			{Name: "trader", Hash: auth.HashKey(traderKey), User: "trader-1", Roles: []auth.Role{auth.RoleTrader}},
+		},
+		JWT: auth.JWTConfig{Secret: jwtSecret},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	guard := auth.NewGuard(log, authenticator, nil)
+
+	router := gin.New()
+	router.GET("/ws", auth.QueryToken("token"), guard.Authenticate(), guard.Require(auth.PermViewOrders),
+		NewStreamHandler(log, feed, []string{allowedOrigin}, heartbeat).ServeWS)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return oms, feed, "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+}
+
+// dial connects as an admin, who receives every user's events
+func dial(t *testing.T, url string) *websocket.Conn {
+	t.Helper()
+	return dialAs(t, url, adminKey)
+}
+
+func dialAs(t *testing.T, url, key string) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial(url, http.Header{auth.APIKeyHeader: {key}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// next reads the next message from the server, skipping heartbeats
+func next(t *testing.T, conn *websocket.Conn) serverMessage {
+	t.Helper()
+	for {
+		conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		var message serverMessage
+		if err := conn.ReadJSON(&message); err != nil {
+			t.Fatal(err)
+		}
+		if message.Type != messageHeartbeat {
+			return message
+		}
+	}
+}
+
+func subscribe(t *testing.T, conn *websocket.Conn, request clientMessage) serverMessage {
+	t.Helper()
+	request.Action = actionSubscribe
+	if err := conn.WriteJSON(request); err != nil {
+		t.Fatal(err)
+	}
+	return next(t, conn)
+}
+
+// waitFor waits until the feed has published sequence events
+func waitFor(t *testing.T, feed *stream.Feed, sequence uint64) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for feed.Sequence() < sequence {
+		if time.Now().After(deadline) {
+			t.Fatalf("feed reached sequence %d, want %d", feed.Sequence(), sequence)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestStreamAuthenticatesThroughTheGuard(t *testing.T) {
+	_, _, url := startStream(t, time.Minute)
+	tests := []struct {
+		name   string
+		suffix string
+		header http.Header
+		status int
+	}{
+		{"no credentials", "", nil, http.StatusUnauthorized},
+		{"wrong token", "?token=wrong", nil, http.StatusUnauthorized},
+		{"wrong API key", "", http.Header{auth.APIKeyHeader: {"wrong"}}, http.StatusUnauthorized},
+		{"no role", "?token=" + bearer("nobody"), nil, http.StatusForbidden},
+		{"origin not allowed", "", http.Header{auth.APIKeyHeader: {traderKey}, "Origin": {"https://evil.example.com"}}, http.StatusForbidden},
+		{"token in the query", "?token=" + bearer("trader-1", auth.RoleTrader), nil, http.StatusSwitchingProtocols},
+		{"allowed origin", "", http.Header{auth.APIKeyHeader: {traderKey}, "Origin": {allowedOrigin}}, http.StatusSwitchingProtocols},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conn, resp, err := websocket.DefaultDialer.Dial(url+tc.suffix, tc.header)
+			if conn != nil {
+				conn.Close()
+			}
+			if resp == nil || resp.StatusCode != tc.status {
+				t.Errorf("got %v (%v), want %d", resp, err, tc.status)
+			}
+		})
+	}
+}
+
+func TestStreamDeliversOnlyTheCallersOrders(t *testing.T) {
+	oms, feed, url := startStream(t, time.Minute)
+	trader := dialAs(t, url, traderKey)
+	admin := dial(t, url)
+	subscribe(t, trader, clientMessage{})
+	subscribe(t, admin, clientMessage{})
+
+	oms.sendFor(1, "order.created", "NSE:INFY", "trader-2")
+	oms.sendFor(2, "trade.executed", "NSE:INFY", "trader-2")
+	oms.sendFor(3, "position.updated", "NSE:INFY", "trader-2")
+	oms.send(4, "order.created", "NSE:INFY") // Placed without a user
+	oms.sendFor(5, "order.created", "NSE:INFY", "trader-1")
+	oms.send(6, "tick", "NSE:INFY")
+	waitFor(t, feed, 6)
+
+	for _, want := range []uint64{5, 6} {
+		if message := next(t, trader); message.Seq != want || message.Event == nil {
+			t.Fatalf("trader got %+v, want event %d", message, want)
+		}
+	}
+	for want := uint64(1); want <= 6; want++ {
+		if message := next(t, admin); message.Seq != want {
+			t.Fatalf("admin got %+v, want event %d", message, want)
+		}
+	}
+}
+
+func TestStreamDeliversSubscribedEvents(t *testing.T) {
+	oms, _, url := startStream(t, time.Minute)
+	conn := dial(t, url)
+
+	subscribed := subscribe(t, conn, clientMessage{Channels: []string{stream.ChannelOrders}, Symbols: []string{"nse:infy"}})
+	if subscribed.Type != messageSubscribed || len(subscribed.Symbols) != 1 || subscribed.Symbols[0] != "NSE:INFY" {
+		t.Fatalf("subscribe: %+v", subscribed)
+	}
+
+	oms.send(1, "order.created", "NSE:INFY")
+	oms.send(2, "tick", "NSE:INFY")         // Another channel
+	oms.send(3, "order.created", "NSE:TCS") // Another symbol
+	oms.send(4, "order.executed", "NSE:INFY")
+
+	for _, want := range []struct {
+		seq       uint64
+		eventType string
+	}{{1, "order.created"}, {4, "order.executed"}} {
+		message := next(t, conn)
+		if message.Type != messageEvent || message.Seq != want.seq || message.Event == nil {
+			t.Fatalf("got %+v, want event %d", message, want.seq)
+		}
+		event := message.Event
+		if event.Type != want.eventType || event.Channel != stream.ChannelOrders || event.Symbol != "NSE:INFY" || event.OMSSequence != want.seq {
+			t.Errorf("event %d: %+v", want.seq, event)
+		}
+		if string(event.Data) != `{"symbol":"NSE:INFY"}` {
+			t.Errorf("event %d carries %s, want the payload unwrapped from the envelope", want.seq, event.Data)
+		}
+	}
+
+	// Events the OMS no longer retains tell every client to resynchronise
+	oms.events <- "event: gap\ndata: 4\n\n"
+	if message := next(t, conn); message.Type != messageResync || message.Seq != 5 {
+		t.Errorf("after a gap got %+v, want resync at 5", message)
+	}
+}
+
+func TestStreamResumesAfterSequence(t *testing.T) {
+	oms, feed, url := startStream(t, time.Minute)
+	oms.send(1, "order.created", "NSE:INFY")
+	oms.send(2, "trade.executed", "NSE:INFY")
+	oms.send(3, "position.opened", "NSE:INFY")
+	waitFor(t, feed, 3)
+
+	conn := dial(t, url)
+	since := uint64(1)
+	if subscribed := subscribe(t, conn, clientMessage{Since: &since}); subscribed.Type != messageSubscribed || subscribed.Seq != 3 {
+		t.Fatalf("subscribe: %+v", subscribed)
+	}
+	for _, want := range []uint64{2, 3} {
+		if message := next(t, conn); message.Type != messageEvent || message.Seq != want {
+			t.Fatalf("got %+v, want event %d from the history", message, want)
+		}
+	}
+	oms.send(4, "order.created", "NSE:TCS")
+	if message := next(t, conn); message.Type != messageEvent || message.Seq != 4 {
+		t.Errorf("got %+v, want live event 4", message)
+	}
+
+	// A sequence this feed never issued cannot be resumed from
+	stale := dial(t, url)
+	unknown := uint64(99)
+	subscribe(t, stale, clientMessage{Since: &unknown})
+	if message := next(t, stale); message.Type != messageResync || message.Seq != 99 {
+		t.Errorf("resuming from 99 got %+v, want resync", message)
+	}
+}
+
+func TestStreamClientMessages(t *testing.T) {
+	_, _, url := startStream(t, time.Minute)
+	conn := dial(t, url)
+
+	tests := []struct {
+		name    string
+		request string
+		reply   string
+		want    string
+	}{
+		{"ping", `{"action":"ping"}`, messagePong, ""},
+		{"unknown channel", `{"action":"subscribe","channels":["quotes"]}`, messageError, "unknown channel quotes"},
+		{"unknown action", `{"action":"watch"}`, messageError, "unknown action watch"},
+		{"not JSON", `hello`, messageError, "invalid message, expected a JSON object with an action"},
+		{"unsubscribe", `{"action":"unsubscribe"}`, messageUnsubscribed, ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(tc.request)); err != nil {
+				t.Fatal(err)
+			}
+			if message := next(t, conn); message.Type != tc.reply || message.Error != tc.want {
+				t.Errorf("got %+v, want %s %q", message, tc.reply, tc.want)
+			}
+		})
+	}
+}
+
+func TestStreamSendsHeartbeats(t *testing.T) {
+	oms, feed, url := startStream(t, 20*time.Millisecond)
+	oms.send(1, "order.created", "NSE:INFY")
+	waitFor(t, feed, 1)
+	conn := dial(t, url)
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var message serverMessage
+	if err := conn.ReadJSON(&message); err != nil {
+		t.Fatal(err)
+	}
+	if message.Type != messageHeartbeat || message.Seq != 1 || message.Time == "" {
+		t.Errorf("got %+v, want a heartbeat at sequence 1", message)
+	}
+}
@@ -0,0 +1,46 @@
+// Command apikey issues a gateway API key. The key is printed once; only its
+// hash goes into the auth.api_keys section of the gateway config.
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Mukilan-T/laabhum-gateway-go/internal/auth"
+	"gopkg.in/yaml.v3"
+)
+
+func main() {
+	name := flag.String("name", "", "name the key is logged under")
+	user := flag.String("user", "", "user the key authenticates as")
+	account := flag.String("account", "", "trading account orders placed with the key belong to")
+	roleList := flag.String("roles", string(auth.RoleTrader), "comma-separated roles: trader, risk or admin")
+	flag.Parse()
+	if *name == "" || *user == "" {
+		log.Fatalf("-name and -user are required")
+	}
+	var roles []auth.Role
+	for _, role := range strings.Split(*roleList, ",") {
+		if role := auth.Role(strings.TrimSpace(role)); role.IsValid() {
+			roles = append(roles, role)
+		} else {
+			log.Fatalf("Unknown role %q", role)
+		}
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatalf("Generating key: %v", err)
+	}
+	key := "lk_" + base64.RawURLEncoding.EncodeToString(secret)
+
+	entry, err := yaml.Marshal([]auth.APIKey{{Name: *name, Hash: auth.HashKey(key), User: *user, Account: *account, Roles: roles}})
+	if err != nil {
+		log.Fatalf("Encoding config entry: %v", err)
+	}
+	fmt.Printf("API key (shown once, send as %s): %s\n\nAdd to auth.api_keys:\n%s", auth.APIKeyHeader, key, entry)
+}
@@ -0,0 +1,48 @@
+// Command contracts writes the pact of requests the gateway expects the OMS to
+// serve. The OMS replays it against its router in its contract tests.
+package main
+
+import (
+	"flag"
+	"log"
+	"reflect"
+
+	"github.com/Mukilan-T/laabhum-gateway-go/internal/oms"
+)
+
+// uncovered are Client methods that make no request of the OMS
+var uncovered = map[string]bool{"Close": true, "SetAddress": true, "SetResilience": true, "OpenCircuits": true}
+
+func main() {
+	out := flag.String("out", "../laabhum-api-go/contract/pacts", "directory the pact is written to")
+	flag.Parse()
+
+	pact := oms.Contract()
+	described := make(map[string]bool, len(pact.Interactions))
+	for _, interaction := range pact.Interactions {
+		if described[interaction.Description] {
+			log.Fatalf("Client.%s has more than one interaction", interaction.Description)
+		}
+		described[interaction.Description] = true
+	}
+
+	// Every call the client can make must be in the pact, or drift in it goes unverified
+	client := reflect.TypeOf(&oms.Client{})
+	missing := 0
+	for i := 0; i < client.NumMethod(); i++ {
+		name := client.Method(i).Name
+		if !uncovered[name] && !described[name] {
+			log.Printf("Client.%s has no interaction in oms.Contract", name)
+			missing++
+		}
+	}
+	if missing > 0 {
+		log.Fatalf("%d client methods are not covered by the contract", missing)
+	}
+
+	path, err := pact.Write(*out)
+	if err != nil {
+		log.Fatalf("Writing pact: %v", err)
+	}
+	log.Printf("Wrote %d interactions to %s", len(pact.Interactions), path)
+}
@@ -2,8 +2,7 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+	"flag"
 	"log"
 	"net/http"
 	"os"
@@ -11,64 +10,76 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Mukilan-T/laabhum-gateway-go/api"
 	"github.com/Mukilan-T/laabhum-gateway-go/config"
+	"github.com/Mukilan-T/laabhum-gateway-go/internal/auth"
 	"github.com/Mukilan-T/laabhum-gateway-go/internal/oms"
+	"github.com/Mukilan-T/laabhum-gateway-go/internal/ratelimit"
+	"github.com/Mukilan-T/laabhum-gateway-go/internal/stream"
 	"github.com/Mukilan-T/laabhum-gateway-go/pkg/logger"
 	"github.com/Mukilan-T/laabhum-gateway-go/routes"
 )
 
-// Order struct represents an order in the system.
-type Order struct {
-	ID                string               `json:"id"`
-	Symbol            string               `json:"symbol"`
-	Quantity          int                  `json:"quantity"`
-	Price             float64              `json:"price"`
-	Side              string               `json:"side"` 
-	Type              string               `json:"type"`
-	Status            string               `json:"status"` 
-	StopPrice         float64              `json:"stop_price,omitempty"` 
-	Strategy          string               `json:"strategy"`
-	RiskPercentage    float64              `json:"risk_percentage"` 
-	StopLossActivated bool                 `json:"stop_loss_activated"` 
-	TakeProfit        float64              `json:"take_profit"` 
-	CreatedAt         int64                `json:"created_at"` 
-	ExpiresAt         time.Time            `json:"expires_at,omitempty"` 
-	ParentID          string               `json:"parent_id"`
-}
-
 func main() {
-	cfg := config.LoadConfig()
-	if cfg == nil {
-		log.Fatalf("Failed to load configuration")
+	configFlag := flag.String("config", "", "YAML configuration file (default "+config.DefaultPath+" when present; env GATEWAY_CONFIG)")
+	flag.Parse()
+
+	configPath, explicit := config.Path(*configFlag)
+	cfg, err := config.Load(configPath, explicit)
+	if err != nil {
+		log.Fatalf("Configuration: %v", err)
 	}
-	fmt.Printf("Loaded OMS address: %s\n", cfg.Oms.BaseURL)
 
-	logLevel := cfg.LogLevel
-	customLogger := logger.New(logLevel)
+	customLogger := logger.New(cfg.LogLevel)
 
 	stdLogger := log.New(customLogger.Writer(), "", log.LstdFlags)
+	stdLogger.Printf("Loaded OMS address: %s (gRPC %s)", cfg.Oms.BaseURL, cfg.Oms.GRPCAddress)
 
-	omsClient := oms.NewClient(cfg.Oms.BaseURL)
-	if omsClient == nil {
-		stdLogger.Fatalf("Failed to create OMS client")
+	omsClient, err := oms.NewClient(cfg.Oms.GRPCAddress, cfg.Oms.Credentials, cfg.Oms.Resilience)
+	if err != nil {
+		stdLogger.Fatalf("Failed to create OMS client: %v", err)
 	}
+	defer omsClient.Close()
+
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
 
-	// Fetch existing orders
-	ordersData, err := omsClient.GetOrders()
+	feed := stream.NewFeed(customLogger, cfg.Oms.BaseURL, cfg.WebSocket.History)
+	go feed.Run(ctx)
+	streams := api.NewStreamHandler(customLogger, feed, cfg.WebSocket.AllowedOrigins, cfg.WebSocket.Heartbeat)
+
+	authenticator, err := auth.New(cfg.Auth)
 	if err != nil {
-		stdLogger.Fatalf("Failed to get orders: %v", err)
+		stdLogger.Fatalf("Authentication: %v", err)
 	}
-
-	var orders []Order
-	if err := json.Unmarshal(ordersData, &orders); err != nil {
-		stdLogger.Fatalf("Failed to unmarshal orders: %v", err)
+	if !cfg.Auth.Enabled() {
+		stdLogger.Printf("No API keys or JWT settings configured; /oms will refuse all requests")
 	}
 
-	for _, order := range orders {
-		stdLogger.Printf("Order ID: %s, Status: %s", order.ID, order.Status)
+	var denials *auth.DenialLog
+	if cfg.Auth.DenialLog != "" {
+		if denials, err = auth.OpenDenialLog(cfg.Auth.DenialLog); err != nil {
+			stdLogger.Fatalf("Authorization: %v", err)
+		}
+		defer denials.Close()
 	}
+	guard := auth.NewGuard(customLogger, authenticator, denials)
 
-	router := routes.SetupRoutes(customLogger, omsClient)
+	limiter := ratelimit.New(cfg.RateLimits)
+
+	router := routes.SetupRoutes(customLogger, omsClient, streams, guard, limiter)
+
+	// Log level, rate limits and OMS settings follow the config file without a restart
+	go config.Watch(ctx, customLogger, configPath, explicit, cfg, func(next *config.Config) {
+		customLogger.SetLevel(next.LogLevel)
+		limiter.SetConfig(next.RateLimits)
+		omsClient.SetResilience(next.Oms.Resilience)
+		if err := omsClient.SetAddress(next.Oms.GRPCAddress); err != nil {
+			customLogger.Errorf("Moving OMS client to %s: %v", next.Oms.GRPCAddress, err)
+		}
+		feed.SetBaseURL(next.Oms.BaseURL)
+		stdLogger.Printf("Config reloaded: log level %s, OMS %s (gRPC %s)", next.LogLevel, next.Oms.BaseURL, next.Oms.GRPCAddress)
+	})
 
 	srv := &http.Server{
 		Addr:    cfg.ServerAddress,
@@ -88,6 +99,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	stdLogger.Println("Shutting down server...")
+	stop()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -1,34 +1,255 @@
+// Package config loads the gateway configuration from a YAML file with
+// GATEWAY_* environment variables layered over it, and reloads the settings
+// that are safe to change while the gateway runs.
 package config
 
 import (
-	"log"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
 	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/Mukilan-T/laabhum-gateway-go/internal/auth"
+	"github.com/Mukilan-T/laabhum-gateway-go/internal/oms"
+	"github.com/Mukilan-T/laabhum-gateway-go/internal/ratelimit"
 	"gopkg.in/yaml.v3"
 )
 
+// DefaultPath is the file read when no -config flag or GATEWAY_CONFIG is
+// given. Unlike an explicit path, it may be missing.
+const DefaultPath = "config.yaml"
+
+// Config is everything the gateway is started with
 type Config struct {
 	Oms struct {
+		// BaseURL is the OMS HTTP API the event stream is followed on
 		BaseURL string `yaml:"baseURL"`
+		// GRPCAddress is the host:port of the OMS gRPC API order calls are made on
+		GRPCAddress string `yaml:"grpcAddress"`
+		// Credentials authenticate order calls; they change on restart only
+		Credentials oms.Credentials `yaml:",inline"`
+		// Resilience sets the timeout, retries and circuit breakers of OMS calls
+		Resilience oms.Resilience `yaml:",inline"`
 	} `yaml:"oms"`
-	LogLevel     string `yaml:"log_level"`
-	OMSAddress   string `yaml:"oms_address"`
+	LogLevel      string `yaml:"log_level"`
 	ServerAddress string `yaml:"server_address"`
+	WebSocket     struct {
+		// AllowedOrigins are the browser origins /ws accepts, such as
+		// https://trade.example.com; clients that send no Origin are accepted
+		AllowedOrigins []string      `yaml:"allowed_origins"`
+		Heartbeat      time.Duration `yaml:"heartbeat"` // Zero for the default
+		History        int           `yaml:"history"`   // Zero for the default
+	} `yaml:"websocket"`
+	// Auth lists the API keys and JWT settings /oms callers authenticate
+	// with; none means every /oms request is refused
+	Auth auth.Config `yaml:"auth"`
+	// RateLimits are the request budgets of /oms callers
+	RateLimits ratelimit.Config `yaml:"rate_limits"`
+}
+
+// Default returns the configuration the gateway runs with when nothing is set
+func Default() Config {
+	var c Config
+	c.Oms.BaseURL = "http://localhost:8081"
+	c.Oms.GRPCAddress = "localhost:9091"
+	c.Oms.Resilience = oms.DefaultResilience
+	c.LogLevel = "info"
+	c.ServerAddress = ":8080"
+	c.RateLimits = ratelimit.DefaultConfig
+	return c
+}
+
+// Load reads the YAML file at path over the defaults, applies GATEWAY_*
+// environment overrides and validates the result, reporting every problem
+// at once. A missing file is only an error when explicit is set.
+func Load(path string, explicit bool) (*Config, error) {
+	c := Default()
+	if err := c.readFile(path, explicit); err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, o := range overrides {
+		if value, ok := os.LookupEnv(o.env); ok {
+			if err := o.set(&c, value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", o.env, err))
+			}
+		}
+	}
+	if err := c.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return &c, nil
 }
 
-func LoadConfig() *Config {
-	file, err := os.Open("config.yaml")
+// Path returns the config file to load given the -config flag: the flag,
+// then GATEWAY_CONFIG, then DefaultPath. explicit is false for the default.
+func Path(flagValue string) (path string, explicit bool) {
+	if flagValue != "" {
+		return flagValue, true
+	}
+	if env := os.Getenv("GATEWAY_CONFIG"); env != "" {
+		return env, true
+	}
+	return DefaultPath, false
+}
+
+func (c *Config) readFile(path string, explicit bool) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return nil
+	}
 	if err != nil {
-		log.Fatalf("Error opening config file: %v", err)
+		return fmt.Errorf("opening config file: %w", err)
 	}
 	defer file.Close()
 
-	var cfg Config
 	decoder := yaml.NewDecoder(file)
-	err = decoder.Decode(&cfg)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("decoding config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate reports every invalid setting
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(name string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		check("log_level", fmt.Errorf("unknown level %q, want debug, info, warn or error", c.LogLevel))
+	}
+	check("server_address", address(c.ServerAddress))
+	check("oms.baseURL", httpURL(c.Oms.BaseURL))
+	check("oms.grpcAddress", address(c.Oms.GRPCAddress))
+	check("oms", c.Oms.Credentials.Validate())
+	check("oms", c.Oms.Resilience.Validate())
+	if c.WebSocket.Heartbeat < 0 {
+		check("websocket.heartbeat", errors.New("must not be negative"))
+	}
+	if c.WebSocket.History < 0 {
+		check("websocket.history", errors.New("must not be negative"))
+	}
+	for _, origin := range c.WebSocket.AllowedOrigins {
+		if err := httpURL(origin); err != nil {
+			check("websocket.allowed_origins", err)
+		}
+	}
+	_, err := auth.New(c.Auth)
+	check("auth", err)
+	if c.Auth.Enabled() && c.Oms.Credentials.ServiceToken == "" {
+		// The OMS would place every authenticated caller's orders anonymously
+		check("oms.serviceToken", errors.New("required when auth is configured"))
+	}
+	check("rate_limits", c.RateLimits.Validate())
+	return errors.Join(errs...)
+}
+
+// RestartRequired names the settings that differ between c and next but
+// only take effect when the gateway restarts
+func (c *Config) RestartRequired(next *Config) []string {
+	var changed []string
+	if c.ServerAddress != next.ServerAddress {
+		changed = append(changed, "server_address")
+	}
+	if strings.Join(c.WebSocket.AllowedOrigins, "\x00") != strings.Join(next.WebSocket.AllowedOrigins, "\x00") {
+		changed = append(changed, "websocket.allowed_origins")
+	}
+	if c.WebSocket.Heartbeat != next.WebSocket.Heartbeat {
+		changed = append(changed, "websocket.heartbeat")
+	}
+	if c.WebSocket.History != next.WebSocket.History {
+		changed = append(changed, "websocket.history")
+	}
+	if !reflect.DeepEqual(c.Auth, next.Auth) {
+		changed = append(changed, "auth")
+	}
+	if c.Oms.Credentials.ServiceToken != next.Oms.Credentials.ServiceToken {
+		changed = append(changed, "oms.serviceToken")
+	}
+	if c.Oms.Credentials.TLS != next.Oms.Credentials.TLS {
+		changed = append(changed, "oms.tls")
+	}
+	if c.Oms.Credentials.CAFile != next.Oms.Credentials.CAFile {
+		changed = append(changed, "oms.caFile")
+	}
+	return changed
+}
+
+func address(value string) error {
+	if value == "" {
+		return errors.New("required")
+	}
+	_, _, err := net.SplitHostPort(value)
+	return err
+}
+
+func httpURL(value string) error {
+	if value == "" {
+		return errors.New("required")
+	}
+	u, err := url.Parse(value)
 	if err != nil {
-		log.Fatalf("Error decoding config file: %v", err)
+		return err
 	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("scheme %q is not http or https", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q has no host", value)
+	}
+	return nil
+}
+
+// override is a setting that can be given as an environment variable
+type override struct {
+	env string
+	set func(c *Config, value string) error
+}
 
-	return &cfg
+var overrides = []override{
+	{"GATEWAY_LOG_LEVEL", func(c *Config, v string) error { c.LogLevel = v; return nil }},
+	{"GATEWAY_SERVER_ADDRESS", func(c *Config, v string) error { c.ServerAddress = v; return nil }},
+	{"GATEWAY_OMS_BASE_URL", func(c *Config, v string) error { c.Oms.BaseURL = v; return nil }},
+	{"GATEWAY_OMS_GRPC_ADDRESS", func(c *Config, v string) error { c.Oms.GRPCAddress = v; return nil }},
+	{"GATEWAY_OMS_SERVICE_TOKEN", func(c *Config, v string) error { c.Oms.Credentials.ServiceToken = v; return nil }},
+	{"GATEWAY_OMS_TIMEOUT", func(c *Config, v string) (err error) {
+		c.Oms.Resilience.Timeout, err = time.ParseDuration(v)
+		return err
+	}},
+	{"GATEWAY_WEBSOCKET_ALLOWED_ORIGINS", func(c *Config, v string) error {
+		// Comma-separated; an empty value clears the file's origins
+		c.WebSocket.AllowedOrigins = nil
+		for _, origin := range strings.Split(v, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				c.WebSocket.AllowedOrigins = append(c.WebSocket.AllowedOrigins, origin)
+			}
+		}
+		return nil
+	}},
+	{"GATEWAY_AUTH_JWT_SECRET", func(c *Config, v string) error { c.Auth.JWT.Secret = v; return nil }},
+	{"GATEWAY_WEBSOCKET_HEARTBEAT", func(c *Config, v string) (err error) {
+		c.WebSocket.Heartbeat, err = time.ParseDuration(v)
+		return err
+	}},
+	{"GATEWAY_WEBSOCKET_HISTORY", func(c *Config, v string) (err error) {
+		c.WebSocket.History, err = strconv.Atoi(v)
+		return err
+	}},
 }
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-gateway-go/pkg/logger"
+)
+
+// pollInterval is how often Watch checks the config file for changes
+const pollInterval = 2 * time.Second
+
+// Watch reloads the config file at path on SIGHUP and whenever the file
+// changes, until ctx is cancelled. Each configuration that loads and
+// validates is passed to apply; one that does not is logged and the current
+// one kept. Changes to settings that need a restart are reported and held
+// back, so apply always sees the values the gateway is running with.
+func Watch(ctx context.Context, log *logger.Logger, path string, explicit bool, current *Config, apply func(*Config)) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	last := stat(path)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangup:
+			log.Infof("SIGHUP received, reloading %s", path)
+		case <-ticker.C:
+			info := stat(path)
+			if info == last {
+				continue
+			}
+			last = info
+			log.Infof("%s changed, reloading", path)
+		}
+
+		next, err := Load(path, explicit)
+		if err != nil {
+			log.Errorf("Reloading config, keeping the current one: %v", err)
+			continue
+		}
+		if changed := current.RestartRequired(next); len(changed) > 0 {
+			log.Warnf("Config changes to %s take effect on restart", strings.Join(changed, ", "))
+			next.ServerAddress = current.ServerAddress
+			next.WebSocket = current.WebSocket
+			next.Auth = current.Auth
+			next.Oms.Credentials = current.Oms.Credentials
+		}
+		apply(next)
+		current = next
+	}
+}
+
+// fileState is what Watch compares to notice the config file changing
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+func stat(path string) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{modTime: info.ModTime(), size: info.Size()}
+}
@@ -0,0 +1,178 @@
+// Package auth authenticates gateway callers by API key or JWT bearer token
+// and authorizes them by role. API keys are stored only as SHA-256 hashes;
+// tokens are signed with HS256 or RS256 and must carry an expiry.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Headers credentials are read from
+const (
+	APIKeyHeader        = "X-API-Key"
+	AuthorizationHeader = "Authorization"
+)
+
+// Authentication methods a Principal can have used
+const (
+	MethodAPIKey = "api_key"
+	MethodJWT    = "jwt"
+)
+
+// hashPrefix marks the algorithm of a stored API key hash
+const hashPrefix = "sha256:"
+
+// Principal is an authenticated caller
+type Principal struct {
+	User    string
+	Account string
+	Roles   []Role
+	Method  string
+	KeyName string // Name of the API key used, when Method is MethodAPIKey
+}
+
+// APIKey is a configured API key, identified by the hash of its secret
+type APIKey struct {
+	Name    string `yaml:"name"`
+	Hash    string `yaml:"hash"` // "sha256:" and the hex digest of the key
+	User    string `yaml:"user"`
+	Account string `yaml:"account"`
+	Roles   []Role `yaml:"roles"`
+}
+
+// Config lists the credentials the gateway accepts
+type Config struct {
+	APIKeys []APIKey  `yaml:"api_keys"`
+	JWT     JWTConfig `yaml:"jwt"`
+	// DenialLog is a file every refused request is appended to, when set
+	DenialLog string `yaml:"denial_log"`
+}
+
+// Enabled reports whether any credentials are configured
+func (c Config) Enabled() bool {
+	return len(c.APIKeys) > 0 || c.JWT.Enabled()
+}
+
+// HashKey returns the hash an API key is stored as
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hashPrefix + hex.EncodeToString(sum[:])
+}
+
+// Authenticator checks the credentials on gateway requests
+type Authenticator struct {
+	keys []storedKey
+	jwt  *jwtVerifier
+}
+
+type storedKey struct {
+	APIKey
+	digest []byte
+}
+
+// ErrUnauthenticated is returned for requests without valid credentials
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// New builds an Authenticator for config, reporting every invalid key and
+// JWT setting. With nothing configured every request is refused.
+func New(config Config) (*Authenticator, error) {
+	var errs []error
+	a := &Authenticator{}
+	names := make(map[string]bool)
+	hashes := make(map[string]bool)
+	for i, key := range config.APIKeys {
+		label := fmt.Sprintf("api_keys[%d]", i)
+		if key.Name != "" {
+			label = "api key " + key.Name
+		}
+		digest, err := hex.DecodeString(strings.TrimPrefix(key.Hash, hashPrefix))
+		switch {
+		case !strings.HasPrefix(key.Hash, hashPrefix) || err != nil || len(digest) != sha256.Size:
+			errs = append(errs, fmt.Errorf("%s: hash must be %q and a hex SHA-256 digest", label, hashPrefix))
+		case hashes[key.Hash]:
+			errs = append(errs, fmt.Errorf("%s: hash is used by another key", label))
+		}
+		if key.Name == "" {
+			errs = append(errs, fmt.Errorf("%s: name is required", label))
+		} else if names[key.Name] {
+			errs = append(errs, fmt.Errorf("%s: name is used by another key", label))
+		}
+		if key.User == "" {
+			errs = append(errs, fmt.Errorf("%s: user is required", label))
+		}
+		if err := checkRoles(key.Roles); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", label, err))
+		}
+		names[key.Name], hashes[key.Hash] = true, true
+		a.keys = append(a.keys, storedKey{APIKey: key, digest: digest})
+	}
+	if config.JWT.Enabled() {
+		verifier, err := newJWTVerifier(config.JWT)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("jwt: %w", err))
+		}
+		a.jwt = verifier
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return a, nil
+}
+
+// Authenticate returns the caller presenting the API key or bearer token on r
+func (a *Authenticator) Authenticate(r *http.Request) (*Principal, error) {
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		return a.apiKey(key)
+	}
+	scheme, token, found := strings.Cut(r.Header.Get(AuthorizationHeader), " ")
+	if found && strings.EqualFold(scheme, "Bearer") && token != "" {
+		if a.jwt == nil {
+			return nil, fmt.Errorf("%w: bearer tokens are not accepted", ErrUnauthenticated)
+		}
+		return a.jwt.verify(token, time.Now())
+	}
+	return nil, fmt.Errorf("%w: no API key or bearer token", ErrUnauthenticated)
+}
+
+func (a *Authenticator) apiKey(key string) (*Principal, error) {
+	sum := sha256.Sum256([]byte(key))
+	// Every stored key is compared so the time taken says nothing about which matched
+	var match *storedKey
+	for i := range a.keys {
+		if subtle.ConstantTimeCompare(sum[:], a.keys[i].digest) == 1 {
+			match = &a.keys[i]
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("%w: unknown API key", ErrUnauthenticated)
+	}
+	return &Principal{
+		User:    match.User,
+		Account: match.Account,
+		Roles:   match.Roles,
+		Method:  MethodAPIKey,
+		KeyName: match.Name,
+	}, nil
+}
+
+// principalKey is where Guard keeps the caller in the request context
+type principalKey struct{}
+
+// WithPrincipal returns ctx carrying the authenticated caller
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext returns the caller Guard authenticated, if any
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok
+}
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Denial is a request refused for missing credentials or permissions
+type Denial struct {
+	Timestamp  time.Time  `json:"timestamp"`
+	Status     int        `json:"status"`
+	Method     string     `json:"method"`
+	Path       string     `json:"path"`
+	ClientIP   string     `json:"client_ip"`
+	RequestID  string     `json:"request_id,omitempty"`
+	User       string     `json:"user,omitempty"`
+	Account    string     `json:"account,omitempty"`
+	Roles      []Role     `json:"roles,omitempty"`
+	Permission Permission `json:"permission,omitempty"`
+	Reason     string     `json:"reason"`
+}
+
+// DenialLog appends every denied request to a JSON lines file
+type DenialLog struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+// OpenDenialLog opens the denial log at path for appending
+func OpenDenialLog(path string) (*DenialLog, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening denial log: %w", err)
+	}
+	return &DenialLog{file: file}, nil
+}
+
+// Record appends denial to the log. A nil DenialLog records nothing.
+func (l *DenialLog) Record(denial Denial) error {
+	if l == nil {
+		return nil
+	}
+	line, err := json.Marshal(denial)
+	if err != nil {
+		return err
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	_, err = l.file.Write(append(line, '\n'))
+	return err
+}
+
+// Close closes the log file
+func (l *DenialLog) Close() error {
+	if l == nil {
+		return nil
+	}
+	return l.file.Close()
+}
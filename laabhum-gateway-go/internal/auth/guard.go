@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-gateway-go/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// OwnerFunc returns the user who placed an order, and false when there is
+// no such order and the handler should report it. Orders placed without a
+// named user have no owner.
+type OwnerFunc func(ctx context.Context, id string) (owner string, found bool, err error)
+
+// IDsFunc returns the IDs of the orders or positions a request acts on
+type IDsFunc func(c *gin.Context) []string
+
+// Params reads the IDs a request acts on from the named route parameters,
+// skipping those the route does not have
+func Params(names ...string) IDsFunc {
+	return func(c *gin.Context) []string {
+		var ids []string
+		for _, name := range names {
+			if id := c.Param(name); id != "" {
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+}
+
+// BodyField reads the ID a request acts on from a string field of its JSON
+// body, leaving the body for the handler. A body without it names nothing,
+// and the handler reports it as invalid.
+func BodyField(name string) IDsFunc {
+	return func(c *gin.Context) []string {
+		if c.Request.Body == nil {
+			return nil
+		}
+		body, err := io.ReadAll(c.Request.Body)
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return nil
+		}
+		var fields map[string]json.RawMessage
+		var id string
+		if json.Unmarshal(body, &fields) != nil || json.Unmarshal(fields[name], &id) != nil || id == "" {
+			return nil
+		}
+		return []string{id}
+	}
+}
+
+// Guard enforces authentication and per-route policies, logging every
+// request it refuses and recording it in the denial log
+type Guard struct {
+	logger        *logger.Logger
+	authenticator *Authenticator
+	denials       *DenialLog
+}
+
+// NewGuard returns a Guard for authenticator; denials may be nil
+func NewGuard(log *logger.Logger, authenticator *Authenticator, denials *DenialLog) *Guard {
+	return &Guard{logger: log, authenticator: authenticator, denials: denials}
+}
+
+// Authenticate refuses requests without valid credentials with 401 and makes
+// the caller available to later handlers through FromContext
+func (g *Guard) Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, err := g.authenticator.Authenticate(c.Request)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="laabhum"`)
+			g.deny(c, http.StatusUnauthorized, nil, "", err.Error(), "Invalid or missing credentials")
+			return
+		}
+		c.Request = c.Request.WithContext(WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// QueryToken moves a bearer token from the named query parameter into the
+// Authorization header for Authenticate, as browsers cannot set headers on
+// WebSocket requests. A request that already has credentials is left alone.
+func QueryToken(name string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.Query(name)
+		if token != "" && c.GetHeader(AuthorizationHeader) == "" && c.GetHeader(APIKeyHeader) == "" {
+			c.Request.Header.Set(AuthorizationHeader, "Bearer "+token)
+		}
+		c.Next()
+	}
+}
+
+// Require refuses callers whose roles do not grant permission with 403
+func (g *Guard) Require(permission Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, _ := FromContext(c.Request.Context())
+		if principal == nil || !principal.Can(permission) {
+			g.deny(c, http.StatusForbidden, principal, permission, "role does not grant "+string(permission), "Permission denied")
+			return
+		}
+		c.Next()
+	}
+}
+
+// Owner refuses callers acting on an order or position someone else owns,
+// every one ids names, unless their roles grant PermAnyOrder. Ones that do
+// not exist are left for the handler to report.
+func (g *Guard) Owner(ids IDsFunc, owner OwnerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, _ := FromContext(c.Request.Context())
+		if principal != nil && principal.Can(PermAnyOrder) {
+			c.Next()
+			return
+		}
+		for _, id := range ids(c) {
+			placedBy, found, err := owner(c.Request.Context(), id)
+			if err != nil {
+				g.logger.Errorf("Looking up the owner of %s: %v", id, err)
+				c.AbortWithStatusJSON(http.StatusBadGateway, gin.H{"error": "Could not verify order ownership"})
+				return
+			}
+			if found && (principal == nil || placedBy == "" || placedBy != principal.User) {
+				g.deny(c, http.StatusForbidden, principal, PermAnyOrder, id+" belongs to another user", "Permission denied")
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// deny refuses the request with status and message, logging reason and
+// recording it in the denial log
+func (g *Guard) deny(c *gin.Context, status int, principal *Principal, permission Permission, reason, message string) {
+	denial := Denial{
+		Timestamp:  time.Now().UTC(),
+		Status:     status,
+		Method:     c.Request.Method,
+		Path:       c.Request.URL.Path,
+		ClientIP:   c.ClientIP(),
+		RequestID:  c.GetHeader("X-Request-ID"),
+		Permission: permission,
+		Reason:     reason,
+	}
+	if principal != nil {
+		denial.User, denial.Account, denial.Roles = principal.User, principal.Account, principal.Roles
+	}
+	g.logger.Warnf("Refused %s %s from %s (user %q): %s", denial.Method, denial.Path, denial.ClientIP, denial.User, reason)
+	if err := g.denials.Record(denial); err != nil {
+		g.logger.Errorf("Recording denied request: %v", err)
+	}
+	c.AbortWithStatusJSON(status, gin.H{"error": message})
+}
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Mukilan-T/laabhum-gateway-go/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// owners is who placed each order the fake OMS knows about
+var owners = map[string]string{
+	"parent-1": "trader-1",
+	"child-1":  "trader-1",
+	"child-2":  "trader-2",
+	"legacy-1": "",
+}
+
+func ownerOf(ctx context.Context, id string) (string, bool, error) {
+	if id == "broken" {
+		return "", false, errors.New("OMS unavailable")
+	}
+	owner, ok := owners[id]
+	return owner, ok, nil
+}
+
+// serve sends a request as principal through an owner check and returns
+// the status, and the body the handler read when the check let it through
+func serve(principal *Principal, ids IDsFunc, method, target, body string) (int, string) {
+	gin.SetMode(gin.TestMode)
+	guard := NewGuard(logger.New("error"), nil, nil)
+
+	var handled string
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(WithPrincipal(c.Request.Context(), principal))
+	})
+	handler := func(c *gin.Context) {
+		data, _ := io.ReadAll(c.Request.Body)
+		handled = string(data)
+		c.Status(http.StatusOK)
+	}
+	router.Handle(method, "/order/:parentID/:childID", guard.Owner(ids, ownerOf), handler)
+	router.Handle(method, "/order", guard.Owner(ids, ownerOf), handler)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return recorder.Code, handled
+}
+
+func TestOwnerChecksEveryOrderInThePath(t *testing.T) {
+	trader := &Principal{User: "trader-1", Roles: []Role{RoleTrader}}
+	admin := &Principal{User: "admin-1", Roles: []Role{RoleAdmin}}
+	ids := Params("parentID", "childID")
+
+	tests := []struct {
+		name      string
+		principal *Principal
+		target    string
+		status    int
+	}{
+		{"own parent and child", trader, "/order/parent-1/child-1", http.StatusOK},
+		// The OMS acts on the child whatever parent is named with it
+		{"own parent, another user's child", trader, "/order/parent-1/child-2", http.StatusForbidden},
+		{"order placed without a user", trader, "/order/parent-1/legacy-1", http.StatusForbidden},
+		{"unknown order", trader, "/order/parent-1/missing", http.StatusOK},
+		{"owner unavailable", trader, "/order/parent-1/broken", http.StatusBadGateway},
+		{"admin", admin, "/order/parent-1/child-2", http.StatusOK},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if status, _ := serve(tc.principal, ids, http.MethodPost, tc.target, ""); status != tc.status {
+				t.Errorf("got %d, want %d", status, tc.status)
+			}
+		})
+	}
+}
+
+func TestOwnerChecksTheOrderInTheBody(t *testing.T) {
+	trader := &Principal{User: "trader-1", Roles: []Role{RoleTrader}}
+	ids := BodyField("id")
+
+	if status, _ := serve(trader, ids, http.MethodDelete, "/order", `{"id": "child-2"}`); status != http.StatusForbidden {
+		t.Errorf("cancelling another user's order: %d, want 403", status)
+	}
+	body := `{"id": "child-1", "type": "LIMIT"}`
+	status, handled := serve(trader, ids, http.MethodDelete, "/order", body)
+	if status != http.StatusOK || handled != body {
+		t.Errorf("cancelling an own order: %d with body %q passed on, want 200 with %q", status, handled, body)
+	}
+}
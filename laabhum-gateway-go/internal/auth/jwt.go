@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// JWTConfig configures bearer tokens. Tokens are HS256 when Secret is set
+// and RS256 when PublicKey is; exactly one of them may be.
+type JWTConfig struct {
+	Secret    string        `yaml:"secret"`     // HS256 shared secret
+	PublicKey string        `yaml:"public_key"` // RS256 PEM public key file
+	Issuer    string        `yaml:"issuer"`     // Required iss claim, when set
+	Audience  string        `yaml:"audience"`   // Required aud claim, when set
+	Leeway    time.Duration `yaml:"leeway"`     // Clock skew allowed on exp and nbf
+}
+
+// Enabled reports whether bearer tokens are accepted
+func (c JWTConfig) Enabled() bool {
+	return c.Secret != "" || c.PublicKey != ""
+}
+
+// minSecret is the shortest HS256 secret accepted, the size of the hash
+const minSecret = sha256.Size
+
+type jwtVerifier struct {
+	config    JWTConfig
+	algorithm string
+	publicKey *rsa.PublicKey
+}
+
+func newJWTVerifier(config JWTConfig) (*jwtVerifier, error) {
+	switch {
+	case config.Secret != "" && config.PublicKey != "":
+		return nil, errors.New("set either secret (HS256) or public_key (RS256), not both")
+	case config.Secret != "":
+		if len(config.Secret) < minSecret {
+			return nil, fmt.Errorf("secret must be at least %d bytes", minSecret)
+		}
+		return &jwtVerifier{config: config, algorithm: "HS256"}, nil
+	}
+
+	data, err := os.ReadFile(config.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("reading public key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s holds no PEM block", config.PublicKey)
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		if parsed, err = x509.ParsePKCS1PublicKey(block.Bytes); err != nil {
+			return nil, fmt.Errorf("parsing public key %s: %w", config.PublicKey, err)
+		}
+	}
+	publicKey, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an RSA public key", config.PublicKey)
+	}
+	return &jwtVerifier{config: config, algorithm: "RS256", publicKey: publicKey}, nil
+}
+
+// claims are the JWT claims the gateway reads
+type claims struct {
+	Subject   string     `json:"sub"`
+	Account   string     `json:"account"`
+	Roles     stringList `json:"roles"`
+	Issuer    string     `json:"iss"`
+	Audience  stringList `json:"aud"`
+	ExpiresAt *int64     `json:"exp"`
+	NotBefore *int64     `json:"nbf"`
+}
+
+// stringList is a claim that may be a single string or a list, like aud
+type stringList []string
+
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var single string
+	if json.Unmarshal(data, &single) == nil {
+		*l = stringList{single}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(l))
+}
+
+// verify checks the token's signature and claims as of now
+func (v *jwtVerifier) verify(token string, now time.Time) (*Principal, error) {
+	fail := func(reason string, args ...interface{}) (*Principal, error) {
+		return nil, fmt.Errorf("%w: %s", ErrUnauthenticated, fmt.Sprintf(reason, args...))
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return fail("malformed token")
+	}
+	var header struct {
+		Algorithm string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return fail("malformed token header: %v", err)
+	}
+	// The algorithm is fixed by configuration, never chosen by the token
+	if header.Algorithm != v.algorithm {
+		return fail("token is signed with %q, want %s", header.Algorithm, v.algorithm)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fail("malformed token signature")
+	}
+	if !v.signed(parts[0]+"."+parts[1], signature) {
+		return fail("invalid token signature")
+	}
+
+	var c claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return fail("malformed token claims: %v", err)
+	}
+	leeway := v.config.Leeway
+	switch {
+	case c.ExpiresAt == nil:
+		return fail("token has no expiry")
+	case now.After(time.Unix(*c.ExpiresAt, 0).Add(leeway)):
+		return fail("token expired at %s", time.Unix(*c.ExpiresAt, 0).UTC().Format(time.RFC3339))
+	case c.NotBefore != nil && now.Before(time.Unix(*c.NotBefore, 0).Add(-leeway)):
+		return fail("token is not valid yet")
+	case c.Subject == "":
+		return fail("token has no subject")
+	case v.config.Issuer != "" && c.Issuer != v.config.Issuer:
+		return fail("token issuer %q is not %q", c.Issuer, v.config.Issuer)
+	case v.config.Audience != "" && !c.Audience.has(v.config.Audience):
+		return fail("token is not for audience %q", v.config.Audience)
+	}
+	// Roles the gateway does not know grant nothing
+	var roles []Role
+	for _, name := range c.Roles {
+		if role := Role(name); role.IsValid() {
+			roles = append(roles, role)
+		}
+	}
+	return &Principal{User: c.Subject, Account: c.Account, Roles: roles, Method: MethodJWT}, nil
+}
+
+func (v *jwtVerifier) signed(input string, signature []byte) bool {
+	switch v.algorithm {
+	case "HS256":
+		mac := hmac.New(sha256.New, []byte(v.config.Secret))
+		mac.Write([]byte(input))
+		return hmac.Equal(signature, mac.Sum(nil))
+	case "RS256":
+		digest := sha256.Sum256([]byte(input))
+		return rsa.VerifyPKCS1v15(v.publicKey, crypto.SHA256, digest[:], signature) == nil
+	}
+	return false
+}
+
+func (l stringList) has(want string) bool {
+	for _, aud := range l {
+		if aud == want {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
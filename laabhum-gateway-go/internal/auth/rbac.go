@@ -0,0 +1,75 @@
+package auth
+
+import "fmt"
+
+// Role is a set of permissions granted to a caller
+type Role string
+
+const (
+	RoleTrader Role = "trader" // Places and manages their own orders
+	RoleRisk   Role = "risk"   // Changes limits and uses the kill switch
+	RoleAdmin  Role = "admin"  // Manages accounts and config, and acts on any order
+)
+
+// Permission is something a route policy can require
+type Permission string
+
+const (
+	PermViewOrders     Permission = "orders:view"     // Read orders, trades, positions and market data
+	PermTrade          Permission = "orders:trade"    // Place, modify, execute, exit and cancel orders
+	PermAnyOrder       Permission = "orders:any"      // Act on orders placed by someone else
+	PermManageLimits   Permission = "risk:limits"     // Change risk limits
+	PermKillSwitch     Permission = "risk:killswitch" // Halt trading
+	PermManageAccounts Permission = "admin:accounts"  // Create and change trading accounts
+	PermManageConfig   Permission = "admin:config"    // Change gateway and OMS configuration
+	PermViewMetrics    Permission = "metrics:view"    // Read gateway metrics, such as rate limit state
+)
+
+// permissions are what each role grants
+var permissions = map[Role][]Permission{
+	RoleTrader: {PermViewOrders, PermTrade},
+	RoleRisk:   {PermViewOrders, PermManageLimits, PermKillSwitch, PermViewMetrics},
+	RoleAdmin: {
+		PermViewOrders, PermTrade, PermAnyOrder,
+		PermManageLimits, PermKillSwitch, PermManageAccounts, PermManageConfig, PermViewMetrics,
+	},
+}
+
+// IsValid reports whether r is a known role
+func (r Role) IsValid() bool {
+	_, ok := permissions[r]
+	return ok
+}
+
+// Grants reports whether r carries permission
+func (r Role) Grants(permission Permission) bool {
+	for _, granted := range permissions[r] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Can reports whether any of the principal's roles carries permission
+func (p *Principal) Can(permission Permission) bool {
+	for _, role := range p.Roles {
+		if role.Grants(permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkRoles rejects roles that are unknown or missing
+func checkRoles(roles []Role) error {
+	if len(roles) == 0 {
+		return fmt.Errorf("at least one role is required (%s, %s or %s)", RoleTrader, RoleRisk, RoleAdmin)
+	}
+	for _, role := range roles {
+		if !role.IsValid() {
+			return fmt.Errorf("unknown role %q", role)
+		}
+	}
+	return nil
+}
@@ -1,94 +1,174 @@
 package oms
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"log"
-	"net/http"
-
+    "context"
+    "errors"
+    "fmt"
+    "sync"
     "time"
 
-    "github.com/gin-gonic/gin"
+    omsv1 "github.com/Mukilan-T/laabhum-api-go/oms/v1"
+    orderv1 "github.com/Mukilan-T/laabhum-api-go/order/v1"
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/backoff"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/protobuf/types/known/timestamppb"
 )
 
-type OrderType string
-type OrderStatus string
-type TradeStrategy string
+// Order is the order of the shared contract. Its enums decode strictly,
+// accepting the casings earlier clients sent.
+type Order = orderv1.Order
 
-const (
-    LIMIT  OrderType = "LIMIT"
-    MARKET OrderType = "MARKET"
-    STOP   OrderType = "STOP"
-)
+// Client calls the OMS gRPC API. Requests and replies are the typed messages
+// of laabhum-api-go, so a call the OMS does not serve fails to compile. Every
+// call gets a deadline, idempotent ones are retried and each method has a
+// circuit breaker, as set by its Resilience.
+type Client struct {
+    mutex      sync.RWMutex
+    address    string
+    dial       []grpc.DialOption
+    conn       *grpc.ClientConn
+    client     omsv1.OrderManagementClient
+    resilience Resilience
+    circuits   circuits
+}
+
+// drainTimeout is how long calls in flight on a replaced connection are
+// given before it is closed
+const drainTimeout = 30 * time.Second
+
+// NewClient creates a new OMS client for the gRPC API at address,
+// authenticating with credentials. The connection is made on the first call
+// and re-established as needed.
+func NewClient(address string, credentials Credentials, resilience Resilience) (*Client, error) {
+    dial, err := credentials.dialOptions()
+    if err != nil {
+        return nil, err
+    }
+    c := &Client{dial: dial, resilience: resilience, circuits: circuits{breakers: make(map[string]*breaker)}}
+    if err := c.SetAddress(address); err != nil {
+        return nil, err
+    }
+    return c, nil
+}
 
-const (
-    PENDING   OrderStatus = "PENDING"
-    EXECUTED  OrderStatus = "EXECUTED"
-    CANCELLED OrderStatus = "CANCELLED"
-)
-const (
-    SIDE_BUY           = "buy"
-    SIDE_SELL          = "sell"
-    TYPE_LIMIT         = "LIMIT"
-    TYPE_MARKET        = "MARKET"
-    TYPE_STOP          = "STOP"
-    STRATEGY_SCALPING  = "scalping"
-)
-type Order struct {
-    ID                string          `json:"id"`
-    Symbol            string          `json:"symbol"`
-    Quantity          int             `json:"quantity"`
-    Price             float64         `json:"price"`
-    Side              string          `json:"side"` // "buy" or "sell"
-    Type              OrderType       `json:"type"` // LIMIT, MARKET, STOP
-    Status            OrderStatus     `json:"status"` // PENDING, EXECUTED, CANCELLED
-    StopPrice         float64         `json:"stop_price,omitempty"` // Stop Order Price (optional)
-    Strategy          TradeStrategy   `json:"strategy"` // Trading strategy (e.g. scalping, day trading)
-    RiskPercentage    float64         `json:"risk_percentage"` // % of capital risked
-    StopLossActivated bool            `json:"stop_loss_activated"` // Add this field
-    TakeProfit        float64         `json:"take_profit"` // Take profit level
-    CreatedAt         int64           `json:"created_at"` // Timestamp for when the order is created
-    ExpiresAt         time.Time       `json:"expires_at,omitempty"` // Optional expiry time for order
-    ParentID          string          `json:"parent_id"` // Add ParentID field
+// connectParams bound how long connecting to the OMS may take and how
+// quickly reconnects back off while it is down
+var connectParams = grpc.ConnectParams{
+    Backoff: backoff.Config{
+        BaseDelay:  500 * time.Millisecond,
+        Multiplier: 1.6,
+        Jitter:     0.2,
+        MaxDelay:   10 * time.Second,
+    },
+    MinConnectTimeout: 5 * time.Second,
 }
 
-type Client struct {
-    BaseURL string
+// SetAddress points later calls at the OMS gRPC API at address, with every
+// circuit closed. Calls already made on the previous connection are left to finish.
+func (c *Client) SetAddress(address string) error {
+    c.mutex.Lock()
+    defer c.mutex.Unlock()
+
+    if c.conn != nil && address == c.address {
+        return nil
+    }
+    options := append([]grpc.DialOption{
+        grpc.WithConnectParams(connectParams),
+        grpc.WithUnaryInterceptor(c.invoke),
+    }, c.dial...)
+    conn, err := grpc.NewClient(address, options...)
+    if err != nil {
+        return fmt.Errorf("connecting to OMS at %s: %w", address, err)
+    }
+    if previous := c.conn; previous != nil {
+        time.AfterFunc(drainTimeout, func() { previous.Close() })
+    }
+    c.address, c.conn, c.client = address, conn, omsv1.NewOrderManagementClient(conn)
+    c.circuits.reset()
+    return nil
+}
+
+// oms returns the client for the current OMS connection
+func (c *Client) oms() omsv1.OrderManagementClient {
+    c.mutex.RLock()
+    defer c.mutex.RUnlock()
+    return c.client
 }
 
-// NewClient creates a new OMS client
-func NewClient(baseURL string) *Client {
-    return &Client{
-        BaseURL: baseURL,
+// Close closes the connection to the OMS
+func (c *Client) Close() error {
+    c.mutex.RLock()
+    defer c.mutex.RUnlock()
+    return c.conn.Close()
+}
+
+// Metadata keys the OMS records in its audit trail
+const (
+    RequestIDKey = "x-request-id"
+    ReasonKey    = "x-reason"
+    UserIDKey    = "x-user-id"
+    AccountIDKey = "x-account-id"
+)
+
+// WithRequestID tags calls made with ctx with a request ID, so OMS audit
+// entries can be traced back to the gateway request
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+    if requestID == "" {
+        return ctx
     }
+    return metadata.AppendToOutgoingContext(ctx, RequestIDKey, requestID)
 }
 
-func (c *Client) performRequest(method, url string, body interface{}) ([]byte, error) {
-    jsonBody, err := json.Marshal(body)
-    if err != nil {
-        return nil, err
+// WithUser attributes calls made with ctx to the authenticated user and
+// their trading account
+func WithUser(ctx context.Context, userID, accountID string) context.Context {
+    if userID != "" {
+        ctx = metadata.AppendToOutgoingContext(ctx, UserIDKey, userID)
     }
+    if accountID != "" {
+        ctx = metadata.AppendToOutgoingContext(ctx, AccountIDKey, accountID)
+    }
+    return ctx
+}
 
-    req, err := http.NewRequest(method, url, bytes.NewBuffer(jsonBody))
-    if err != nil {
-        return nil, err
+// WithReason records why calls made with ctx change orders
+func WithReason(ctx context.Context, reason string) context.Context {
+    if reason == "" {
+        return ctx
     }
-    req.Header.Set("Content-Type", "application/json")
+    return metadata.AppendToOutgoingContext(ctx, ReasonKey, reason)
+}
 
-    client := &http.Client{}
-    resp, err := client.Do(req)
-    if err != nil {
-        return nil, err
+// ErrInvalidRequest is returned for requests that cannot be converted into
+// OMS messages, such as orders with an unknown side or product
+var ErrInvalidRequest = errors.New("invalid request")
+
+// checkEnum rejects enum values outside the order contract; an empty value
+// is left unspecified for the OMS to default
+func checkEnum[E interface {
+    ~string
+    IsValid() bool
+}](kind string, value E) error {
+    if value != "" && !value.IsValid() {
+        return fmt.Errorf("%w: unknown %s %q", ErrInvalidRequest, kind, value)
     }
-    defer resp.Body.Close()
+    return nil
+}
 
-    if resp.StatusCode != http.StatusOK {
-        return nil, fmt.Errorf("received status code %d", resp.StatusCode)
+func timestamp(t time.Time) *timestamppb.Timestamp {
+    if t.IsZero() {
+        return nil
     }
+    return timestamppb.New(t)
+}
 
-    return io.ReadAll(resp.Body)
+// orderProto converts an order as posted to the gateway into an OMS order
+func orderProto(order Order) (*omsv1.Order, error) {
+    if err := order.Validate(); err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+    }
+    return order.Proto(), nil
 }
 
 // StopLoss represents a stop loss structure
@@ -99,46 +179,45 @@ type StopLoss struct {
     Active   bool   `json:"active"`
 }
 
-// ActivateStopLoss activates a stop loss for a specific order
-func (c *Client) ActivateStopLoss(parentID, childID string) ([]byte, error) {
-    url := fmt.Sprintf("%s/orders/sl/%s/%s/active", c.BaseURL, parentID, childID)
-    return c.performRequest(http.MethodPost, url, nil)
+// ActivateStopLoss arms the stop loss of a parent order's child
+func (c *Client) ActivateStopLoss(ctx context.Context, parentID, childID string) (*omsv1.OrderReply, error) {
+    return c.oms().SetStopLoss(ctx, &omsv1.SetStopLossRequest{ParentId: parentID, OrderId: childID, Active: true})
 }
 
-// CancelStopLoss cancels a stop loss for a specific order
-func (c *Client) CancelStopLoss(parentID, childID string) ([]byte, error) {
-    url := fmt.Sprintf("%s/orders/sl/%s/%s/cancel", c.BaseURL, parentID, childID)
-    return c.performRequest(http.MethodPost, url, nil)
-}
-func (c *Client) CreateScalperOrder(order Order) ([]byte, error) {
-    log.Println("CreateScalperOrder client.go handler invoked") // Add this line for debugging
-    url := fmt.Sprintf("%s/orders/scalper", c.BaseURL) // Ensure this matches your expected URL
-    return c.performRequest(http.MethodPost, url, order)
+// CancelStopLoss disarms the stop loss of a parent order's child
+func (c *Client) CancelStopLoss(ctx context.Context, parentID, childID string) (*omsv1.OrderReply, error) {
+    return c.oms().SetStopLoss(ctx, &omsv1.SetStopLossRequest{ParentId: parentID, OrderId: childID, Active: false})
 }
 
-
+// CreateScalperOrder places a scalper order, using the order's stop price as its stop loss
+func (c *Client) CreateScalperOrder(ctx context.Context, order Order) (*omsv1.ScalperOrderReply, error) {
+    return c.oms().CreateScalperOrder(ctx, &omsv1.ScalperOrder{
+        Id:             order.ID,
+        Symbol:         order.Symbol,
+        Quantity:       int32(order.Quantity),
+        Price:          order.Price,
+        StopLoss:       order.StopPrice,
+        TakeProfit:     order.TakeProfit,
+        RiskPercentage: order.RiskPercentage,
+        CreatedAt:      order.CreatedAt,
+        ExpiresAt:      timestamp(order.ExpiresAt),
+    })
+}
 
 // ExecuteOrder executes a specific order
-func (c *Client) ExecuteOrder(order Order) ([]byte, error) {
-    url := fmt.Sprintf("%s/orders/%s/execute", c.BaseURL, order.ID)
-    return c.performRequest(http.MethodPost, url, order)
-}
-// GetAllOrders retrieves all orders
-func (c *Client) GetAllOrders() ([]byte, error) {
-    url := fmt.Sprintf("%s/orders", c.BaseURL)
-    return c.performRequest(http.MethodGet, url, nil)
+func (c *Client) ExecuteOrder(ctx context.Context, order Order) (*omsv1.Ack, error) {
+    converted, err := orderProto(order)
+    if err != nil {
+        return nil, err
+    }
+    return c.oms().ExecuteOrder(ctx, &omsv1.ExecuteOrderRequest{Order: converted})
 }
+
 // CancelOrder cancels a specific order
-func (c *Client) CancelOrder(orderID string) ([]byte, error) {
-    url := fmt.Sprintf("%s/orders/%s/cancel", c.BaseURL, orderID)
-    return c.performRequest(http.MethodDelete, url, nil)
+func (c *Client) CancelOrder(ctx context.Context, orderID string) (*omsv1.Ack, error) {
+    return c.oms().CancelOrder(ctx, &omsv1.OrderRef{OrderId: orderID})
 }
 
-// Handlers represents the HTTP handlers
-type Handlers struct {
-    logger    Logger
-    omsClient *Client
-}
 // CTCOrder represents a CTC order structure
 type CTCOrder struct {
     ID          string  `json:"id"`
@@ -154,257 +233,214 @@ type CTCOrder struct {
     Status      string  `json:"status"`
 }
 
-// CreateCTCOrder creates a new CTC order
-func (c *Client) CreateCTCOrder(order CTCOrder) ([]byte, error) {
-    url := fmt.Sprintf("%s/orders/ctc", c.BaseURL)
-    return c.performRequest(http.MethodPost, url, order)
+// CreateCTC places a CTC order under parentID
+func (c *Client) CreateCTC(ctx context.Context, parentID string, ctcOrder CTCOrder) (*omsv1.CTCOrderReply, error) {
+    return c.oms().CreateCTC(ctx, &omsv1.CTCOrder{
+        Id:        ctcOrder.ID,
+        ParentId:  parentID,
+        Symbol:    ctcOrder.Symbol,
+        Quantity:  int32(ctcOrder.Quantity),
+        Price:     ctcOrder.Price,
+        OrderType: ctcOrder.OrderType,
+    })
 }
 
-// CreateCTCOrderHandler handles the creation of a new CTC order
-func (h *Handlers) CreateCTCOrderHandler(c *gin.Context) {
-    var order CTCOrder
-    if err := c.ShouldBindJSON(&order); err != nil {
-        h.logger.Errorf("Failed to bind CTC order data: %v", err)
-        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid CTC order data"})
-        return
-    }
+func (c *Client) ExecuteSpecificChild(ctx context.Context, parentID, childID string) (*omsv1.Ack, error) {
+    return c.oms().ExecuteSpecificChild(ctx, &omsv1.ChildRef{ParentId: parentID, ChildId: childID})
+}
 
-    response, err := h.omsClient.CreateCTCOrder(order)
+// ModifyChildOrder changes the quantity, price or stop price of one of parentID's children
+func (c *Client) ModifyChildOrder(ctx context.Context, parentID, childID string, changes Order) (*omsv1.OrderReply, error) {
+    converted, err := orderProto(changes)
     if err != nil {
-        h.logger.Errorf("Failed to create CTC order: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create CTC order"})
-        return
+        return nil, err
     }
-
-    c.JSON(http.StatusOK, response)
-}
-// Logger is a placeholder for a logging interface
-type Logger interface {
-    Errorf(format string, args ...interface{})
+    return c.oms().ModifyOrder(ctx, &omsv1.ModifyOrderRequest{ParentId: parentID, OrderId: childID, Changes: converted})
 }
 
-// NewHandler creates a new Handler
-func NewHandler(omsClient *Client, logger Logger) *Handlers {
-    return &Handlers{omsClient: omsClient, logger: logger}
-}
-
-// ExecuteOrder executes a specific order
-func (h *Handlers) ExecuteOrder(c *gin.Context) {
-    var order Order
-    if err := c.ShouldBindJSON(&order); err != nil {
-        h.logger.Errorf("Failed to bind order data: %v", err)
-        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order data"})
-        return
-    }
-
-    response, err := h.omsClient.ExecuteOrder(order)
+// CreateOrder places order once per idempotencyKey, or per its client order
+// ID when the key is empty; a retry returns the original order marked Replayed
+func (c *Client) CreateOrder(ctx context.Context, idempotencyKey string, order Order) (*omsv1.OrderReply, error) {
+    converted, err := orderProto(order)
     if err != nil {
-        h.logger.Errorf("Failed to execute order: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to execute order"})
-        return
+        return nil, err
     }
+    return c.oms().CreateOrder(ctx, &omsv1.CreateOrderRequest{Order: converted, IdempotencyKey: idempotencyKey})
+}
 
-    c.JSON(http.StatusOK, response)
+// ExecuteAllChildTrades executes all child trades for a parent order
+func (c *Client) ExecuteAllChildTrades(ctx context.Context, parentID string) (*omsv1.Ack, error) {
+    return c.oms().ExecuteAllChildTrades(ctx, &omsv1.ParentRef{ParentId: parentID})
 }
-func (h *Handlers) CreateScalperOrder(c *gin.Context) {
-    var order Order
-    if err := c.ShouldBindJSON(&order); err != nil {
-        h.logger.Errorf("Failed to bind order data: %v", err)
-        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order data"})
-        return
-    }
 
-    response, err := h.omsClient.CreateScalperOrder(order)
+// ModifyOrder changes the quantity, price or stop price of an unfilled order
+func (c *Client) ModifyOrder(ctx context.Context, orderID string, changes Order) (*omsv1.OrderReply, error) {
+    converted, err := orderProto(changes)
     if err != nil {
-        h.logger.Errorf("Failed to create scalper order: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create scalper order"})
-        return
+        return nil, err
     }
-
-    c.JSON(http.StatusOK, response)
+    return c.oms().ModifyOrder(ctx, &omsv1.ModifyOrderRequest{OrderId: orderID, Changes: converted})
 }
 
-// CancelOrder cancels a specific order
-func (h *Handlers) CancelOrder(c *gin.Context) {
-    var order Order
-    if err := c.ShouldBindJSON(&order); err != nil {
-        h.logger.Errorf("Failed to bind order data: %v", err)
-        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order data"})
-        return
-    }
+func (c *Client) ExitAllTrades(ctx context.Context) (*omsv1.Ack, error) {
+    return c.oms().ExitAllTrades(ctx, &omsv1.ExitAllTradesRequest{})
+}
 
-    response, err := h.omsClient.CancelOrder(order.ID)
-    if err != nil {
-        h.logger.Errorf("Failed to cancel order: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel order"})
-        return
-    }
+func (c *Client) ExitChildTrades(ctx context.Context, parentID string) (*omsv1.Ack, error) {
+    return c.oms().ExitChildTrades(ctx, &omsv1.ParentRef{ParentId: parentID})
+}
 
-    c.JSON(http.StatusOK, response)
+func (c *Client) CancelAllChildOrders(ctx context.Context, parentID string) (*omsv1.Ack, error) {
+    return c.oms().CancelAllChildOrders(ctx, &omsv1.ParentRef{ParentId: parentID})
 }
 
-// Additional methods for Client
+func (c *Client) GetTrades(ctx context.Context, parentID string) (*omsv1.TradeList, error) {
+    return c.oms().GetTrades(ctx, &omsv1.ParentRef{ParentId: parentID})
+}
 
-func (c *Client) ExecuteSpecificChild(parentID, childID string) ([]byte, error) {
-    url := fmt.Sprintf("%s/orders/%s/%s/execute", c.BaseURL, parentID, childID)
-    return c.performRequest(http.MethodPost, url, nil)
+func (c *Client) DeleteParentOrder(ctx context.Context, parentID string) (*omsv1.Ack, error) {
+    return c.oms().DeleteParentOrder(ctx, &omsv1.ParentRef{ParentId: parentID})
 }
 
-func (c *Client) ModifyChildOrder(parentID, childID, orderType string, order Order) ([]byte, error) {
-    url := fmt.Sprintf("%s/orders/%s/%s/%s/modify", c.BaseURL, parentID, childID, orderType)
-    return c.performRequest(http.MethodPatch, url, order)
+// SyncPositions reprices the open positions and returns those opened by
+// userID's orders, or all of them when it is empty
+func (c *Client) SyncPositions(ctx context.Context, userID string) (*omsv1.PositionList, error) {
+    return c.oms().SyncPositions(ctx, &omsv1.SyncPositionsRequest{UserId: userID})
 }
 
-func (c *Client) CreateOrder(order Order) ([]byte, error) {
-    url := c.BaseURL + "/orders"
-    body, err := json.Marshal(order)
-    if err != nil {
-        return nil, err
-    }
-    resp, err := http.Post(url, "application/json", bytes.NewBuffer(body))
-    if err != nil {
-        return nil, err
-    }
-    defer resp.Body.Close()
-    return io.ReadAll(resp.Body)
+// GetPosition retrieves one position with the user who owns it, without repricing it
+func (c *Client) GetPosition(ctx context.Context, positionID string) (*omsv1.Position, error) {
+    return c.oms().GetPosition(ctx, &omsv1.PositionRef{PositionId: positionID})
 }
 
-// ExecuteAllChildTrades sends a request to execute all child trades for a parent order
-func (c *Client) ExecuteAllChildTrades(parentID string) ([]byte, error) {
-    url := c.BaseURL + "/orders/" + parentID + "/execute" // Ensure this is correct
-    resp, err := http.Post(url, "application/json", nil)
-    if err != nil {
-        return nil, err
-    }
-    if resp.StatusCode != http.StatusOK {
-        return nil, fmt.Errorf("received status code %d", resp.StatusCode)
-    }
-    log.Printf("Executing child trades for parentID: %s", parentID)
-    defer resp.Body.Close()
-    return io.ReadAll(resp.Body)
+// StrategyLeg is one leg of a multi-leg order, sized as quantity * ratio
+type StrategyLeg struct {
+    Symbol string    `json:"symbol"`
+    Side   orderv1.Side      `json:"side"`
+    Ratio  int               `json:"ratio"`
+    Type   orderv1.OrderType `json:"type"`
+    Price  float64           `json:"price"`
 }
 
-func (c *Client) CreateCTC(parentID string, ctcOrder CTCOrder) ([]byte, error) {
-    url := c.BaseURL + "/orders/" + parentID + "/ctc"
-    return c.performRequest(http.MethodPost, url, ctcOrder)
+// MultiLegOrder places two to four legs as children of one parent
+type MultiLegOrder struct {
+    Name     string                `json:"name"`
+    Quantity int                   `json:"quantity"`
+    Product  orderv1.ProductType   `json:"product,omitempty"`
+    Strategy orderv1.TradeStrategy `json:"strategy,omitempty"`
+    Legs     []StrategyLeg         `json:"legs"`
 }
 
-func (c *Client) ModifyOrder(parentID, orderType string, order Order) ([]byte, error) {
-    url := c.BaseURL + "/orders/" + parentID + "/" + orderType + "/modify"
-    body, err := json.Marshal(order)
-    if err != nil {
+// CreateMultiLegOrder submits a straddle, strangle or spread in one request
+func (c *Client) CreateMultiLegOrder(ctx context.Context, order MultiLegOrder) (*omsv1.MultiLegSummary, error) {
+    if err := checkEnum("product", order.Product); err != nil {
         return nil, err
     }
-    req, err := http.NewRequest("PATCH", url, bytes.NewBuffer(body))
-    if err != nil {
-        return nil, err
+    legs := make([]*omsv1.StrategyLeg, 0, len(order.Legs))
+    for _, leg := range order.Legs {
+        if err := checkEnum("side", leg.Side); err != nil {
+            return nil, err
+        }
+        if err := checkEnum("order type", leg.Type); err != nil {
+            return nil, err
+        }
+        legs = append(legs, &omsv1.StrategyLeg{
+            Symbol: leg.Symbol,
+            Side:   leg.Side.Proto(),
+            Ratio:  int32(leg.Ratio),
+            Type:   leg.Type.Proto(),
+            Price:  leg.Price,
+        })
     }
-    req.Header.Set("Content-Type", "application/json")
+    return c.oms().CreateMultiLegOrder(ctx, &omsv1.MultiLegOrder{
+        Name:     order.Name,
+        Quantity: int32(order.Quantity),
+        Product:  order.Product.Proto(),
+        Strategy: string(order.Strategy),
+        Legs:     legs,
+    })
+}
 
-    resp, err := http.DefaultClient.Do(req)
-    if err != nil {
-        return nil, err
-    }
-    defer resp.Body.Close()
-    return io.ReadAll(resp.Body)
+// GetMultiLegOrder retrieves a multi-leg order's legs, net premium and combined position
+func (c *Client) GetMultiLegOrder(ctx context.Context, parentID string) (*omsv1.MultiLegSummary, error) {
+    return c.oms().GetMultiLegOrder(ctx, &omsv1.ParentRef{ParentId: parentID})
 }
 
-func (c *Client) ExitAllTrades() ([]byte, error) {
-    url := c.BaseURL + "/orders/exit/all"
-    resp, err := http.Post(url, "application/json", nil)
-    if err != nil {
-        return nil, err
-    }
-    defer resp.Body.Close()
-    return io.ReadAll(resp.Body)
+// GetOrderTimeline retrieves the audit trail of changes to an order and its positions
+func (c *Client) GetOrderTimeline(ctx context.Context, orderID string) (*omsv1.Timeline, error) {
+    return c.oms().GetOrderTimeline(ctx, &omsv1.OrderRef{OrderId: orderID})
 }
 
-func (c *Client) ExitChildTrades(parentID string) ([]byte, error) {
-    url := c.BaseURL + "/orders/" + parentID + "/exit"
-    resp, err := http.Post(url, "application/json", nil)
-    if err != nil {
-        return nil, err
-    }
-    defer resp.Body.Close()
-    return io.ReadAll(resp.Body)
+// PositionConversion moves all or part of an open position to another product type
+type PositionConversion struct {
+    PositionID  string              `json:"position_id"`
+    FromProduct orderv1.ProductType `json:"from_product"`
+    ToProduct   orderv1.ProductType `json:"to_product"`
+    Quantity    int                 `json:"quantity"`
 }
 
-func (c *Client) CancelAllChildOrders(parentID string) ([]byte, error) {
-    url := c.BaseURL + "/orders/" + parentID + "/cancel"
-    resp, err := http.Post(url, "application/json", nil)
-    if err != nil {
+// ConvertPosition converts an open position between intraday, delivery and carry-forward
+func (c *Client) ConvertPosition(ctx context.Context, req PositionConversion) (*omsv1.Position, error) {
+    if err := checkEnum("product", req.FromProduct); err != nil {
         return nil, err
     }
-    defer resp.Body.Close()
-    return io.ReadAll(resp.Body)
-}
-
-func (c *Client) GetTrades(parentID string) ([]byte, error) {
-    url := c.BaseURL + "/trades?parentID=" + parentID
-    resp, err := http.Get(url)
-    if err != nil {
+    if err := checkEnum("product", req.ToProduct); err != nil {
         return nil, err
     }
-    defer resp.Body.Close()
-    return io.ReadAll(resp.Body)
+    return c.oms().ConvertPosition(ctx, &omsv1.PositionConversion{
+        PositionId:  req.PositionID,
+        FromProduct: req.FromProduct.Proto(),
+        ToProduct:   req.ToProduct.Proto(),
+        Quantity:    int32(req.Quantity),
+    })
 }
 
-func (c *Client) DeleteParentOrder(parentID string) ([]byte, error) {
-    url := c.BaseURL + "/orders/" + parentID
-    req, err := http.NewRequest("DELETE", url, nil)
-    if err != nil {
-        return nil, err
-    }
-    resp, err := http.DefaultClient.Do(req)
-    if err != nil {
-        return nil, err
-    }
-    defer resp.Body.Close()
-    return io.ReadAll(resp.Body)
+// GetOptionChain retrieves the calls and puts on an underlying for one expiry
+func (c *Client) GetOptionChain(ctx context.Context, underlying, expiry string) (*omsv1.OptionChain, error) {
+    return c.oms().GetOptionChain(ctx, &omsv1.OptionChainRequest{Underlying: underlying, Expiry: expiry})
 }
 
-func (c *Client) SyncPositions() ([]byte, error) {
-    url := c.BaseURL + "/positions"
-    resp, err := http.Get(url)
-    if err != nil {
-        return nil, err
-    }
-    defer resp.Body.Close()
-    return io.ReadAll(resp.Body)
+// GetExpiries retrieves the contract expiries listed for an underlying
+func (c *Client) GetExpiries(ctx context.Context, underlying string) (*omsv1.ExpiryList, error) {
+    return c.oms().GetExpiries(ctx, &omsv1.ExpiriesRequest{Underlying: underlying})
 }
 
-func (c *Client) GetOrders() ([]byte, error) {
-    url := fmt.Sprintf("%s/orders", c.BaseURL)
-    return c.performRequest(http.MethodGet, url, nil)
+// GetMarketStatus retrieves the current session phase for a segment
+func (c *Client) GetMarketStatus(ctx context.Context, segment string) (*omsv1.MarketStatus, error) {
+    var parsed orderv1.Segment
+    if segment != "" {
+        var err error
+        if parsed, err = orderv1.ParseSegment(segment); err != nil {
+            return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+        }
+    }
+    return c.oms().GetMarketStatus(ctx, &omsv1.MarketStatusRequest{Segment: parsed.Proto()})
 }
 
-func (c *Client) ExitSpecificChild(parentID, childID string) ([]byte, error) {
-    url := fmt.Sprintf("%s/orders/%s/%s/exit", c.BaseURL, parentID, childID)
-    return c.performRequest(http.MethodPost, url, nil)
+// GetHolidays retrieves the exchange holiday list
+func (c *Client) GetHolidays(ctx context.Context) (*omsv1.HolidayList, error) {
+    return c.oms().GetHolidays(ctx, &omsv1.HolidaysRequest{})
 }
 
-func (c *Client) CancelSpecificChildOrder(parentID, childID string) ([]byte, error) {
-    url := fmt.Sprintf("%s/orders/%s/%s/cancel", c.BaseURL, parentID, childID)
-    return c.performRequest(http.MethodPost, url, nil)
+// GetOrder retrieves one order with the user who placed it
+func (c *Client) GetOrder(ctx context.Context, orderID string) (*omsv1.Order, error) {
+    return c.oms().GetOrder(ctx, &omsv1.OrderRef{OrderId: orderID})
 }
 
-// Example usage of CancelSpecificChildOrder with advanced logic and error handling
-func (h *Handlers) CancelOrderHandler(w http.ResponseWriter, r *http.Request) {
-    parentID := r.URL.Query().Get("parentID")
-    orderID := r.URL.Query().Get("orderID")
+// GetOrders retrieves the orders placed by userID, or all orders when it is empty
+func (c *Client) GetOrders(ctx context.Context, userID string) (*omsv1.OrderList, error) {
+    return c.oms().GetOrders(ctx, &omsv1.GetOrdersRequest{UserId: userID})
+}
 
-    if parentID == "" || orderID == "" {
-        http.Error(w, "parentID and orderID are required", http.StatusBadRequest)
-        return
-    }
+func (c *Client) ExitSpecificChild(ctx context.Context, parentID, childID string) (*omsv1.Ack, error) {
+    return c.oms().ExitSpecificChild(ctx, &omsv1.ChildRef{ParentId: parentID, ChildId: childID})
+}
 
-    response, err := h.omsClient.CancelSpecificChildOrder(parentID, orderID)
-    if err != nil {
-        http.Error(w, fmt.Sprintf("Failed to cancel order: %v", err), http.StatusInternalServerError)
-        return
-    }
+func (c *Client) CancelSpecificChildOrder(ctx context.Context, parentID, childID string) (*omsv1.Ack, error) {
+    return c.oms().CancelSpecificChildOrder(ctx, &omsv1.ChildRef{ParentId: parentID, ChildId: childID})
+}
 
-    w.Header().Set("Content-Type", "application/json")
-    w.WriteHeader(http.StatusOK)
-    w.Write(response)
-}
\ No newline at end of file
+// StreamEvents follows the OMS event stream from after since until ctx is cancelled
+func (c *Client) StreamEvents(ctx context.Context, since uint64) (omsv1.OrderManagement_StreamEventsClient, error) {
+    return c.oms().StreamEvents(ctx, &omsv1.StreamEventsRequest{Since: since})
+}
@@ -0,0 +1,193 @@
+package oms
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/Mukilan-T/laabhum-api-go/contract"
+)
+
+//go:generate go run ../../cmd/contracts -out ../../../laabhum-api-go/contract/pacts
+
+// Consumer and Provider name the two sides of the gateway's contract with the OMS
+const (
+	Consumer = "laabhum-gateway"
+	Provider = "laabhum-oms"
+)
+
+// Provider states the OMS sets up before replaying an interaction. The
+// placeholders each state fills are listed with it; every state places its
+// orders as the user {userID}.
+const (
+	// {parentID}, {childID}: a pending limit order with a pending child that has a stop price
+	StateParentWithChild = "a parent order with a pending child"
+	// {orderID}: a pending limit order
+	StateOrderPending = "an order is pending"
+	// {positionID}: an intraday position opened by an executed market order
+	StatePositionOpen = "an open position"
+	// {underlying}, {expiry}, {call}, {put}: a call and a put at one strike
+	StateOptionsListed = "option contracts are listed"
+	// {parentID}: a multi-leg order placed on listed option contracts
+	StateMultiLegPlaced = "a multi-leg order is placed"
+)
+
+// Example replies. Only their shape is verified: every field must be served
+// with the same JSON type, whatever its value.
+const (
+	orderFields = `"id": "", "parent_id": "", "symbol": "", "quantity": 0, "price": 0,
+		"side": "", "type": "", "status": "", "strategy": "", "product": "",
+		"risk_percentage": 0, "stop_loss_activated": false, "take_profit": 0, "created_at": 0`
+	orderJSON = `{` + orderFields + `}`
+	// ownedOrderJSON is an order with the user who placed it, which the
+	// gateway checks ownership against
+	ownedOrderJSON = `{` + orderFields + `, "user_id": ""}`
+	ackJSON        = `{"message": ""}`
+	orderReplyJSON = `{"message": "", "order": ` + orderJSON + `}`
+	positionFields = `"id": "", "order_id": "", "symbol": "", "quantity": 0, "entry_price": 0, "current_price": 0, "product": ""`
+	positionJSON   = `{` + positionFields + `}`
+	// ownedPositionJSON is a position with the user who owns it
+	ownedPositionJSON = `{` + positionFields + `, "user_id": ""}`
+	multiLegJSON      = `{"parent": ` + orderJSON + `, "legs": [` + orderJSON + `], "net_premium": 0, "position": [{"symbol": "", "quantity": 0}]}`
+	instrumentJSON    = `{"exchange": "", "tradingsymbol": "", "segment": "", "instrument_type": "", "tick_size": 0, "lot_size": 0}`
+	limitOrderJSON    = `{"symbol": "INFY", "quantity": 1, "price": 1500, "side": "buy", "type": "LIMIT", "strategy": "DAY_TRADING", "product": "MIS"}`
+	orderChangesJSON  = `{"quantity": 2, "price": 1505}`
+)
+
+// Contract is every request the gateway makes of the OMS, one interaction
+// per Client method. The client speaks gRPC, whose methods mirror the OMS
+// REST routes one-to-one; each interaction pins the route, method and
+// payloads its method stands for, and the OMS verifies them against its
+// router. The stream feed follows the event stream over HTTP directly.
+func Contract() contract.Pact {
+	return contract.Pact{
+		Consumer: Consumer,
+		Provider: Provider,
+		Interactions: []contract.Interaction{
+			interaction("ActivateStopLoss", StateParentWithChild,
+				contract.Request{Method: http.MethodPatch, Path: "/oms/scalper/order/sl/{parentID}/{childID}/active"},
+				http.StatusOK, orderReplyJSON),
+			interaction("CancelStopLoss", StateParentWithChild,
+				contract.Request{Method: http.MethodPatch, Path: "/oms/scalper/order/sl/{parentID}/{childID}/cancel"},
+				http.StatusOK, orderReplyJSON),
+			interaction("CreateScalperOrder", "",
+				contract.Request{Method: http.MethodPost, Path: "/oms/scalper/order",
+					Body: raw(`{"symbol": "INFY", "price": 1500, "stop_loss": 1490, "take_profit": 1520, "risk_percentage": 0.01}`)},
+				http.StatusCreated, `{"message": "", "order": {"id": "", "symbol": "", "quantity": 0, "price": 0,
+					"stop_loss": 0, "take_profit": 0, "risk_percentage": 0, "created_at": 0}}`),
+			interaction("ExecuteOrder", StateOrderPending,
+				contract.Request{Method: http.MethodPost, Path: "/oms/order/execute", Body: raw(`{"id": "{orderID}", "type": "LIMIT"}`)},
+				http.StatusOK, ackJSON),
+			interaction("CancelOrder", StateOrderPending,
+				contract.Request{Method: http.MethodDelete, Path: "/oms/order/cancel", Body: raw(`{"id": "{orderID}"}`)},
+				http.StatusOK, ackJSON),
+			interaction("CreateCTC", StateParentWithChild,
+				contract.Request{Method: http.MethodPost, Path: "/oms/scalper/order/{parentID}/ctc",
+					Body: raw(`{"parent_id": "{parentID}", "symbol": "INFY", "quantity": 1, "price": 1500, "order_type": "LIMIT"}`)},
+				http.StatusCreated, `{"message": "", "order": {"id": "", "parent_id": "", "quantity": 0, "price": 0, "order_type": ""}}`),
+			interaction("ExecuteSpecificChild", StateParentWithChild,
+				contract.Request{Method: http.MethodPost, Path: "/oms/scalper/order/{parentID}/{childID}/execute"},
+				http.StatusOK, ackJSON),
+			interaction("ModifyChildOrder", StateParentWithChild,
+				contract.Request{Method: http.MethodPatch, Path: "/oms/scalper/order/scalper/{parentID}/{childID}/modify", Body: raw(orderChangesJSON)},
+				http.StatusOK, orderReplyJSON),
+			interaction("CreateOrder", "",
+				contract.Request{Method: http.MethodPut, Path: "/oms/order", Body: raw(limitOrderJSON)},
+				http.StatusCreated, orderReplyJSON),
+			interaction("ExecuteAllChildTrades", StateParentWithChild,
+				contract.Request{Method: http.MethodPost, Path: "/oms/scalper/order/{parentID}/execute"},
+				http.StatusOK, ackJSON),
+			interaction("ModifyOrder", StateOrderPending,
+				contract.Request{Method: http.MethodPatch, Path: "/oms/scalper/order/scalper/{orderID}/modify", Body: raw(orderChangesJSON)},
+				http.StatusOK, orderReplyJSON),
+			interaction("ExitAllTrades", "",
+				contract.Request{Method: http.MethodPost, Path: "/oms/scalper/exit/trade"},
+				http.StatusOK, ackJSON),
+			interaction("ExitChildTrades", StateParentWithChild,
+				contract.Request{Method: http.MethodPost, Path: "/oms/scalper/trade/{parentID}/exit"},
+				http.StatusOK, ackJSON),
+			interaction("CancelAllChildOrders", StateParentWithChild,
+				contract.Request{Method: http.MethodPost, Path: "/oms/scalper/order/{parentID}/cancel"},
+				http.StatusOK, ackJSON),
+			interaction("GetTrades", StateParentWithChild,
+				contract.Request{Method: http.MethodGet, Path: "/oms/scalper/trades/{parentID}"},
+				http.StatusOK, `[]`),
+			interaction("DeleteParentOrder", StateParentWithChild,
+				contract.Request{Method: http.MethodDelete, Path: "/oms/scalper/order/{parentID}"},
+				http.StatusOK, ackJSON),
+			interaction("SyncPositions", StatePositionOpen,
+				contract.Request{Method: http.MethodGet, Path: "/oms/positions", Query: map[string]string{"user_id": "{userID}"}},
+				http.StatusOK, `{"message": "", "positions": [`+ownedPositionJSON+`]}`),
+			interaction("GetPosition", StatePositionOpen,
+				contract.Request{Method: http.MethodGet, Path: "/oms/positions/{positionID}"},
+				http.StatusOK, ownedPositionJSON),
+			interaction("CreateMultiLegOrder", StateOptionsListed,
+				contract.Request{Method: http.MethodPost, Path: "/oms/strategy/order",
+					Body: raw(`{"name": "straddle", "quantity": 50, "product": "NRML", "strategy": "POSITION_TRADING", "legs": [
+						{"symbol": "{call}", "side": "buy", "ratio": 1, "type": "LIMIT", "price": 10},
+						{"symbol": "{put}", "side": "buy", "ratio": 1, "type": "LIMIT", "price": 10}]}`)},
+				http.StatusCreated, `{"message": "", "order": `+multiLegJSON+`}`),
+			interaction("GetMultiLegOrder", StateMultiLegPlaced,
+				contract.Request{Method: http.MethodGet, Path: "/oms/strategy/order/{parentID}"},
+				http.StatusOK, multiLegJSON),
+			interaction("GetOrderTimeline", StateOrderPending,
+				contract.Request{Method: http.MethodGet, Path: "/oms/orders/{orderID}/timeline"},
+				http.StatusOK, `[{"seq": 0, "timestamp": "", "order_id": "", "action": "", "actor": ""}]`),
+			interaction("ConvertPosition", StatePositionOpen,
+				contract.Request{Method: http.MethodPut, Path: "/oms/position/convert",
+					Body: raw(`{"position_id": "{positionID}", "from_product": "MIS", "to_product": "CNC", "quantity": 0}`)},
+				http.StatusOK, `{"message": "", "position": `+positionJSON+`}`),
+			interaction("GetOptionChain", StateOptionsListed,
+				contract.Request{Method: http.MethodGet, Path: "/oms/options/chain",
+					Query: map[string]string{"underlying": "{underlying}", "expiry": "{expiry}"}},
+				http.StatusOK, `{"underlying": "", "expiry": "", "rows": [{"strike": 0, "call": `+instrumentJSON+`, "put": `+instrumentJSON+`}]}`),
+			interaction("GetExpiries", StateOptionsListed,
+				contract.Request{Method: http.MethodGet, Path: "/oms/options/expiries", Query: map[string]string{"underlying": "{underlying}"}},
+				http.StatusOK, `[""]`),
+			interaction("GetMarketStatus", "",
+				contract.Request{Method: http.MethodGet, Path: "/oms/calendar/status", Query: map[string]string{"segment": "EQ"}},
+				http.StatusOK, `{"segment": "", "time": "", "trading_day": false, "phase": "", "next_open": ""}`),
+			interaction("GetHolidays", "",
+				contract.Request{Method: http.MethodGet, Path: "/oms/calendar/holidays"},
+				http.StatusOK, `[]`),
+			interaction("GetOrders", StateOrderPending,
+				contract.Request{Method: http.MethodGet, Path: "/oms/orders", Query: map[string]string{"user_id": "{userID}"}},
+				http.StatusOK, `[`+ownedOrderJSON+`]`),
+			interaction("GetOrder", StateOrderPending,
+				contract.Request{Method: http.MethodGet, Path: "/oms/orders/{orderID}"},
+				http.StatusOK, ownedOrderJSON),
+			interaction("ExitSpecificChild", StateParentWithChild,
+				contract.Request{Method: http.MethodPost, Path: "/oms/scalper/trade/{parentID}/{childID}/exit"},
+				http.StatusOK, ackJSON),
+			interaction("CancelSpecificChildOrder", StateParentWithChild,
+				contract.Request{Method: http.MethodPost, Path: "/oms/scalper/order/{parentID}/{childID}/cancel"},
+				http.StatusOK, ackJSON),
+			{
+				Description: "StreamEvents",
+				Request: contract.Request{Method: http.MethodGet, Path: "/oms/events/stream",
+					Query: map[string]string{"since": "0"}, Stream: true},
+				Response: contract.Response{Status: http.StatusOK, Headers: map[string]string{"Content-Type": "text/event-stream"}},
+			},
+		},
+	}
+}
+
+// interaction expects request in state to be answered with status and a
+// body shaped like reply
+func interaction(method, state string, request contract.Request, status int, reply string) contract.Interaction {
+	return contract.Interaction{
+		Description: method,
+		State:       state,
+		Request:     request,
+		Response:    contract.Response{Status: status, Body: raw(reply)},
+	}
+}
+
+// raw compacts example JSON for the pact file
+func raw(example string) json.RawMessage {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(example)); err != nil {
+		panic("oms: invalid example JSON: " + err.Error())
+	}
+	return compact.Bytes()
+}
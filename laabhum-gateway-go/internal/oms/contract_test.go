@@ -0,0 +1,29 @@
+package oms
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// pacts is where the gateway publishes its pact for the OMS to verify
+const pacts = "../../../laabhum-api-go/contract/pacts"
+
+// TestPublishedPactMatchesContract checks that the pact the OMS verifies is
+// the one Contract describes, so a changed request is not left unverified
+func TestPublishedPactMatchesContract(t *testing.T) {
+	pact := Contract()
+	published, err := os.ReadFile(filepath.Join(pacts, pact.FileName()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := json.MarshalIndent(pact, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(published, append(want, '\n')) {
+		t.Errorf("%s is out of date with oms.Contract; run go generate ./internal/oms", pact.FileName())
+	}
+}
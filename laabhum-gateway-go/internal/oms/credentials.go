@@ -0,0 +1,72 @@
+package oms
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Credentials are how the gateway authenticates to the OMS gRPC API
+type Credentials struct {
+	// ServiceToken is sent as a bearer token on every call
+	ServiceToken string `yaml:"serviceToken"`
+	// TLS verifies the OMS certificate against CAFile, or the system roots
+	// when it is empty; without it calls are sent in plaintext
+	TLS    bool   `yaml:"tls"`
+	CAFile string `yaml:"caFile"`
+}
+
+// Validate reports every invalid setting
+func (c Credentials) Validate() error {
+	if c.CAFile != "" && !c.TLS {
+		return errors.New("caFile: requires tls")
+	}
+	return nil
+}
+
+// dialOptions returns the transport and per-call credentials of connections
+func (c Credentials) dialOptions() ([]grpc.DialOption, error) {
+	transport := insecure.NewCredentials()
+	if c.TLS {
+		config := &tls.Config{MinVersion: tls.VersionTLS12}
+		if c.CAFile != "" {
+			pem, err := os.ReadFile(c.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("reading OMS CA: %w", err)
+			}
+			config.RootCAs = x509.NewCertPool()
+			if !config.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates in OMS CA %s", c.CAFile)
+			}
+		}
+		transport = credentials.NewTLS(config)
+	}
+	options := []grpc.DialOption{grpc.WithTransportCredentials(transport)}
+	if c.ServiceToken != "" {
+		options = append(options, grpc.WithPerRPCCredentials(serviceToken{token: c.ServiceToken, secure: c.TLS}))
+	}
+	return options, nil
+}
+
+// serviceToken sends the service token in the authorization metadata
+type serviceToken struct {
+	token  string
+	secure bool
+}
+
+func (t serviceToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+// RequireTransportSecurity lets the token go out in plaintext only when TLS
+// is off, which the OMS allows on its loopback interface alone
+func (t serviceToken) RequireTransportSecurity() bool {
+	return t.secure
+}
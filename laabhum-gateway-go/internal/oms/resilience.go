@@ -0,0 +1,251 @@
+package oms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	omsv1 "github.com/Mukilan-T/laabhum-api-go/oms/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy retries idempotent calls the OMS could not be reached for,
+// waiting a jittered, doubling delay between attempts
+type RetryPolicy struct {
+	MaxAttempts int           `yaml:"max_attempts"` // Including the first; 1 turns retries off
+	BaseDelay   time.Duration `yaml:"base_delay"`
+	MaxDelay    time.Duration `yaml:"max_delay"`
+}
+
+// BreakerPolicy opens an endpoint's circuit after Failures consecutive calls
+// fail to reach the OMS. Calls then fail fast until Cooldown has passed, when
+// one call is let through to probe whether the OMS has recovered.
+type BreakerPolicy struct {
+	Failures int           `yaml:"failures"`
+	Cooldown time.Duration `yaml:"cooldown"`
+}
+
+// Resilience is how OMS calls are bounded, retried and cut off
+type Resilience struct {
+	Timeout time.Duration `yaml:"timeout"` // Deadline of calls whose context has none
+	Retry   RetryPolicy   `yaml:"retry"`
+	Breaker BreakerPolicy `yaml:"breaker"`
+}
+
+// DefaultResilience gives calls 5 seconds, tries idempotent ones up to 3
+// times and opens a circuit after 5 failures for 10 seconds
+var DefaultResilience = Resilience{
+	Timeout: 5 * time.Second,
+	Retry:   RetryPolicy{MaxAttempts: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second},
+	Breaker: BreakerPolicy{Failures: 5, Cooldown: 10 * time.Second},
+}
+
+// Validate reports every invalid setting
+func (r Resilience) Validate() error {
+	var errs []error
+	if r.Timeout <= 0 {
+		errs = append(errs, errors.New("timeout: must be positive"))
+	}
+	if r.Retry.MaxAttempts < 1 {
+		errs = append(errs, errors.New("retry.max_attempts: must be at least 1"))
+	}
+	if r.Retry.BaseDelay <= 0 {
+		errs = append(errs, errors.New("retry.base_delay: must be positive"))
+	}
+	if r.Retry.MaxDelay < r.Retry.BaseDelay {
+		errs = append(errs, errors.New("retry.max_delay: must not be below base_delay"))
+	}
+	if r.Breaker.Failures < 1 {
+		errs = append(errs, errors.New("breaker.failures: must be at least 1"))
+	}
+	if r.Breaker.Cooldown <= 0 {
+		errs = append(errs, errors.New("breaker.cooldown: must be positive"))
+	}
+	return errors.Join(errs...)
+}
+
+// backoff returns the delay before retry n, the first being 1: a random
+// duration up to BaseDelay doubled n-1 times, capped at MaxDelay
+func (p RetryPolicy) backoff(n int) time.Duration {
+	ceiling := p.MaxDelay
+	if n <= 30 {
+		if d := p.BaseDelay << (n - 1); d > 0 && d < ceiling {
+			ceiling = d
+		}
+	}
+	return time.Duration(rand.Int63n(int64(ceiling))) + 1
+}
+
+// idempotentMethods are the OMS calls that only read, and so can be repeated
+// safely. SyncPositions writes the positions it reconciles, so it is not one.
+var idempotentMethods = map[string]bool{
+	omsv1.OrderManagement_GetOrders_FullMethodName:        true,
+	omsv1.OrderManagement_GetOrderTimeline_FullMethodName: true,
+	omsv1.OrderManagement_GetTrades_FullMethodName:        true,
+	omsv1.OrderManagement_GetMultiLegOrder_FullMethodName: true,
+	omsv1.OrderManagement_GetInstrument_FullMethodName:    true,
+	omsv1.OrderManagement_GetExpiries_FullMethodName:      true,
+	omsv1.OrderManagement_GetOptionChain_FullMethodName:   true,
+	omsv1.OrderManagement_GetMarketStatus_FullMethodName:  true,
+	omsv1.OrderManagement_GetHolidays_FullMethodName:      true,
+}
+
+// idempotent reports whether a call may be repeated: reads, and orders the
+// OMS deduplicates by idempotency key or client order ID
+func idempotent(method string, req interface{}) bool {
+	if create, ok := req.(*omsv1.CreateOrderRequest); ok {
+		return create.IdempotencyKey != "" || create.GetOrder().GetClientOrderId() != ""
+	}
+	return idempotentMethods[method]
+}
+
+// unreachable reports whether err means the call did not get an answer from
+// the OMS, as opposed to the OMS refusing it
+func unreachable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// breaker is the circuit of one OMS endpoint
+type breaker struct {
+	failures int
+	openedAt time.Time // Zero while closed
+	probing  bool      // A call is testing the half-open circuit
+}
+
+// circuits holds a breaker per OMS method
+type circuits struct {
+	mutex    sync.Mutex
+	breakers map[string]*breaker
+}
+
+// allow reports whether a call to method may go ahead, and when a closed
+// circuit will next let one through if not
+func (cs *circuits) allow(method string, policy BreakerPolicy, now time.Time) (bool, time.Time) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	b := cs.breakers[method]
+	if b == nil || b.openedAt.IsZero() {
+		return true, time.Time{}
+	}
+	retryAt := b.openedAt.Add(policy.Cooldown)
+	if now.Before(retryAt) || b.probing {
+		return false, retryAt
+	}
+	b.probing = true
+	return true, time.Time{}
+}
+
+// record updates method's circuit with the outcome of a call it allowed.
+// Calls the caller gave up on say nothing about the OMS and change nothing.
+func (cs *circuits) record(method string, policy BreakerPolicy, err error, now time.Time) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	b := cs.breakers[method]
+	if b == nil {
+		b = &breaker{}
+		cs.breakers[method] = b
+	}
+	switch {
+	case status.Code(err) == codes.Canceled:
+		b.probing = false
+	case unreachable(err):
+		b.failures++
+		// A failed probe reopens the circuit for another cooldown
+		if b.failures >= policy.Failures || b.probing {
+			b.openedAt = now
+		}
+		b.probing = false
+	default:
+		delete(cs.breakers, method)
+	}
+}
+
+// reset closes every circuit
+func (cs *circuits) reset() {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	cs.breakers = make(map[string]*breaker)
+}
+
+// open returns the short names of the methods whose circuits are not closed
+func (cs *circuits) open() []string {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	var open []string
+	for method, b := range cs.breakers {
+		if !b.openedAt.IsZero() {
+			open = append(open, methodName(method))
+		}
+	}
+	sort.Strings(open)
+	return open
+}
+
+// methodName trims the service from a full gRPC method name
+func methodName(method string) string {
+	return method[strings.LastIndex(method, "/")+1:]
+}
+
+// SetResilience changes how later calls are bounded, retried and cut off
+func (c *Client) SetResilience(resilience Resilience) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.resilience = resilience
+}
+
+// OpenCircuits names the OMS calls currently failing fast because the OMS
+// could not be reached for them; none means the OMS looks healthy
+func (c *Client) OpenCircuits() []string {
+	return c.circuits.open()
+}
+
+// invoke runs every unary call: it applies the default deadline, fails fast
+// while the method's circuit is open and retries idempotent calls the OMS
+// could not be reached for
+func (c *Client) invoke(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	c.mutex.RLock()
+	policy := c.resilience
+	c.mutex.RUnlock()
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		defer cancel()
+	}
+	attempts := 1
+	if idempotent(method, req) {
+		attempts = policy.Retry.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		if ok, retryAt := c.circuits.allow(method, policy.Breaker, time.Now()); !ok {
+			return omsv1.NewError(http.StatusServiceUnavailable, fmt.Sprintf("OMS %s is unavailable, retry after %s",
+				methodName(method), retryAt.UTC().Format(time.RFC3339)))
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		c.circuits.record(method, policy.Breaker, err, time.Now())
+		if err == nil || attempt >= attempts || status.Code(err) != codes.Unavailable {
+			return err
+		}
+
+		timer := time.NewTimer(policy.Retry.backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+	}
+}
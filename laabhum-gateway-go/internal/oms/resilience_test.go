@@ -0,0 +1,145 @@
+package oms
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	omsv1 "github.com/Mukilan-T/laabhum-api-go/oms/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testBreaker = BreakerPolicy{Failures: 2, Cooldown: 10 * time.Second}
+
+func TestBreakerOpensAndProbes(t *testing.T) {
+	const method = omsv1.OrderManagement_GetOrders_FullMethodName
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	start := time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+
+	// Each step either asks to call at a time or records a call's outcome
+	type step struct {
+		name  string
+		at    time.Time
+		allow *bool  // Whether a call is let through, when asking
+		err   *error // The outcome, when recording
+		open  []string
+	}
+	yes, no := true, false
+	var ok error
+	steps := []step{
+		{name: "closed", at: at(0), allow: &yes},
+		{name: "first failure", at: at(0), err: &unavailable},
+		{name: "still closed", at: at(1), allow: &yes},
+		{name: "second failure opens", at: at(1), err: &unavailable, open: []string{"GetOrders"}},
+		{name: "fails fast while open", at: at(5), allow: &no, open: []string{"GetOrders"}},
+		{name: "probe after cooldown", at: at(11), allow: &yes, open: []string{"GetOrders"}},
+		{name: "one probe at a time", at: at(11), allow: &no, open: []string{"GetOrders"}},
+		{name: "failed probe reopens", at: at(12), err: &unavailable, open: []string{"GetOrders"}},
+		{name: "open for another cooldown", at: at(21), allow: &no, open: []string{"GetOrders"}},
+		{name: "second probe", at: at(22), allow: &yes, open: []string{"GetOrders"}},
+		{name: "successful probe closes", at: at(22), err: &ok},
+		{name: "closed again", at: at(22), allow: &yes},
+	}
+
+	cs := &circuits{breakers: make(map[string]*breaker)}
+	for _, s := range steps {
+		if s.allow != nil {
+			if allowed, _ := cs.allow(method, testBreaker, s.at); allowed != *s.allow {
+				t.Fatalf("%s: allowed %v, want %v", s.name, allowed, *s.allow)
+			}
+		} else {
+			cs.record(method, testBreaker, *s.err, s.at)
+		}
+		if open := cs.open(); len(open) != len(s.open) || (len(open) > 0 && open[0] != s.open[0]) {
+			t.Fatalf("%s: open circuits %v, want %v", s.name, open, s.open)
+		}
+	}
+}
+
+func TestBreakerIsPerMethod(t *testing.T) {
+	cs := &circuits{breakers: make(map[string]*breaker)}
+	now := time.Now()
+	for i := 0; i < testBreaker.Failures; i++ {
+		cs.record(omsv1.OrderManagement_GetTrades_FullMethodName, testBreaker, status.Error(codes.Unavailable, ""), now)
+	}
+	if allowed, _ := cs.allow(omsv1.OrderManagement_GetTrades_FullMethodName, testBreaker, now); allowed {
+		t.Error("GetTrades allowed with its circuit open")
+	}
+	if allowed, _ := cs.allow(omsv1.OrderManagement_GetOrders_FullMethodName, testBreaker, now); !allowed {
+		t.Error("GetOrders refused because another method's circuit is open")
+	}
+	// Refusals and cancelled calls say nothing about the OMS being reachable
+	cs.record(omsv1.OrderManagement_GetOrders_FullMethodName, BreakerPolicy{Failures: 1, Cooldown: time.Second}, status.Error(codes.InvalidArgument, ""), now)
+	cs.record(omsv1.OrderManagement_GetOrders_FullMethodName, BreakerPolicy{Failures: 1, Cooldown: time.Second}, status.Error(codes.Canceled, ""), now)
+	if open := cs.open(); len(open) != 1 || open[0] != "GetTrades" {
+		t.Errorf("open circuits %v, want only GetTrades", open)
+	}
+}
+
+func TestInvokeRetriesOnlyIdempotentCalls(t *testing.T) {
+	resilience := Resilience{
+		Timeout: time.Second,
+		Retry:   RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+		Breaker: BreakerPolicy{Failures: 100, Cooldown: time.Second},
+	}
+	tests := []struct {
+		name     string
+		method   string
+		req      interface{}
+		code     codes.Code
+		attempts int
+	}{
+		{"read", omsv1.OrderManagement_GetOrders_FullMethodName, &omsv1.GetOrdersRequest{}, codes.Unavailable, 3},
+		{"order without a key", omsv1.OrderManagement_CreateOrder_FullMethodName, &omsv1.CreateOrderRequest{}, codes.Unavailable, 1},
+		{"order with an idempotency key", omsv1.OrderManagement_CreateOrder_FullMethodName,
+			&omsv1.CreateOrderRequest{IdempotencyKey: "key-1"}, codes.Unavailable, 3},
+		{"order with a client order ID", omsv1.OrderManagement_CreateOrder_FullMethodName,
+			&omsv1.CreateOrderRequest{Order: &omsv1.Order{ClientOrderId: "client-1"}}, codes.Unavailable, 3},
+		{"cancel", omsv1.OrderManagement_CancelOrder_FullMethodName, &omsv1.OrderRef{}, codes.Unavailable, 1},
+		{"position sync writes", omsv1.OrderManagement_SyncPositions_FullMethodName, &omsv1.SyncPositionsRequest{}, codes.Unavailable, 1},
+		{"read refused by the OMS", omsv1.OrderManagement_GetOrders_FullMethodName, &omsv1.GetOrdersRequest{}, codes.InvalidArgument, 1},
+		{"read timed out", omsv1.OrderManagement_GetOrders_FullMethodName, &omsv1.GetOrdersRequest{}, codes.DeadlineExceeded, 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{resilience: resilience, circuits: circuits{breakers: make(map[string]*breaker)}}
+			attempts := 0
+			invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				attempts++
+				return status.Error(tc.code, "failed")
+			}
+			err := c.invoke(context.Background(), tc.method, tc.req, nil, nil, invoker)
+			if status.Code(err) != tc.code {
+				t.Errorf("got %v, want %v", err, tc.code)
+			}
+			if attempts != tc.attempts {
+				t.Errorf("tried %d times, want %d", attempts, tc.attempts)
+			}
+		})
+	}
+}
+
+func TestInvokeFailsFastWhileOpen(t *testing.T) {
+	resilience := DefaultResilience
+	resilience.Retry.MaxAttempts = 1
+	resilience.Breaker = BreakerPolicy{Failures: 1, Cooldown: time.Minute}
+	c := &Client{resilience: resilience, circuits: circuits{breakers: make(map[string]*breaker)}}
+	attempts := 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		attempts++
+		return status.Error(codes.Unavailable, "connection refused")
+	}
+	method := omsv1.OrderManagement_GetOrders_FullMethodName
+	c.invoke(context.Background(), method, &omsv1.GetOrdersRequest{}, nil, nil, invoker)
+	err := c.invoke(context.Background(), method, &omsv1.GetOrdersRequest{}, nil, nil, invoker)
+	if attempts != 1 {
+		t.Errorf("the OMS was called %d times, want the open circuit to stop the second call", attempts)
+	}
+	if got := omsv1.ErrorFrom(err).GetHttpStatus(); got != http.StatusServiceUnavailable {
+		t.Errorf("failing fast returned %v (HTTP %d), want 503", err, got)
+	}
+}
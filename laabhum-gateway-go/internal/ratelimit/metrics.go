@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// WriteMetrics writes the limiter's counters and every bucket's tokens in the
+// Prometheus text format
+func (l *Limiter) WriteMetrics(w io.Writer) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	counts := make([]count, 0, len(l.counts))
+	for c := range l.counts {
+		counts = append(counts, c)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		return fmt.Sprint(counts[i]) < fmt.Sprint(counts[j])
+	})
+	fmt.Fprintln(w, "# HELP gateway_ratelimit_requests_total Requests charged to rate limits by class and result, and the scope that limited or throttled them.")
+	fmt.Fprintln(w, "# TYPE gateway_ratelimit_requests_total counter")
+	for _, c := range counts {
+		labels := fmt.Sprintf("class=%q,result=%q", c.class, c.result)
+		if c.scope != "" {
+			labels += fmt.Sprintf(",scope=%q", c.scope)
+		}
+		fmt.Fprintf(w, "gateway_ratelimit_requests_total{%s} %d\n", labels, l.counts[c])
+	}
+
+	fmt.Fprintln(w, "# HELP gateway_ratelimit_throttle_wait_seconds_total Time orders spent queued behind the exchange throttle.")
+	fmt.Fprintln(w, "# TYPE gateway_ratelimit_throttle_wait_seconds_total counter")
+	fmt.Fprintf(w, "gateway_ratelimit_throttle_wait_seconds_total %s\n", strconv.FormatFloat(l.throttled.Seconds(), 'f', -1, 64))
+
+	now := l.now()
+	keys := make([]key, 0, len(l.buckets))
+	for k := range l.buckets {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+	fmt.Fprintln(w, "# HELP gateway_ratelimit_tokens Tokens left in each rate limit bucket; negative when orders are queued.")
+	fmt.Fprintln(w, "# TYPE gateway_ratelimit_tokens gauge")
+	for _, k := range keys {
+		b := l.buckets[k]
+		b.refill(now)
+		fmt.Fprintf(w, "gateway_ratelimit_tokens{scope=%q,class=%q,name=%q} %s\n", k.scope, k.class, k.name, strconv.FormatFloat(b.tokens, 'f', 2, 64))
+	}
+	if l.exchange != nil {
+		l.exchange.refill(now)
+		fmt.Fprintf(w, "gateway_ratelimit_tokens{scope=%q,class=%q} %s\n", ScopeExchange, ClassOrders, strconv.FormatFloat(l.exchange.tokens, 'f', 2, 64))
+	}
+}
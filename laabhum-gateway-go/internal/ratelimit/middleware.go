@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/Mukilan-T/laabhum-gateway-go/internal/auth"
+	"github.com/Mukilan-T/laabhum-gateway-go/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// ClassOf returns the budget a request with method is charged to: reads are
+// GETs, everything else enters or changes orders
+func ClassOf(method string) Class {
+	if method == http.MethodGet || method == http.MethodHead {
+		return ClassReads
+	}
+	return ClassOrders
+}
+
+// Middleware charges each authenticated request to its caller's budgets,
+// refusing it with 429 and a Retry-After header when one is spent, and holds
+// orders back to the exchange throttle. It must run after the auth.Guard
+// checks of the route, so requests they refuse are not charged.
+func (l *Limiter) Middleware(log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := auth.FromContext(c.Request.Context())
+		if !ok {
+			c.Next()
+			return
+		}
+		// API keys are limited per key, bearer tokens per user
+		name := principal.KeyName
+		if principal.Method != auth.MethodAPIKey {
+			name = "user:" + principal.User
+		}
+
+		decision := l.Allow(name, principal.Account, ClassOf(c.Request.Method))
+		if !decision.Allowed {
+			log.Debugf("Rate limited %s %s for %s (%s budget, retry in %v)", c.Request.Method, c.Request.URL.Path, name, decision.Scope, decision.RetryAfter)
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(decision.RetryAfter.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error": "Rate limit exceeded",
+				"scope": decision.Scope,
+			})
+			return
+		}
+		if !Wait(c.Request.Context(), decision.Wait) {
+			// The caller went away while the order was queued
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
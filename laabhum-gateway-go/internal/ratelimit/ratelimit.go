@@ -0,0 +1,291 @@
+// Package ratelimit keeps gateway callers within their request budgets with
+// token buckets per API key and per account, separately for order entry and
+// reads, and paces order entry across the gateway to the exchange's
+// orders-per-second limit.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Class is the budget a request is charged to
+type Class string
+
+const (
+	ClassOrders Class = "orders"
+	ClassReads  Class = "reads"
+)
+
+// Scopes a request can be limited in
+const (
+	ScopeKey      = "key"
+	ScopeAccount  = "account"
+	ScopeExchange = "exchange"
+)
+
+// Limit is a token bucket refilled at Rate tokens a second up to Burst. A
+// zero Rate leaves requests unlimited.
+type Limit struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+// Budgets are the order and read limits of one scope
+type Budgets struct {
+	Orders Limit `yaml:"orders"`
+	Reads  Limit `yaml:"reads"`
+}
+
+// Throttle paces order entry across the gateway. Orders wait up to MaxWait
+// for their turn before they are refused.
+type Throttle struct {
+	Limit   `yaml:",inline"`
+	MaxWait time.Duration `yaml:"max_wait"`
+}
+
+// Config sets every limit
+type Config struct {
+	Key      Budgets  `yaml:"key"`     // Per API key, or per user for bearer tokens
+	Account  Budgets  `yaml:"account"` // Per trading account
+	Exchange Throttle `yaml:"exchange"`
+}
+
+// DefaultConfig allows each key 10 orders and 50 reads a second and paces
+// orders to the exchange at 10 a second
+var DefaultConfig = Config{
+	Key:      Budgets{Orders: Limit{Rate: 10, Burst: 20}, Reads: Limit{Rate: 50, Burst: 100}},
+	Account:  Budgets{Orders: Limit{Rate: 20, Burst: 40}, Reads: Limit{Rate: 100, Burst: 200}},
+	Exchange: Throttle{Limit: Limit{Rate: 10, Burst: 10}, MaxWait: time.Second},
+}
+
+// Validate reports every invalid limit
+func (c Config) Validate() error {
+	var errs []error
+	check := func(name string, limit Limit) {
+		if limit.Rate < 0 || math.IsInf(limit.Rate, 0) || math.IsNaN(limit.Rate) {
+			errs = append(errs, fmt.Errorf("%s.rate: must be a positive number, or 0 for no limit", name))
+		}
+		if limit.Rate > 0 && limit.Burst < 1 {
+			errs = append(errs, fmt.Errorf("%s.burst: must be at least 1", name))
+		}
+	}
+	check("key.orders", c.Key.Orders)
+	check("key.reads", c.Key.Reads)
+	check("account.orders", c.Account.Orders)
+	check("account.reads", c.Account.Reads)
+	check("exchange", c.Exchange.Limit)
+	if c.Exchange.MaxWait < 0 {
+		errs = append(errs, errors.New("exchange.max_wait: must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+func (c Config) limit(scope string, class Class) Limit {
+	budgets := c.Key
+	switch scope {
+	case ScopeAccount:
+		budgets = c.Account
+	case ScopeExchange:
+		return c.Exchange.Limit
+	}
+	if class == ClassOrders {
+		return budgets.Orders
+	}
+	return budgets.Reads
+}
+
+// bucket is a token bucket; tokens may go negative when orders are queued
+// behind the exchange throttle
+type bucket struct {
+	limit   Limit
+	tokens  float64
+	updated time.Time
+	used    time.Time
+}
+
+func newBucket(limit Limit, now time.Time) *bucket {
+	return &bucket{limit: limit, tokens: float64(limit.Burst), updated: now}
+}
+
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed*b.limit.Rate)
+	}
+	b.updated = now
+}
+
+// wait returns how long until a token is free
+func (b *bucket) wait() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.limit.Rate * float64(time.Second))
+}
+
+// key names a bucket
+type key struct {
+	scope string
+	class Class
+	name  string
+}
+
+// Decision is the outcome of charging a request
+type Decision struct {
+	Allowed    bool
+	Scope      string        // Scope that refused the request
+	RetryAfter time.Duration // When the refusing budget has a token again
+	Wait       time.Duration // How long an allowed order must wait for the exchange throttle
+}
+
+// idleAfter is how long a full bucket is kept before it is forgotten
+const idleAfter = 10 * time.Minute
+
+// Limiter holds every caller's buckets
+type Limiter struct {
+	mutex     sync.Mutex
+	config    Config
+	buckets   map[key]*bucket
+	exchange  *bucket
+	swept     time.Time
+	counts    map[count]uint64
+	throttled time.Duration
+	now       func() time.Time
+}
+
+// count is a metrics counter for requests of one scope and class
+type count struct {
+	scope  string
+	class  Class
+	result string
+}
+
+// New returns a Limiter enforcing config
+func New(config Config) *Limiter {
+	l := &Limiter{
+		buckets: make(map[key]*bucket),
+		counts:  make(map[count]uint64),
+		now:     time.Now,
+	}
+	l.SetConfig(config)
+	return l
+}
+
+// SetConfig changes the limits. Buckets keep their tokens, capped at the new burst.
+func (l *Limiter) SetConfig(config Config) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	l.config = config
+	for k, b := range l.buckets {
+		limit := config.limit(k.scope, k.class)
+		if limit.Rate == 0 {
+			delete(l.buckets, k)
+			continue
+		}
+		b.refill(now)
+		b.limit = limit
+		b.tokens = math.Min(b.tokens, float64(limit.Burst))
+	}
+	if config.Exchange.Rate == 0 {
+		l.exchange = nil
+	} else if l.exchange == nil {
+		l.exchange = newBucket(config.Exchange.Limit, now)
+	} else {
+		l.exchange.refill(now)
+		l.exchange.limit = config.Exchange.Limit
+		l.exchange.tokens = math.Min(l.exchange.tokens, float64(config.Exchange.Burst))
+	}
+}
+
+// Allow charges a request of class to the key and account buckets and, for
+// orders, the exchange throttle. Nothing is charged unless every budget has
+// room. An empty account is not limited per account.
+func (l *Limiter) Allow(keyName, account string, class Class) Decision {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	var charged []*bucket
+	for _, k := range []key{{ScopeKey, class, keyName}, {ScopeAccount, class, account}} {
+		limit := l.config.limit(k.scope, k.class)
+		if limit.Rate == 0 || k.name == "" {
+			continue
+		}
+		b, ok := l.buckets[k]
+		if !ok {
+			b = newBucket(limit, now)
+			l.buckets[k] = b
+		}
+		b.refill(now)
+		if wait := b.wait(); wait > 0 {
+			return l.refuse(k.scope, class, wait)
+		}
+		charged = append(charged, b)
+	}
+
+	var wait time.Duration
+	if class == ClassOrders && l.exchange != nil {
+		l.exchange.refill(now)
+		wait = l.exchange.wait()
+		if wait > l.config.Exchange.MaxWait {
+			return l.refuse(ScopeExchange, class, wait-l.config.Exchange.MaxWait)
+		}
+		// Orders queued behind the throttle take their token now, so the
+		// next one waits behind them
+		charged = append(charged, l.exchange)
+	}
+
+	for _, b := range charged {
+		b.tokens--
+		b.used = now
+	}
+	l.counts[count{"", class, "allowed"}]++
+	if wait > 0 {
+		l.counts[count{ScopeExchange, class, "throttled"}]++
+		l.throttled += wait
+	}
+	return Decision{Allowed: true, Wait: wait}
+}
+
+func (l *Limiter) refuse(scope string, class Class, retryAfter time.Duration) Decision {
+	l.counts[count{scope, class, "limited"}]++
+	return Decision{Scope: scope, RetryAfter: retryAfter}
+}
+
+// sweep forgets buckets that have refilled and gone unused for idleAfter,
+// at most once a minute. A forgotten bucket is recreated full, as it was.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < time.Minute {
+		return
+	}
+	l.swept = now
+	for k, b := range l.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.limit.Burst) && now.Sub(b.used) >= idleAfter {
+			delete(l.buckets, k)
+		}
+	}
+}
+
+// Wait blocks for d or until ctx is done, reporting whether the wait ran out
+func Wait(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,130 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+// clock is a Limiter time source moved by hand
+type clock struct{ now time.Time }
+
+func (c *clock) advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestLimiter(config Config) (*Limiter, *clock) {
+	c := &clock{now: time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC)}
+	l := New(Config{})
+	l.now = func() time.Time { return c.now }
+	l.SetConfig(config)
+	return l, c
+}
+
+func TestAllowRefillsTokens(t *testing.T) {
+	l, clock := newTestLimiter(Config{Key: Budgets{Reads: Limit{Rate: 2, Burst: 2}}})
+
+	steps := []struct {
+		name       string
+		advance    time.Duration
+		allowed    bool
+		retryAfter time.Duration
+	}{
+		{"burst", 0, true, 0},
+		{"rest of the burst", 0, true, 0},
+		{"empty", 0, false, 500 * time.Millisecond},
+		{"partly refilled", 250 * time.Millisecond, false, 250 * time.Millisecond},
+		{"one token back", 250 * time.Millisecond, true, 0},
+		{"empty again", 0, false, 500 * time.Millisecond},
+		{"refilled to the burst only", time.Hour, true, 0},
+		{"second of the burst", 0, true, 0},
+		{"burst spent", 0, false, 500 * time.Millisecond},
+	}
+	for _, s := range steps {
+		clock.advance(s.advance)
+		d := l.Allow("key-1", "", ClassReads)
+		if d.Allowed != s.allowed || d.RetryAfter != s.retryAfter {
+			t.Fatalf("%s: got %+v, want allowed %v retrying after %s", s.name, d, s.allowed, s.retryAfter)
+		}
+		if !d.Allowed && d.Scope != ScopeKey {
+			t.Errorf("%s: refused in scope %q, want %q", s.name, d.Scope, ScopeKey)
+		}
+	}
+}
+
+func TestAllowIsolatesKeysAndClasses(t *testing.T) {
+	l, _ := newTestLimiter(Config{
+		Key:     Budgets{Orders: Limit{Rate: 1, Burst: 1}, Reads: Limit{Rate: 1, Burst: 1}},
+		Account: Budgets{Orders: Limit{Rate: 1, Burst: 2}},
+	})
+
+	tests := []struct {
+		name    string
+		key     string
+		account string
+		class   Class
+		allowed bool
+		scope   string
+	}{
+		{"first key", "key-1", "acct-1", ClassOrders, true, ""},
+		{"first key again", "key-1", "acct-1", ClassOrders, false, ScopeKey},
+		{"first key reads", "key-1", "acct-1", ClassReads, true, ""},
+		{"second key on the same account", "key-2", "acct-1", ClassOrders, true, ""},
+		// The account budget is spent by both keys together
+		{"third key on the same account", "key-3", "acct-1", ClassOrders, false, ScopeAccount},
+		{"third key on another account", "key-3", "acct-2", ClassOrders, true, ""},
+		{"no account", "key-4", "", ClassOrders, true, ""},
+	}
+	for _, tc := range tests {
+		d := l.Allow(tc.key, tc.account, tc.class)
+		if d.Allowed != tc.allowed || d.Scope != tc.scope {
+			t.Errorf("%s: got %+v, want allowed %v in scope %q", tc.name, d, tc.allowed, tc.scope)
+		}
+	}
+}
+
+func TestRefusedRequestsAreNotCharged(t *testing.T) {
+	l, _ := newTestLimiter(Config{
+		Key:     Budgets{Orders: Limit{Rate: 1, Burst: 2}},
+		Account: Budgets{Orders: Limit{Rate: 1, Burst: 1}},
+	})
+	l.Allow("key-1", "acct-1", ClassOrders)
+	if d := l.Allow("key-1", "acct-1", ClassOrders); d.Allowed || d.Scope != ScopeAccount {
+		t.Fatalf("second order on the account: %+v, want refused for the account", d)
+	}
+	// The key's second token was not taken by the refused order
+	if d := l.Allow("key-1", "acct-2", ClassOrders); !d.Allowed {
+		t.Errorf("key refused after an order refused for its account: %+v", d)
+	}
+}
+
+func TestExchangeThrottleQueuesOrders(t *testing.T) {
+	l, _ := newTestLimiter(Config{Exchange: Throttle{Limit: Limit{Rate: 10, Burst: 1}, MaxWait: 150 * time.Millisecond}})
+
+	for i, want := range []Decision{
+		{Allowed: true},
+		{Allowed: true, Wait: 100 * time.Millisecond},
+		{Scope: ScopeExchange, RetryAfter: 50 * time.Millisecond},
+	} {
+		if d := l.Allow("key-1", "", ClassOrders); d != want {
+			t.Errorf("order %d: got %+v, want %+v", i+1, d, want)
+		}
+	}
+	if d := l.Allow("key-1", "", ClassReads); !d.Allowed || d.Wait != 0 {
+		t.Errorf("read behind the throttle: %+v, want allowed at once", d)
+	}
+}
+
+func TestSweepForgetsIdleBuckets(t *testing.T) {
+	l, clock := newTestLimiter(Config{Key: Budgets{Reads: Limit{Rate: 1, Burst: 1}}})
+	l.Allow("idle", "", ClassReads)
+	l.Allow("busy", "", ClassReads)
+
+	for elapsed := time.Duration(0); elapsed < idleAfter; elapsed += time.Minute {
+		clock.advance(time.Minute)
+		l.Allow("busy", "", ClassReads)
+	}
+	if _, ok := l.buckets[key{ScopeKey, ClassReads, "idle"}]; ok {
+		t.Error("a bucket idle for the sweep period was kept")
+	}
+	if _, ok := l.buckets[key{ScopeKey, ClassReads, "busy"}]; !ok {
+		t.Error("a bucket in use was forgotten")
+	}
+}
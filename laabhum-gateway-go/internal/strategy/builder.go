@@ -3,7 +3,6 @@ package strategy
 import (
 	"errors"
 	"log"
-	"time"
 
 	"github.com/Mukilan-T/laabhum-gateway-go/internal/oms"
 )
@@ -16,22 +15,15 @@ const (
 )
 
 type Builder struct {
-	logger      *log.Logger
-	thresholds  map[string]int
-	retryPolicy RetryPolicy
-}
-
-type RetryPolicy struct {
-	MaxRetries int
-	Delay      time.Duration
+	logger     *log.Logger
+	thresholds map[string]int
 }
 
 // NewBuilder creates a new Strategy Builder instance
-func NewBuilder(logger *log.Logger, retryPolicy RetryPolicy) *Builder {
+func NewBuilder(logger *log.Logger) *Builder {
 	return &Builder{
-		logger:      logger,
-		thresholds:  map[string]int{"high": 1000, "medium": 100, "low": 10},
-		retryPolicy: retryPolicy,
+		logger:     logger,
+		thresholds: map[string]int{"high": 1000, "medium": 100, "low": 10},
 	}
 }
 
@@ -61,17 +53,3 @@ func (b *Builder) ProcessOrder(order oms.Order) (string, error) {
 
 	return strategy, nil
 }
-
-// RetryPolicy related functions
-func (b *Builder) retryOperation(operation func() error) error {
-	var lastError error
-	for i := 0; i < b.retryPolicy.MaxRetries; i++ {
-		lastError = operation()
-		if lastError == nil {
-			return nil
-		}
-		b.logger.Printf("Retrying operation, attempt %d/%d\n", i+1, b.retryPolicy.MaxRetries)
-		time.Sleep(b.retryPolicy.Delay)
-	}
-	return lastError
-}
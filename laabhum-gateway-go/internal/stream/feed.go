@@ -0,0 +1,354 @@
+// Package stream follows the OMS event stream and fans its events out to
+// gateway subscribers, numbering them so clients can resume after a disconnect.
+package stream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-gateway-go/pkg/logger"
+)
+
+// Channels events are grouped into for subscribers
+const (
+	ChannelOrders    = "orders"
+	ChannelFills     = "fills"
+	ChannelPositions = "positions"
+	ChannelTicks     = "ticks"
+	ChannelSystem    = "system"
+)
+
+// Channels lists every channel a client can subscribe to
+var Channels = []string{ChannelOrders, ChannelFills, ChannelPositions, ChannelTicks, ChannelSystem}
+
+// TypeResync is the type of the system event published when the feed lost
+// events from the OMS, so subscribers know to reload their state
+const TypeResync = "resync"
+
+// DefaultHistory is how many events a Feed keeps for subscribers resuming after a disconnect
+const DefaultHistory = 4096
+
+const (
+	streamPath = "/oms/events/stream"
+	minBackoff = time.Second
+	maxBackoff = 30 * time.Second
+	// idleTimeout is how long the OMS may stay silent, keep-alives included,
+	// before the connection is considered dead
+	idleTimeout = 45 * time.Second
+)
+
+// Event is an OMS event as delivered to gateway subscribers
+type Event struct {
+	Sequence    uint64 `json:"seq"`
+	OMSSequence uint64 `json:"oms_seq,omitempty"`
+	Channel     string `json:"channel"`
+	Type        string `json:"type"`
+	Symbol      string `json:"symbol,omitempty"`
+	// UserID is who placed the order the event is about; ticks and system
+	// events have none
+	UserID    string          `json:"user_id,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+// omsEvent is an event as sent on the OMS event stream
+type omsEvent struct {
+	Sequence  uint64          `json:"sequence"`
+	Type      string          `json:"type"`
+	Timestamp time.Time       `json:"timestamp"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// domainEvent is the envelope order, trade, position and tick events carry
+type domainEvent struct {
+	SchemaVersion int             `json:"schema_version"`
+	Symbol        string          `json:"symbol"`
+	UserID        string          `json:"user_id"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// ChannelOf returns the subscriber channel an OMS event type belongs to
+func ChannelOf(eventType string) string {
+	switch {
+	case strings.HasPrefix(eventType, "order."):
+		return ChannelOrders
+	case eventType == "trade.executed":
+		return ChannelFills
+	case strings.HasPrefix(eventType, "position."):
+		return ChannelPositions
+	case eventType == "tick":
+		return ChannelTicks
+	default:
+		return ChannelSystem
+	}
+}
+
+// Feed follows the OMS event stream, reconnecting with backoff and resuming
+// from the last OMS sequence it saw, and republishes each event with its own
+// sequence number. The gateway sequence keeps increasing across OMS
+// reconnects and restarts, so it is the one clients resume from.
+type Feed struct {
+	logger  *logger.Logger
+	baseURL string
+	client  *http.Client
+
+	mutex       sync.RWMutex
+	disconnect  context.CancelFunc // Ends the current connection to the OMS
+	moved       bool               // The connection ended because baseURL changed
+	sequence    uint64
+	omsSequence uint64
+	history     []Event
+	limit       int
+	subscribers map[chan Event]struct{}
+}
+
+func NewFeed(logger *logger.Logger, baseURL string, history int) *Feed {
+	if history <= 0 {
+		history = DefaultHistory
+	}
+	return &Feed{
+		logger:      logger,
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		client:      &http.Client{},
+		limit:       history,
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Run follows the OMS event stream until ctx is cancelled
+func (f *Feed) Run(ctx context.Context) {
+	backoff := minBackoff
+	for {
+		start := time.Now()
+		err := f.follow(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		// A connection that stayed up for a while starts the backoff over
+		if time.Since(start) > maxBackoff {
+			backoff = minBackoff
+		}
+		f.mutex.Lock()
+		moved := f.moved
+		f.moved = false
+		f.mutex.Unlock()
+		if moved {
+			backoff = minBackoff
+			continue
+		}
+		f.logger.Warnf("OMS event stream disconnected, retrying in %v: %v", backoff, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// follow reads one connection to the OMS event stream until it fails
+func (f *Feed) follow(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	f.mutex.Lock()
+	url := f.baseURL + streamPath
+	if f.omsSequence > 0 {
+		url += "?since=" + strconv.FormatUint(f.omsSequence, 10)
+	}
+	f.disconnect = cancel
+	f.moved = false
+	f.mutex.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	f.logger.Infof("Following OMS event stream at %s", url)
+
+	watchdog := time.AfterFunc(idleTimeout, cancel)
+	defer watchdog.Stop()
+
+	var eventType string
+	var data strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		watchdog.Reset(idleTimeout)
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if data.Len() > 0 {
+				f.dispatch(eventType, data.String())
+			}
+			eventType = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// Keep-alive comment
+		case strings.HasPrefix(line, "event:"):
+			eventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("stream closed by the OMS")
+}
+
+// SetBaseURL moves the feed to the OMS at baseURL, reconnecting at once. The
+// new OMS numbers its events afresh, so subscribers are told to resynchronise.
+func (f *Feed) SetBaseURL(baseURL string) {
+	baseURL = strings.TrimRight(baseURL, "/")
+	f.mutex.Lock()
+	if baseURL == f.baseURL {
+		f.mutex.Unlock()
+		return
+	}
+	previous := f.baseURL
+	f.baseURL = baseURL
+	f.omsSequence = 0
+	f.moved = true
+	if f.disconnect != nil {
+		f.disconnect()
+	}
+	f.mutex.Unlock()
+
+	f.logger.Infof("Moving OMS event stream from %s to %s", previous, baseURL)
+	f.publish(0, Event{
+		Channel:   ChannelSystem,
+		Type:      TypeResync,
+		Timestamp: time.Now(),
+	})
+}
+
+func (f *Feed) dispatch(eventType, data string) {
+	if eventType == "gap" {
+		f.logger.Warnf("OMS event stream skipped events after %s", data)
+		f.mutex.Lock()
+		f.omsSequence = 0
+		f.mutex.Unlock()
+		f.publish(0, Event{
+			Channel:   ChannelSystem,
+			Type:      TypeResync,
+			Timestamp: time.Now(),
+			Data:      json.RawMessage(data),
+		})
+		return
+	}
+
+	var source omsEvent
+	if err := json.Unmarshal([]byte(data), &source); err != nil {
+		f.logger.Errorf("Decoding OMS event: %v", err)
+		return
+	}
+	event := Event{
+		OMSSequence: source.Sequence,
+		Channel:     ChannelOf(source.Type),
+		Type:        source.Type,
+		Timestamp:   source.Timestamp,
+		Data:        source.Data,
+	}
+	var domain domainEvent
+	if json.Unmarshal(source.Data, &domain) == nil && domain.SchemaVersion > 0 {
+		event.Symbol = domain.Symbol
+		event.UserID = domain.UserID
+		event.Data = domain.Data
+	}
+	f.publish(source.Sequence, event)
+}
+
+// publish stamps the event with the next gateway sequence, records it and
+// delivers it to every subscriber. Subscribers that are not keeping up miss
+// the event and catch up from the history.
+func (f *Feed) publish(omsSequence uint64, event Event) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if omsSequence > 0 {
+		f.omsSequence = omsSequence
+	}
+	f.sequence++
+	event.Sequence = f.sequence
+	f.history = append(f.history, event)
+	if len(f.history) >= 2*f.limit {
+		f.history = append([]Event(nil), f.history[len(f.history)-f.limit:]...)
+	}
+	for ch := range f.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel receiving every event published from now on
+func (f *Feed) Subscribe(buffer int) chan Event {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	ch := make(chan Event, buffer)
+	f.subscribers[ch] = struct{}{}
+	return ch
+}
+
+// Unsubscribe stops delivery to ch and closes it
+func (f *Feed) Unsubscribe(ch chan Event) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, ok := f.subscribers[ch]; ok {
+		delete(f.subscribers, ch)
+		close(ch)
+	}
+}
+
+// Sequence returns the sequence of the latest event
+func (f *Feed) Sequence() uint64 {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	return f.sequence
+}
+
+// Since returns the retained events after sequence. The second result is false
+// when some of them have already been dropped from the history, or sequence
+// was never issued by this feed, and the caller must resynchronise.
+func (f *Feed) Since(sequence uint64) ([]Event, bool) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	if sequence > f.sequence {
+		return nil, false
+	}
+	if sequence == f.sequence {
+		return nil, true
+	}
+	complete := len(f.history) > 0 && f.history[0].Sequence <= sequence+1
+	start := 0
+	for start < len(f.history) && f.history[start].Sequence <= sequence {
+		start++
+	}
+	return append([]Event(nil), f.history[start:]...), complete
+}
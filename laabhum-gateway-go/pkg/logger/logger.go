@@ -13,6 +13,7 @@ import (
 type Logger struct {
 	*zap.SugaredLogger
 	*log.Logger
+	level zap.AtomicLevel
 }
 
 // New creates a new Logger instance with the given log level
@@ -20,11 +21,12 @@ func New(logLevel string) *Logger {
 	// Create zap logger
 	zapConfig := zap.NewProductionEncoderConfig()
 	zapConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	level := zap.NewAtomicLevelAt(getZapLevel(logLevel))
 
 	zapCore := zapcore.NewCore(
 		zapcore.NewJSONEncoder(zapConfig),
 		zapcore.AddSync(os.Stdout),
-		getZapLevel(logLevel),
+		level,
 	)
 
 	zapLogger := zap.New(zapCore, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel))
@@ -35,9 +37,15 @@ func New(logLevel string) *Logger {
 	return &Logger{
 		SugaredLogger: zapLogger.Sugar(),
 		Logger:        stdLogger,
+		level:         level,
 	}
 }
 
+// SetLevel changes the level of the zap logger while it is in use
+func (l *Logger) SetLevel(logLevel string) {
+	l.level.SetLevel(getZapLevel(logLevel))
+}
+
 // Writer returns an io.Writer for the standard Logger
 func (l *Logger) Writer() io.Writer {
 	return l.Logger.Writer()
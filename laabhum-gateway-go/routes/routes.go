@@ -2,58 +2,107 @@ package routes
 
 import (
 	"github.com/Mukilan-T/laabhum-gateway-go/api"
+	"github.com/Mukilan-T/laabhum-gateway-go/internal/auth"
 	"github.com/Mukilan-T/laabhum-gateway-go/internal/oms"
+	"github.com/Mukilan-T/laabhum-gateway-go/internal/ratelimit"
 	"github.com/Mukilan-T/laabhum-gateway-go/pkg/logger"
     "github.com/gin-gonic/gin"
     "net/http"
 )
 
-func SetupRoutes(logger *logger.Logger, omsClient *oms.Client) *gin.Engine {
+// SetupRoutes builds the gateway API. Every /oms route requires an API key
+// or bearer token accepted by guard and a role granting its permission, and
+// routes naming orders or positions, in the path or the body, also require
+// the caller to own every one unless they may act on any order. Callers are
+// then charged to their rate limits. /ws requires the same credentials, which
+// browsers may send as ?token=, and streams callers only their own orders'
+// events unless they may act on any order.
+func SetupRoutes(logger *logger.Logger, omsClient *oms.Client, streams *api.StreamHandler, guard *auth.Guard, limiter *ratelimit.Limiter) *gin.Engine {
 	router := gin.Default()
 	handlers := api.NewHandlers(logger, omsClient)
-router.GET("/", func(c *gin.Context) {
-    c.JSON(http.StatusOK, gin.H{"message": "Server is running"})
-})
+	// The gateway is degraded while OMS calls fail fast on open circuits
+	router.GET("/", func(c *gin.Context) {
+		if open := omsClient.OpenCircuits(); len(open) > 0 {
+			c.JSON(http.StatusOK, gin.H{"message": "Server is running", "status": "degraded", "open_circuits": open})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "Server is running", "status": "ok"})
+	})
+	router.GET("/metrics", guard.Authenticate(), guard.Require(auth.PermViewMetrics), func(c *gin.Context) {
+		c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		limiter.WriteMetrics(c.Writer)
+	})
+
+	omsRoutes := router.Group("/oms", guard.Authenticate())
+
+	// Route policies
+	view := guard.Require(auth.PermViewOrders)
+	trade := guard.Require(auth.PermTrade)
+	killSwitch := guard.Require(auth.PermKillSwitch)
+	// Charged after the route's policies, so refused requests spend no budget
+	limit := limiter.Middleware(logger)
+	// The OMS does not check that a child belongs to the parent named with
+	// it, so every order in the path is checked
+	owner := guard.Owner(auth.Params("parentID", "childID", "orderID", "id"), handlers.OrderOwner)
+	bodyOwner := guard.Owner(auth.BodyField("id"), handlers.OrderOwner)
+	positionOwner := guard.Owner(auth.BodyField("position_id"), handlers.PositionOwner)
 
 	// Scalper Order Routes
-	router.POST("/oms/scalper/order", handlers.CreateScalperOrder)
-	router.POST("/oms/scalper/order/:parentID/execute", handlers.ExecuteAllChildTrades)
-	router.POST("/oms/scalper/order/:parentID/:childID/execute", handlers.ExecuteSpecificChild)
-	router.POST("/oms/scalper/order/:parentID/ctc", handlers.CreateCTC)
-	router.PATCH("/oms/scalper/order/:orderType/:parentID/modify", handlers.ModifyOrder)
-	router.PATCH("/oms/scalper/order/:orderType/:parentID/:childID/modify", handlers.ModifyChildOrder)
+	omsRoutes.POST("/scalper/order", trade, limit, handlers.CreateScalperOrder)
+	omsRoutes.POST("/scalper/order/:parentID/execute", trade, owner, limit, handlers.ExecuteAllChildTrades)
+	omsRoutes.POST("/scalper/order/:parentID/:childID/execute", trade, owner, limit, handlers.ExecuteSpecificChild)
+	omsRoutes.POST("/scalper/order/:parentID/ctc", trade, owner, limit, handlers.CreateCTC)
+	omsRoutes.PATCH("/scalper/order/:orderType/:parentID/modify", trade, owner, limit, handlers.ModifyOrder)
+	omsRoutes.PATCH("/scalper/order/:orderType/:parentID/:childID/modify", trade, owner, limit, handlers.ModifyChildOrder)
+
+	// Multi-leg strategy orders
+	omsRoutes.POST("/strategy/order", trade, limit, handlers.CreateMultiLegOrder)
+	omsRoutes.GET("/strategy/order/:parentID", view, owner, limit, handlers.GetMultiLegOrder)
 
 	// Exit Trade Routes
-	router.POST("/oms/scalper/exit/trade", handlers.ExitAllTrades)
-	router.POST("/oms/scalper/trade/:parentID/exit", handlers.ExitChildTrades)
-	router.POST("/oms/scalper/trade/:parentID/:childID/exit", handlers.ExitSpecificChild)
+	// Exiting every trade at once halts trading, so it is the kill switch
+	omsRoutes.POST("/scalper/exit/trade", killSwitch, limit, handlers.ExitAllTrades)
+	omsRoutes.POST("/scalper/trade/:parentID/exit", trade, owner, limit, handlers.ExitChildTrades)
+	omsRoutes.POST("/scalper/trade/:parentID/:childID/exit", trade, owner, limit, handlers.ExitSpecificChild)
 
     // Cancel all child orders
-    router.POST("/oms/scalper/order/:parentID/child/:childID/cancel", handlers.CancelSpecificChildOrder)
-    router.POST("/oms/scalper/order/:parentID/order/:orderID/cancel", handlers.CancelSpecificOrder)
+    omsRoutes.POST("/scalper/order/:parentID/child/:childID/cancel", trade, owner, limit, handlers.CancelSpecificChildOrder)
+    omsRoutes.POST("/scalper/order/:parentID/order/:orderID/cancel", trade, owner, limit, handlers.CancelSpecificOrder)
 
     // Get trades for a specific parent order
-    router.GET("/oms/scalper/trades/:parentID", handlers.GetTrades)
+    omsRoutes.GET("/scalper/trades/:parentID", view, owner, limit, handlers.GetTrades)
 
     // Delete a parent order
-    router.DELETE("/oms/scalper/order/:parentID", handlers.DeleteParentOrder)
+    omsRoutes.DELETE("/scalper/order/:parentID", trade, owner, limit, handlers.DeleteParentOrder)
 
     // Activate and cancel stop loss for child orders
-    router.PATCH("/oms/scalper/order/sl/:parentID/:childID/active", handlers.ActivateStopLoss)
-    router.PATCH("/oms/scalper/order/sl/:parentID/:childID/cancel", handlers.CancelStopLoss)
+    omsRoutes.PATCH("/scalper/order/sl/:parentID/:childID/active", trade, owner, limit, handlers.ActivateStopLoss)
+    omsRoutes.PATCH("/scalper/order/sl/:parentID/:childID/cancel", trade, owner, limit, handlers.CancelStopLoss)
 
     // General Order Routes
-    router.GET("/oms/orders", handlers.GetOrders)
-    router.PUT("/oms/order", handlers.CreateOrder)
-    router.POST("/oms/order/execute", handlers.ExecuteOrder)
-    router.DELETE("/oms/order/cancel", handlers.CancelOrder)
+    omsRoutes.GET("/orders", view, limit, handlers.GetOrders)
+    omsRoutes.GET("/orders/:id/timeline", view, owner, limit, handlers.GetOrderTimeline)
+    omsRoutes.PUT("/order", trade, limit, handlers.CreateOrder)
+    omsRoutes.POST("/order/execute", trade, bodyOwner, limit, handlers.ExecuteOrder)
+    omsRoutes.DELETE("/order/cancel", trade, bodyOwner, limit, handlers.CancelOrder)
+
+    // Futures and options
+    omsRoutes.GET("/options/expiries", view, limit, handlers.GetExpiries)
+    omsRoutes.GET("/options/chain", view, limit, handlers.GetOptionChain)
+
+    // Trading calendar
+    omsRoutes.GET("/calendar/status", view, limit, handlers.GetMarketStatus)
+    omsRoutes.GET("/calendar/holidays", view, limit, handlers.GetHolidays)
 
     // Position Routes
-    router.GET("/oms/positions", handlers.SyncPositions)
-    router.GET("/oms/position/sync", handlers.SyncPositions)
-    router.PUT("/oms/position/convert", handlers.SyncPositions)
-    router.POST("/oms/position/order", handlers.CreateOrder)
-    router.DELETE("/oms/position/order", handlers.CancelOrder)
+    omsRoutes.GET("/positions", view, limit, handlers.SyncPositions)
+    omsRoutes.GET("/position/sync", view, limit, handlers.SyncPositions)
+    omsRoutes.PUT("/position/convert", trade, positionOwner, limit, handlers.ConvertPosition)
+    omsRoutes.POST("/position/order", trade, limit, handlers.CreateOrder)
+    omsRoutes.DELETE("/position/order", trade, bodyOwner, limit, handlers.CancelOrder)
+
+    // Streaming order updates, fills, positions and ticks
+    router.GET("/ws", auth.QueryToken("token"), guard.Authenticate(), guard.Require(auth.PermViewOrders), streams.ServeWS)
 
     return router
 }
\ No newline at end of file
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	orderv1 "github.com/Mukilan-T/laabhum-api-go/order/v1"
+	"github.com/Mukilan-T/laabhum-oms-go/instruments"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+	"github.com/Mukilan-T/laabhum-oms-go/service"
+	"github.com/gin-gonic/gin"
+)
+
+// The order commands below are shared by the HTTP and NATS APIs so both
+// transports answer with the same status codes and bodies.
+
+// statusOf returns the status a failure is answered with: 404 for missing
+// records, 400 for invalid requests, 409 for requests the state of the order,
+// market or account rules out, and fallback for anything else
+func statusOf(err error, fallback int) int {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidOrder), errors.Is(err, orderv1.ErrInvalidValue),
+		errors.Is(err, instruments.ErrUnknownInstrument):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrOrderState), errors.Is(err, service.ErrIdempotencyConflict),
+		errors.Is(err, repository.ErrDuplicateClientOrderID),
+		errors.Is(err, service.ErrMarketClosed), errors.Is(err, service.ErrInsufficientFunds),
+		errors.Is(err, service.ErrInsufficientHoldings):
+		return http.StatusConflict
+	}
+	return fallback
+}
+
+// createOrder places order once per idempotency key or client order ID. A
+// replay answers 200 with the original order; reusing a key for a different
+// order is a conflict.
+func (h *Handlers) createOrder(svc *service.OMSService, key string, order models.Order) (int, gin.H) {
+	createdOrder, replayed, err := svc.SubmitOrder(key, order)
+	if err != nil {
+		h.logger.Printf("Order creation failed: %v", err)
+		return statusOf(err, http.StatusInternalServerError), gin.H{"error": "Order creation failed: " + err.Error()}
+	}
+	if replayed {
+		return http.StatusOK, gin.H{"message": "Order already created", "order": createdOrder, "replayed": true}
+	}
+	return http.StatusCreated, gin.H{"message": "Order created successfully", "order": createdOrder}
+}
+
+func (h *Handlers) cancelOrder(svc *service.OMSService, orderID string) (int, gin.H) {
+	if err := svc.CancelOrder(orderID); err != nil {
+		h.logger.Printf("Order cancellation failed: %v", err)
+		return statusOf(err, http.StatusInternalServerError), gin.H{"error": "Order cancellation failed: " + err.Error()}
+	}
+	return http.StatusOK, gin.H{"message": "Order cancelled successfully"}
+}
+
+// childOf checks that orderID is one of parentID's children; an empty parentID accepts any order
+func childOf(svc *service.OMSService, parentID, orderID string) error {
+	if parentID == "" {
+		return nil
+	}
+	child, err := svc.GetOrder(orderID)
+	if err != nil {
+		return err
+	}
+	if child.ParentID != parentID {
+		return fmt.Errorf("order %s is not a child of %s", orderID, parentID)
+	}
+	return nil
+}
+
+// modifyOrder amends orderID; a non-empty parentID requires the order to be one of its children
+func (h *Handlers) modifyOrder(svc *service.OMSService, parentID, orderID string, changes models.Order) (int, gin.H) {
+	if err := childOf(svc, parentID, orderID); err != nil {
+		h.logger.Printf("Order modification failed: %v", err)
+		return http.StatusBadRequest, gin.H{"error": "Order modification failed: " + err.Error()}
+	}
+
+	modified, err := svc.ModifyOrder(orderID, changes)
+	if err != nil {
+		h.logger.Printf("Order modification failed: %v", err)
+		return statusOf(err, http.StatusInternalServerError), gin.H{"error": "Order modification failed: " + err.Error()}
+	}
+	return http.StatusOK, gin.H{"message": "Order modified successfully", "order": modified}
+}
+
+func (h *Handlers) setStopLoss(svc *service.OMSService, parentID, orderID string, active bool) (int, gin.H) {
+	if err := childOf(svc, parentID, orderID); err != nil {
+		h.logger.Printf("Stop loss update failed: %v", err)
+		return http.StatusBadRequest, gin.H{"error": "Stop loss update failed: " + err.Error()}
+	}
+
+	order, err := svc.SetStopLoss(orderID, active)
+	if err != nil {
+		h.logger.Printf("Stop loss update failed: %v", err)
+		return statusOf(err, http.StatusInternalServerError), gin.H{"error": "Stop loss update failed: " + err.Error()}
+	}
+	return http.StatusOK, gin.H{"message": "Stop loss updated successfully", "order": order}
+}
+
+// positions reprices the open positions and returns them, only userID's when
+// it is not empty
+func (h *Handlers) positions(svc *service.OMSService, userID string) (int, gin.H) {
+	if err := svc.SyncPositions(); err != nil {
+		h.logger.Printf("Failed to sync positions: %v", err)
+		return statusOf(err, http.StatusInternalServerError), gin.H{"error": "Failed to sync positions: " + err.Error()}
+	}
+	positions, err := svc.GetPositions(userID)
+	if err != nil {
+		h.logger.Printf("Failed to retrieve positions: %v", err)
+		return statusOf(err, http.StatusInternalServerError), gin.H{"error": "Failed to retrieve positions: " + err.Error()}
+	}
+	return http.StatusOK, gin.H{"message": "Positions synced successfully", "positions": positions}
+}
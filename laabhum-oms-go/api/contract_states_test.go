@@ -0,0 +1,142 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/audit"
+	"github.com/Mukilan-T/laabhum-oms-go/calendar"
+	"github.com/Mukilan-T/laabhum-oms-go/instruments"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/service"
+)
+
+// marketOpen pins every session to a Wednesday morning, when all segments
+// trade, so verification does not depend on when it runs
+var marketOpen = time.Date(2026, time.January, 7, 10, 30, 0, 0, calendar.IST)
+
+// state seeds an OMS and returns the placeholders its interactions use
+type state func(svc *service.OMSService) (map[string]string, error)
+
+// states are the provider states consumers may name in their pacts
+var states = map[string]state{
+	"":                                    func(*service.OMSService) (map[string]string, error) { return nil, nil },
+	"a parent order with a pending child": parentWithChild,
+	"an order is pending":                 orderPending,
+	"an open position":                    positionOpen,
+	"option contracts are listed":         optionsListed,
+	"a multi-leg order is placed":         multiLegPlaced,
+}
+
+// stateUser places the orders of every state
+const stateUser = "trader-1"
+
+// asTrader places orders as stateUser, as the gateway does for its callers
+func asTrader(svc *service.OMSService) *service.OMSService {
+	return svc.As(audit.Source{Actor: audit.User(stateUser), Account: "acct-1"})
+}
+
+// stateOrder is the limit order the states place
+func stateOrder() models.Order {
+	return models.Order{
+		Symbol:   "INFY",
+		Quantity: 1,
+		Price:    1500,
+		Side:     models.SideBuy,
+		Type:     models.LimitOrder,
+		Strategy: models.StrategyDayTrading,
+		Product:  models.ProductMIS,
+	}
+}
+
+func orderPending(svc *service.OMSService) (map[string]string, error) {
+	order, err := asTrader(svc).CreateOrder(stateOrder())
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"orderID": order.ID}, nil
+}
+
+func parentWithChild(svc *service.OMSService) (map[string]string, error) {
+	parent, err := asTrader(svc).CreateOrder(stateOrder())
+	if err != nil {
+		return nil, err
+	}
+	child := stateOrder()
+	child.ParentID = parent.ID
+	child.StopPrice = 1490
+	created, err := asTrader(svc).CreateOrder(child)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"parentID": parent.ID, "childID": created.ID}, nil
+}
+
+func positionOpen(svc *service.OMSService) (map[string]string, error) {
+	order := stateOrder()
+	order.Type = models.MarketOrder
+	if _, err := asTrader(svc).CreateOrder(order); err != nil {
+		return nil, err
+	}
+	positions, err := svc.GetPositions("")
+	if err != nil {
+		return nil, err
+	}
+	if len(positions) == 0 {
+		return nil, errors.New("executed market order opened no position")
+	}
+	return map[string]string{"positionID": positions[0].ID}, nil
+}
+
+func optionsListed(svc *service.OMSService) (map[string]string, error) {
+	// Expiries are checked against the wall clock, not the pinned session time
+	expiry := time.Now().AddDate(0, 1, 0).Format("2006-01-02")
+	option := func(symbol string, kind models.InstrumentType) instruments.Instrument {
+		return instruments.Instrument{
+			Exchange:   "NFO",
+			Symbol:     symbol,
+			Segment:    models.SegmentFNO,
+			Type:       kind,
+			Underlying: "NIFTY",
+			Expiry:     expiry,
+			Strike:     24000,
+			TickSize:   0.05,
+			LotSize:    50,
+		}
+	}
+	master, err := instruments.NewMaster([]instruments.Instrument{
+		{Exchange: "NSE", Symbol: "INFY", Segment: models.SegmentEquity, TickSize: 0.05, LotSize: 1},
+		option("NIFTY24000CE", models.InstrumentCall),
+		option("NIFTY24000PE", models.InstrumentPut),
+	})
+	if err != nil {
+		return nil, err
+	}
+	svc.SetInstruments(master)
+	return map[string]string{
+		"underlying": "NIFTY",
+		"expiry":     expiry,
+		"call":       "NFO:NIFTY24000CE",
+		"put":        "NFO:NIFTY24000PE",
+	}, nil
+}
+
+func multiLegPlaced(svc *service.OMSService) (map[string]string, error) {
+	params, err := optionsListed(svc)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := asTrader(svc).CreateMultiLegOrder(models.MultiLegOrder{
+		Name:     "straddle",
+		Quantity: 50,
+		Product:  models.ProductNRML,
+		Legs: []models.StrategyLeg{
+			{Symbol: params["call"], Side: models.SideBuy, Ratio: 1, Type: models.LimitOrder, Price: 10},
+			{Symbol: params["put"], Side: models.SideBuy, Ratio: 1, Type: models.LimitOrder, Price: 10},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"parentID": summary.Parent.ID}, nil
+}
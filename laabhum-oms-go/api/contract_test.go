@@ -0,0 +1,236 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-api-go/contract"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+	"github.com/Mukilan-T/laabhum-oms-go/service"
+	"github.com/gin-gonic/gin"
+)
+
+// provider is the name consumers give the OMS in their pacts
+const provider = "laabhum-oms"
+
+// pacts is where consumers publish the pacts the OMS is verified against
+const pacts = "../../laabhum-api-go/contract/pacts"
+
+// streamWait is how long a streaming response is given to send its headers
+const streamWait = 200 * time.Millisecond
+
+// TestConsumerContracts replays every interaction consumers publish against
+// the router on a fresh in-memory OMS in the interaction's state, so a route,
+// method or payload a consumer relies on that the OMS no longer serves fails
+func TestConsumerContracts(t *testing.T) {
+	published, err := contract.LoadDir(pacts, provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(published) == 0 {
+		t.Fatalf("no pacts for %s in %s", provider, pacts)
+	}
+	gin.SetMode(gin.TestMode)
+
+	for _, pact := range published {
+		for _, interaction := range pact.Interactions {
+			t.Run(pact.Consumer+"/"+interaction.Description, func(t *testing.T) {
+				if err := verify(interaction); err != nil {
+					t.Error(err)
+				}
+			})
+		}
+	}
+}
+
+// setUp builds a fresh OMS in the named state and returns its router with
+// the values of the placeholders the state's requests use
+func setUp(name string) (http.Handler, map[string]string, error) {
+	setup, ok := states[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown provider state %q", name)
+	}
+	svc := service.NewOMSService(repository.NewInMemoryOrderRepository())
+	svc.SetClock(func() time.Time { return marketOpen })
+	params, err := setup(svc)
+	if err != nil {
+		return nil, nil, err
+	}
+	if params == nil {
+		params = map[string]string{}
+	}
+	params["userID"] = stateUser
+	// Fields a consumer sends that the OMS does not read are drift too
+	opts := Options{DisallowUnknownFields: true}
+	return SetupRoutes(log.New(io.Discard, "", 0), svc, nil, opts), params, nil
+}
+
+// verify replays interaction and compares the reply with the one expected
+func verify(interaction contract.Interaction) error {
+	handler, params, err := setUp(interaction.State)
+	if err != nil {
+		return fmt.Errorf("setting up %q: %w", interaction.State, err)
+	}
+	req, err := build(interaction.Request, params)
+	if err != nil {
+		return err
+	}
+
+	recorder := httptest.NewRecorder()
+	if interaction.Request.Stream {
+		ctx, cancel := context.WithTimeout(req.Context(), streamWait)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+	handler.ServeHTTP(recorder, req)
+
+	want := interaction.Response
+	if recorder.Code != want.Status {
+		return fmt.Errorf("%s %s returned %d, want %d: %s",
+			req.Method, req.URL.RequestURI(), recorder.Code, want.Status, strings.TrimSpace(recorder.Body.String()))
+	}
+	for name, prefix := range want.Headers {
+		if value := recorder.Header().Get(name); !strings.HasPrefix(value, prefix) {
+			return fmt.Errorf("%s %s returned %s %q, want %q", req.Method, req.URL.RequestURI(), name, value, prefix)
+		}
+	}
+	if interaction.Request.Stream || len(want.Body) == 0 {
+		return nil
+	}
+
+	var expected, actual interface{}
+	if err := json.Unmarshal(want.Body, &expected); err != nil {
+		return fmt.Errorf("expected body: %w", err)
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &actual); err != nil {
+		return fmt.Errorf("%s %s returned a body that is not JSON: %w", req.Method, req.URL.RequestURI(), err)
+	}
+	if problems := match("body", expected, actual); len(problems) > 0 {
+		return fmt.Errorf("%s %s: %s", req.Method, req.URL.RequestURI(), strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+var placeholder = regexp.MustCompile(`\{[A-Za-z]+\}`)
+
+// build fills the placeholders of r from params
+func build(r contract.Request, params map[string]string) (*http.Request, error) {
+	pairs := make([]string, 0, 2*len(params))
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	fill := strings.NewReplacer(pairs...).Replace
+
+	target := fill(r.Path)
+	body := fill(string(r.Body))
+	filled := []string{target, body}
+	query := url.Values{}
+	for name, value := range r.Query {
+		query.Set(name, fill(value))
+		filled = append(filled, query.Get(name))
+	}
+	for _, filled := range filled {
+		if missing := placeholder.FindString(filled); missing != "" {
+			return nil, fmt.Errorf("provider state does not set %s used by %s %s", missing, r.Method, r.Path)
+		}
+	}
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req := httptest.NewRequest(r.Method, target, strings.NewReader(body))
+	if len(r.Body) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// match compares actual against the shape of expected: objects must have
+// every expected key, arrays every element shaped like the first expected
+// one, and scalars the same JSON type. A null expectation matches anything.
+func match(path string, expected, actual interface{}) []string {
+	switch expected := expected.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		object, ok := actual.(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s is %s, want an object", path, kind(actual))}
+		}
+		keys := make([]string, 0, len(expected))
+		for key := range expected {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var problems []string
+		for _, key := range keys {
+			value, ok := object[key]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s.%s is missing", path, key))
+				continue
+			}
+			problems = append(problems, match(path+"."+key, expected[key], value)...)
+		}
+		return problems
+	case []interface{}:
+		array, ok := actual.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s is %s, want an array", path, kind(actual))}
+		}
+		if len(expected) == 0 {
+			return nil
+		}
+		if len(array) == 0 {
+			return []string{fmt.Sprintf("%s is empty, want elements like %s", path, example(expected[0]))}
+		}
+		var problems []string
+		for i, element := range array {
+			problems = append(problems, match(fmt.Sprintf("%s[%d]", path, i), expected[0], element)...)
+		}
+		return problems
+	default:
+		if kind(expected) != kind(actual) {
+			return []string{fmt.Sprintf("%s is %s, want %s", path, kind(actual), kind(expected))}
+		}
+		return nil
+	}
+}
+
+// kind names the JSON type of a decoded value
+func kind(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func example(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return kind(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,500 @@
+package api
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	omsv1 "github.com/Mukilan-T/laabhum-api-go/oms/v1"
+	orderv1 "github.com/Mukilan-T/laabhum-api-go/order/v1"
+	"github.com/Mukilan-T/laabhum-oms-go/audit"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/events"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+	"github.com/Mukilan-T/laabhum-oms-go/service"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// GRPCServer serves the OMS over gRPC. Every call fails with the status the
+// HTTP API would answer with, carried in an omsv1.Error detail, and reads the
+// caller's identity from the same headers sent as gRPC metadata.
+type GRPCServer struct {
+	omsv1.UnimplementedOrderManagementServer
+	logger     *log.Logger
+	omsService *service.OMSService
+	opts       Options
+}
+
+func NewGRPCServer(logger *log.Logger, omsService *service.OMSService, opts Options) *GRPCServer {
+	return &GRPCServer{logger: logger, omsService: omsService, opts: opts}
+}
+
+// Register adds the OMS service to server
+func (s *GRPCServer) Register(server *grpc.Server) {
+	omsv1.RegisterOrderManagementServer(server, s)
+}
+
+// ServerOptions returns the interceptors refusing calls that do not carry the
+// service token as a bearer token in their authorization metadata
+func (s *GRPCServer) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := s.authenticate(ctx); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := s.authenticate(stream.Context()); err != nil {
+				return err
+			}
+			return handler(srv, stream)
+		}),
+	}
+}
+
+func (s *GRPCServer) authenticate(ctx context.Context) error {
+	if s.opts.ServiceToken == "" {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		if s.opts.isServiceToken(strings.TrimPrefix(value, "Bearer ")) {
+			return nil
+		}
+	}
+	return omsv1.NewError(http.StatusUnauthorized, "Invalid or missing service token")
+}
+
+// service returns the OMS service attributed to the caller, echoing the
+// request ID back in the response header. The user and account metadata are
+// only read when the service token is required, which the caller then passed.
+func (s *GRPCServer) service(ctx context.Context) *service.OMSService {
+	md, _ := metadata.FromIncomingContext(ctx)
+	value := func(header string) string {
+		if values := md.Get(strings.ToLower(header)); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	requestID := value(RequestIDHeader)
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(RequestIDHeader), requestID))
+
+	source := audit.Source{
+		Actor:     audit.User(""),
+		Reason:    value(ReasonHeader),
+		RequestID: requestID,
+	}
+	if s.opts.ServiceToken != "" {
+		source.Actor = audit.User(value(UserIDHeader))
+		source.Account = value(AccountIDHeader)
+	}
+	return s.omsService.As(source)
+}
+
+// fail logs and returns err as a status error answered with httpStatus, or
+// with the status the HTTP API gives errors of its kind
+func (s *GRPCServer) fail(httpStatus int, message string, err error) error {
+	s.logger.Printf("%s: %v", message, err)
+	return omsv1.NewError(statusOf(err, httpStatus), message+": "+err.Error())
+}
+
+func (s *GRPCServer) CreateOrder(ctx context.Context, req *omsv1.CreateOrderRequest) (*omsv1.OrderReply, error) {
+	order, err := orderv1.OrderFromProto(req.Order)
+	if err != nil {
+		return nil, s.fail(http.StatusBadRequest, "Invalid input", err)
+	}
+	created, replayed, err := s.service(ctx).SubmitOrder(req.IdempotencyKey, order)
+	if err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Order creation failed", err)
+	}
+	if replayed {
+		return &omsv1.OrderReply{Message: "Order already created", Order: created.Proto(), Replayed: true}, nil
+	}
+	return &omsv1.OrderReply{Message: "Order created successfully", Order: created.Proto()}, nil
+}
+
+func (s *GRPCServer) GetOrders(ctx context.Context, req *omsv1.GetOrdersRequest) (*omsv1.OrderList, error) {
+	orders, err := s.omsService.GetOrders(repository.OrderFilter{
+		ParentID: req.ParentId,
+		Symbol:   req.Symbol,
+		Status:   orderv1.OrderStatusFromProto(req.Status),
+		UserID:   req.UserId,
+	})
+	if err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Failed to retrieve orders", err)
+	}
+	return &omsv1.OrderList{Orders: ordersToProto(orders)}, nil
+}
+
+func (s *GRPCServer) GetOrder(ctx context.Context, req *omsv1.OrderRef) (*omsv1.Order, error) {
+	order, err := s.omsService.GetOrder(req.OrderId)
+	if err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Failed to retrieve order", err)
+	}
+	return order.Proto(), nil
+}
+
+func (s *GRPCServer) ExecuteOrder(ctx context.Context, req *omsv1.ExecuteOrderRequest) (*omsv1.Ack, error) {
+	order, err := orderv1.OrderFromProto(req.Order)
+	if err != nil {
+		return nil, s.fail(http.StatusBadRequest, "Invalid input", err)
+	}
+	if err := s.service(ctx).ExecuteOrder(order); err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Order execution failed", err)
+	}
+	return &omsv1.Ack{Message: "Order executed successfully"}, nil
+}
+
+func (s *GRPCServer) CancelOrder(ctx context.Context, req *omsv1.OrderRef) (*omsv1.Ack, error) {
+	if err := s.service(ctx).CancelOrder(req.OrderId); err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Order cancellation failed", err)
+	}
+	return &omsv1.Ack{Message: "Order cancelled successfully"}, nil
+}
+
+func (s *GRPCServer) ModifyOrder(ctx context.Context, req *omsv1.ModifyOrderRequest) (*omsv1.OrderReply, error) {
+	svc := s.service(ctx)
+	if err := childOf(svc, req.ParentId, req.OrderId); err != nil {
+		return nil, s.fail(http.StatusBadRequest, "Order modification failed", err)
+	}
+	changes, err := orderv1.OrderFromProto(req.Changes)
+	if err != nil {
+		return nil, s.fail(http.StatusBadRequest, "Invalid input", err)
+	}
+	order, err := svc.ModifyOrder(req.OrderId, changes)
+	if err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Order modification failed", err)
+	}
+	return &omsv1.OrderReply{Message: "Order modified successfully", Order: order.Proto()}, nil
+}
+
+func (s *GRPCServer) SetStopLoss(ctx context.Context, req *omsv1.SetStopLossRequest) (*omsv1.OrderReply, error) {
+	svc := s.service(ctx)
+	if err := childOf(svc, req.ParentId, req.OrderId); err != nil {
+		return nil, s.fail(http.StatusBadRequest, "Stop loss update failed", err)
+	}
+	order, err := svc.SetStopLoss(req.OrderId, req.Active)
+	if err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Stop loss update failed", err)
+	}
+	return &omsv1.OrderReply{Message: "Stop loss updated successfully", Order: order.Proto()}, nil
+}
+
+func (s *GRPCServer) GetOrderTimeline(ctx context.Context, req *omsv1.OrderRef) (*omsv1.Timeline, error) {
+	entries, err := s.omsService.Timeline(req.OrderId)
+	if err != nil {
+		return nil, s.fail(http.StatusNotFound, "Failed to retrieve order timeline", err)
+	}
+	timeline := &omsv1.Timeline{Entries: make([]*omsv1.TimelineEntry, 0, len(entries))}
+	for _, entry := range entries {
+		converted, err := timelineEntryToProto(entry)
+		if err != nil {
+			return nil, s.fail(http.StatusInternalServerError, "Failed to retrieve order timeline", err)
+		}
+		timeline.Entries = append(timeline.Entries, converted)
+	}
+	return timeline, nil
+}
+
+func (s *GRPCServer) CreateScalperOrder(ctx context.Context, req *omsv1.ScalperOrder) (*omsv1.ScalperOrderReply, error) {
+	order, err := s.service(ctx).CreateScalperOrder(scalperOrderFromProto(req))
+	if err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Order creation failed", err)
+	}
+	return &omsv1.ScalperOrderReply{Message: "Scalper order created successfully", Order: scalperOrderToProto(*order)}, nil
+}
+
+func (s *GRPCServer) ExecuteAllChildTrades(ctx context.Context, req *omsv1.ParentRef) (*omsv1.Ack, error) {
+	if err := s.service(ctx).ExecuteAllChildTrades(req.ParentId); err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Failed to execute all child trades", err)
+	}
+	return &omsv1.Ack{Message: "All child trades executed successfully"}, nil
+}
+
+func (s *GRPCServer) ExecuteSpecificChild(ctx context.Context, req *omsv1.ChildRef) (*omsv1.Ack, error) {
+	if err := s.service(ctx).ExecuteSpecificChild(req.ParentId, req.ChildId); err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Failed to execute specific child trade", err)
+	}
+	return &omsv1.Ack{Message: "Specific child trade executed successfully"}, nil
+}
+
+func (s *GRPCServer) CreateCTC(ctx context.Context, req *omsv1.CTCOrder) (*omsv1.CTCOrderReply, error) {
+	order, err := s.service(ctx).CreateCTC(ctcOrderFromProto(req))
+	if err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "CTC order creation failed", err)
+	}
+	return &omsv1.CTCOrderReply{Message: "CTC order created successfully", Order: ctcOrderToProto(*order)}, nil
+}
+
+func (s *GRPCServer) ExitAllTrades(ctx context.Context, req *omsv1.ExitAllTradesRequest) (*omsv1.Ack, error) {
+	if err := s.service(ctx).ExitAllTrades(""); err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Failed to exit all trades", err)
+	}
+	return &omsv1.Ack{Message: "All trades exited successfully"}, nil
+}
+
+func (s *GRPCServer) ExitChildTrades(ctx context.Context, req *omsv1.ParentRef) (*omsv1.Ack, error) {
+	if err := s.service(ctx).ExitChildTrades(req.ParentId); err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Failed to exit child trades", err)
+	}
+	return &omsv1.Ack{Message: "Child trades exited successfully"}, nil
+}
+
+func (s *GRPCServer) ExitSpecificChild(ctx context.Context, req *omsv1.ChildRef) (*omsv1.Ack, error) {
+	if err := s.service(ctx).ExitSpecificChild(req.ParentId, req.ChildId); err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Failed to exit specific child trade", err)
+	}
+	return &omsv1.Ack{Message: "Specific child trade exited successfully"}, nil
+}
+
+func (s *GRPCServer) CancelAllChildOrders(ctx context.Context, req *omsv1.ParentRef) (*omsv1.Ack, error) {
+	if err := s.service(ctx).CancelAllChildOrders(req.ParentId); err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Failed to cancel all child orders", err)
+	}
+	return &omsv1.Ack{Message: "All child orders cancelled successfully"}, nil
+}
+
+func (s *GRPCServer) CancelSpecificChildOrder(ctx context.Context, req *omsv1.ChildRef) (*omsv1.Ack, error) {
+	if err := s.service(ctx).CancelSpecificChildOrder(req.ParentId, req.ChildId); err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Failed to cancel specific child order", err)
+	}
+	return &omsv1.Ack{Message: "Specific child order cancelled successfully"}, nil
+}
+
+func (s *GRPCServer) GetTrades(ctx context.Context, req *omsv1.ParentRef) (*omsv1.TradeList, error) {
+	trades, err := s.omsService.GetTrades(req.ParentId)
+	if err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Failed to retrieve trades", err)
+	}
+	list := &omsv1.TradeList{Trades: make([]*omsv1.Trade, 0, len(trades))}
+	for _, trade := range trades {
+		list.Trades = append(list.Trades, tradeToProto(trade))
+	}
+	return list, nil
+}
+
+func (s *GRPCServer) DeleteParentOrder(ctx context.Context, req *omsv1.ParentRef) (*omsv1.Ack, error) {
+	if err := s.service(ctx).DeleteParentOrder(req.ParentId); err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Failed to delete parent order", err)
+	}
+	return &omsv1.Ack{Message: "Parent order deleted successfully"}, nil
+}
+
+func (s *GRPCServer) CreateMultiLegOrder(ctx context.Context, req *omsv1.MultiLegOrder) (*omsv1.MultiLegSummary, error) {
+	order, err := multiLegOrderFromProto(req)
+	if err != nil {
+		return nil, s.fail(http.StatusBadRequest, "Invalid input", err)
+	}
+	summary, err := s.service(ctx).CreateMultiLegOrder(order)
+	if err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Multi-leg order failed", err)
+	}
+	return multiLegSummaryToProto(summary), nil
+}
+
+func (s *GRPCServer) GetMultiLegOrder(ctx context.Context, req *omsv1.ParentRef) (*omsv1.MultiLegSummary, error) {
+	summary, err := s.omsService.GetMultiLegOrder(req.ParentId)
+	if err != nil {
+		return nil, s.fail(http.StatusNotFound, "Failed to retrieve multi-leg order", err)
+	}
+	return multiLegSummaryToProto(summary), nil
+}
+
+func (s *GRPCServer) SyncPositions(ctx context.Context, req *omsv1.SyncPositionsRequest) (*omsv1.PositionList, error) {
+	svc := s.service(ctx)
+	if err := svc.SyncPositions(); err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Failed to sync positions", err)
+	}
+	positions, err := svc.GetPositions(req.UserId)
+	if err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Failed to retrieve positions", err)
+	}
+	list := &omsv1.PositionList{Positions: make([]*omsv1.Position, 0, len(positions))}
+	for _, position := range positions {
+		list.Positions = append(list.Positions, positionToProto(position))
+	}
+	return list, nil
+}
+
+func (s *GRPCServer) GetPosition(ctx context.Context, req *omsv1.PositionRef) (*omsv1.Position, error) {
+	position, err := s.omsService.GetPosition(req.PositionId)
+	if err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Failed to retrieve position", err)
+	}
+	return positionToProto(*position), nil
+}
+
+func (s *GRPCServer) ConvertPosition(ctx context.Context, req *omsv1.PositionConversion) (*omsv1.Position, error) {
+	position, err := s.service(ctx).ConvertPosition(models.PositionConversion{
+		PositionID:  req.PositionId,
+		FromProduct: orderv1.ProductFromProto(req.FromProduct),
+		ToProduct:   orderv1.ProductFromProto(req.ToProduct),
+		Quantity:    int(req.Quantity),
+	})
+	if err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Position conversion failed", err)
+	}
+	return positionToProto(*position), nil
+}
+
+func (s *GRPCServer) SquareOffIntraday(ctx context.Context, req *omsv1.SquareOffRequest) (*omsv1.SquareOffReport, error) {
+	report, err := s.service(ctx).SquareOffIntraday()
+	if err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Intraday square-off failed", err)
+	}
+	return squareOffReportToProto(report), nil
+}
+
+func (s *GRPCServer) HandleExpiries(ctx context.Context, req *omsv1.HandleExpiriesRequest) (*omsv1.ExpiryReport, error) {
+	action := service.ExpiryAction(req.Action)
+	if action == "" {
+		action = service.ExpiryClose
+	}
+	report, err := s.service(ctx).HandleExpiries(action)
+	if err != nil {
+		return nil, s.fail(http.StatusInternalServerError, "Expiry run failed", err)
+	}
+	return expiryReportToProto(report), nil
+}
+
+func (s *GRPCServer) GetInstrument(ctx context.Context, req *omsv1.InstrumentRequest) (*omsv1.Instrument, error) {
+	master := s.omsService.Instruments()
+	if master == nil {
+		return nil, omsv1.NewError(http.StatusNotFound, "No instrument master loaded")
+	}
+	instrument, err := master.Lookup(req.Symbol)
+	if err != nil {
+		return nil, omsv1.NewError(http.StatusNotFound, err.Error())
+	}
+	return instrumentToProto(&instrument), nil
+}
+
+func (s *GRPCServer) GetExpiries(ctx context.Context, req *omsv1.ExpiriesRequest) (*omsv1.ExpiryList, error) {
+	master := s.omsService.Instruments()
+	if master == nil {
+		return nil, omsv1.NewError(http.StatusNotFound, "No instrument master loaded")
+	}
+	return &omsv1.ExpiryList{Expiries: master.Expiries(req.Underlying)}, nil
+}
+
+func (s *GRPCServer) GetOptionChain(ctx context.Context, req *omsv1.OptionChainRequest) (*omsv1.OptionChain, error) {
+	master := s.omsService.Instruments()
+	if master == nil {
+		return nil, omsv1.NewError(http.StatusNotFound, "No instrument master loaded")
+	}
+	chain, err := master.OptionChain(req.Underlying, req.Expiry)
+	if err != nil {
+		s.logger.Printf("Option chain lookup failed: %v", err)
+		return nil, omsv1.NewError(http.StatusNotFound, err.Error())
+	}
+	return optionChainToProto(chain), nil
+}
+
+func (s *GRPCServer) GetMarketStatus(ctx context.Context, req *omsv1.MarketStatusRequest) (*omsv1.MarketStatus, error) {
+	segment := orderv1.SegmentFromProto(req.Segment)
+	if segment == "" {
+		segment = models.SegmentEquity
+	}
+	return marketStatusToProto(s.omsService.Calendar().Status(segment, time.Now())), nil
+}
+
+func (s *GRPCServer) GetHolidays(ctx context.Context, req *omsv1.HolidaysRequest) (*omsv1.HolidayList, error) {
+	holidays := s.omsService.Calendar().Holidays()
+	list := &omsv1.HolidayList{Holidays: make([]*omsv1.Holiday, 0, len(holidays))}
+	for _, holiday := range holidays {
+		list.Holidays = append(list.Holidays, holidayToProto(holiday))
+	}
+	return list, nil
+}
+
+// RecordTick answers not found unless ticks are accepted, like the HTTP API
+// which leaves the route out
+func (s *GRPCServer) RecordTick(ctx context.Context, req *omsv1.Tick) (*omsv1.Ack, error) {
+	if !s.opts.AcceptTicks {
+		return nil, omsv1.NewError(http.StatusNotFound, "Tick ingestion is disabled")
+	}
+	if err := s.service(ctx).RecordTick(tickFromProto(req)); err != nil {
+		return nil, s.fail(http.StatusBadRequest, "Failed to record tick", err)
+	}
+	return &omsv1.Ack{Message: "Tick recorded"}, nil
+}
+
+// StreamEvents follows the OMS event stream like the server-sent events
+// endpoint, resuming after req.Since and sending a gap event in place of
+// events that are no longer retained
+func (s *GRPCServer) StreamEvents(req *omsv1.StreamEventsRequest, stream omsv1.OrderManagement_StreamEventsServer) error {
+	bus, ok := s.omsService.Events().(events.Stream)
+	if !ok {
+		return omsv1.NewError(http.StatusNotImplemented, "Event stream not available")
+	}
+
+	ch := bus.Subscribe(streamBuffer)
+	defer bus.Unsubscribe(ch)
+
+	last, following := req.Since, req.Since > 0
+	catchUp := func() error {
+		backlog, complete := bus.Since(last)
+		if !complete {
+			if err := stream.Send(&omsv1.Event{Sequence: last, Type: "gap", Gap: true}); err != nil {
+				return err
+			}
+			last = 0
+		}
+		for _, event := range backlog {
+			if err := s.sendEvent(stream, event); err != nil {
+				return err
+			}
+			last = event.Sequence
+		}
+		return nil
+	}
+	if following {
+		if err := catchUp(); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.opts.StopStreams:
+			return nil
+		case event, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			var err error
+			switch {
+			case following && event.Sequence <= last:
+				continue
+			case following && event.Sequence > last+1:
+				err = catchUp()
+			default:
+				err = s.sendEvent(stream, event)
+				last, following = event.Sequence, true
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *GRPCServer) sendEvent(stream omsv1.OrderManagement_StreamEventsServer, event events.Event) error {
+	converted, err := eventToProto(event)
+	if err != nil {
+		s.logger.Printf("Encoding event %d: %v", event.Sequence, err)
+		return nil
+	}
+	return stream.Send(converted)
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	omsv1 "github.com/Mukilan-T/laabhum-api-go/oms/v1"
+	"github.com/Mukilan-T/laabhum-oms-go/calendar"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+	"github.com/Mukilan-T/laabhum-oms-go/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startGRPC serves the gRPC API over an in-memory listener
+func startGRPC(t *testing.T, opts Options) omsv1.OrderManagementClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	omsService := service.NewOMSService(repository.NewInMemoryOrderRepository())
+	// A Monday mid-session, so orders are placed rather than rejected or queued
+	omsService.SetClock(func() time.Time { return time.Date(2026, 10, 19, 11, 0, 0, 0, calendar.IST) })
+	grpcAPI := NewGRPCServer(log.New(io.Discard, "", 0), omsService, opts)
+	server := grpc.NewServer(grpcAPI.ServerOptions()...)
+	grpcAPI.Register(server)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return omsv1.NewOrderManagementClient(conn)
+}
+
+func TestGRPCRequiresServiceToken(t *testing.T) {
+	client := startGRPC(t, Options{ServiceToken: "s3cret"})
+
+	tests := []struct {
+		name          string
+		authorization string
+		code          codes.Code
+	}{
+		{"no token", "", codes.Unauthenticated},
+		{"wrong token", "Bearer guess", codes.Unauthenticated},
+		{"service token", "Bearer s3cret", codes.OK},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.authorization != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", tc.authorization)
+			}
+			_, err := client.GetOrders(ctx, &omsv1.GetOrdersRequest{})
+			if code := status.Code(err); code != tc.code {
+				t.Errorf("GetOrders: %v, want %s", err, tc.code)
+			}
+
+			stream, err := client.StreamEvents(ctx, &omsv1.StreamEventsRequest{})
+			if err == nil && tc.code != codes.OK {
+				_, err = stream.Recv()
+			}
+			if tc.code != codes.OK && status.Code(err) != tc.code {
+				t.Errorf("StreamEvents: %v, want %s", err, tc.code)
+			}
+		})
+	}
+}
+
+func TestGRPCRefusesTicksUnlessEnabled(t *testing.T) {
+	tick := &omsv1.Tick{Symbol: "NSE:INFY", Price: 100}
+
+	_, err := startGRPC(t, Options{}).RecordTick(context.Background(), tick)
+	if got := omsv1.ErrorFrom(err).GetHttpStatus(); got != http.StatusNotFound {
+		t.Errorf("RecordTick while disabled: %v, want 404", err)
+	}
+	if _, err := startGRPC(t, Options{AcceptTicks: true}).RecordTick(context.Background(), tick); err != nil {
+		t.Errorf("RecordTick while enabled: %v", err)
+	}
+}
+
+func TestGRPCRefusesUnknownStrategies(t *testing.T) {
+	client := startGRPC(t, Options{})
+	order := &omsv1.Order{Symbol: "NSE:INFY", Quantity: 1, Price: 100, Side: omsv1.Side_SIDE_BUY, Type: omsv1.OrderType_ORDER_TYPE_LIMIT}
+
+	order.Strategy = "SWING"
+	_, err := client.CreateOrder(context.Background(), &omsv1.CreateOrderRequest{Order: order})
+	if got := omsv1.ErrorFrom(err).GetHttpStatus(); got != http.StatusBadRequest {
+		t.Errorf("unknown strategy: %v, want 400 as over JSON", err)
+	}
+
+	_, err = client.CreateMultiLegOrder(context.Background(), &omsv1.MultiLegOrder{Name: "spread", Quantity: 1, Strategy: "SWING"})
+	if got := omsv1.ErrorFrom(err).GetHttpStatus(); got != http.StatusBadRequest {
+		t.Errorf("multi-leg order with an unknown strategy: %v, want 400", err)
+	}
+}
+
+func TestGRPCNamesUsersOnlyWithServiceToken(t *testing.T) {
+	order := &omsv1.Order{Symbol: "NSE:INFY", Quantity: 1, Price: 100, Side: omsv1.Side_SIDE_BUY,
+		Type: omsv1.OrderType_ORDER_TYPE_LIMIT, UserId: "someone-else"}
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		"authorization", "Bearer s3cret", "x-user-id", "trader-1", "x-account-id", "acct-1")
+
+	tests := []struct {
+		name      string
+		opts      Options
+		userID    string
+		accountID string
+	}{
+		{"no token required", Options{}, "", ""},
+		{"service token", Options{ServiceToken: "s3cret"}, "trader-1", "acct-1"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			created, err := startGRPC(t, tc.opts).CreateOrder(ctx, &omsv1.CreateOrderRequest{Order: order})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := created.Order; got.UserId != tc.userID || got.AccountId != tc.accountID {
+				t.Errorf("order placed for user %q and account %q, want %q and %q", got.UserId, got.AccountId, tc.userID, tc.accountID)
+			}
+		})
+	}
+}
@@ -1,9 +1,17 @@
 package api
 
 import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"io"
 	"log"
 	"net/http"
+	"strings"
+	"time"
 
+	orderv1 "github.com/Mukilan-T/laabhum-api-go/order/v1"
+	"github.com/Mukilan-T/laabhum-oms-go/audit"
 	"github.com/Mukilan-T/laabhum-oms-go/models"
 	"github.com/Mukilan-T/laabhum-oms-go/repository"
 	"github.com/Mukilan-T/laabhum-oms-go/service"
@@ -13,6 +21,7 @@ import (
 type Handlers struct {
     logger     *log.Logger
     omsService *service.OMSService
+    opts       Options
 }
 
 // NewHandlers initializes the handlers with OMSService
@@ -22,9 +31,96 @@ func NewHandlers(logger *log.Logger, omsService *service.OMSService) *Handlers {
         omsService: omsService,
     }
 }
-func SetupRoutes(logger *log.Logger, omsService *service.OMSService) *gin.Engine {
-	router := gin.Default()
+// Request headers naming who made a change and why, recorded in the audit trail
+const (
+    RequestIDHeader = "X-Request-ID"
+    UserIDHeader    = "X-User-ID"
+    AccountIDHeader = "X-Account-ID"
+    ReasonHeader    = "X-Reason"
+)
+
+// IdempotencyKeyHeader names an order submission so a retry of it returns
+// the original order instead of placing another
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// bind decodes the JSON request body into v. Orders go through the contract
+// decoder, so the legacy keys it accepts are not refused as unknown.
+func (h *Handlers) bind(c *gin.Context, v interface{}) error {
+    body, err := io.ReadAll(c.Request.Body)
+    if err != nil {
+        return err
+    }
+    if order, ok := v.(*models.Order); ok {
+        var opts []orderv1.DecodeOption
+        if h.opts.DisallowUnknownFields {
+            opts = append(opts, orderv1.DisallowUnknownFields())
+        }
+        decoded, err := orderv1.Decode(body, opts...)
+        if err != nil {
+            return err
+        }
+        *order = decoded
+        return nil
+    }
+    decoder := json.NewDecoder(bytes.NewReader(body))
+    if h.opts.DisallowUnknownFields {
+        decoder.DisallowUnknownFields()
+    }
+    return decoder.Decode(v)
+}
+
+// service returns the OMS service attributed to the caller of this request,
+// under the ID the RequestID middleware gave it. Only callers presenting the
+// service token name the user and account they act for.
+func (h *Handlers) service(c *gin.Context) *service.OMSService {
+    source := audit.Source{
+        Actor:     audit.User(""),
+        Reason:    c.GetHeader(ReasonHeader),
+        RequestID: requestID(c),
+    }
+    if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok && h.opts.isServiceToken(token) {
+        source.Actor = audit.User(c.GetHeader(UserIDHeader))
+        source.Account = c.GetHeader(AccountIDHeader)
+    }
+    return h.omsService.As(source)
+}
+
+// Options turns on the parts of the OMS APIs that are off by default
+type Options struct {
+	// AcceptTicks lets callers post market prices. Ticks mark positions to
+	// market and can trigger take-profit closes, so only enable it where the
+	// API is reachable by the price feed alone.
+	AcceptTicks bool
+	// ServiceToken is the bearer token gRPC callers must present; with none
+	// every caller is accepted, which is only safe on a loopback address.
+	// Over HTTP and NATS it is optional, but on every API only callers
+	// presenting it may name the user and account they act for.
+	ServiceToken string
+	// DisallowUnknownFields refuses request bodies with fields the OMS does
+	// not read, so contract verification catches a consumer sending them
+	DisallowUnknownFields bool
+	// StopStreams ends the event streams when closed, so a graceful shutdown
+	// drains other requests without waiting on clients that never hang up
+	StopStreams <-chan struct{}
+}
+
+// isServiceToken reports whether token is the configured service token
+func (o Options) isServiceToken(token string) bool {
+	return o.ServiceToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(o.ServiceToken)) == 1
+}
+
+// SetupRoutes builds the OMS HTTP API. Every request is given a request ID,
+// logged to accessLog unless it is nil, and recovered from if its handler
+// panics; handler failures are logged to logger.
+func SetupRoutes(logger *log.Logger, omsService *service.OMSService, accessLog *log.Logger, opts Options) *gin.Engine {
+	router := gin.New()
+	router.Use(RequestID())
+	if accessLog != nil {
+		router.Use(Logger(accessLog))
+	}
+	router.Use(Recovery(logger))
 	handlers := NewHandlers(logger, omsService)
+	handlers.opts = opts
 
 	// Scalper Order Routes
 	router.POST("/oms/scalper/order", handlers.CreateScalperOrder)
@@ -33,6 +129,12 @@ func SetupRoutes(logger *log.Logger, omsService *service.OMSService) *gin.Engine
 	router.POST("/oms/scalper/order/:parentID/ctc", handlers.CreateCTC)
 	router.PATCH("/oms/scalper/order/:orderType/:parentID/modify", handlers.ModifyOrder)
 	router.PATCH("/oms/scalper/order/:orderType/:parentID/:childID/modify", handlers.ModifyChildOrder)
+	router.PATCH("/oms/scalper/order/sl/:parentID/:childID/active", handlers.ActivateStopLoss)
+	router.PATCH("/oms/scalper/order/sl/:parentID/:childID/cancel", handlers.CancelStopLoss)
+
+	// Multi-leg strategy orders
+	router.POST("/oms/strategy/order", handlers.CreateMultiLegOrder)
+	router.GET("/oms/strategy/order/:parentID", handlers.GetMultiLegOrder)
 
 	// Exit scalper trades
 	router.POST("/oms/scalper/exit/trade", handlers.ExitAllTrades)
@@ -52,12 +154,35 @@ func SetupRoutes(logger *log.Logger, omsService *service.OMSService) *gin.Engine
 
 	// Position Routes
 	router.GET("/oms/positions", handlers.SyncPositions)
+	router.GET("/oms/positions/:id", handlers.GetPosition)
+	router.PUT("/oms/position/convert", handlers.ConvertPosition)
+	router.POST("/oms/positions/squareoff", handlers.SquareOffIntraday)
+
+	// Instrument master
+	router.GET("/oms/instruments/:symbol", handlers.GetInstrument)
+
+	// Futures and options
+	router.GET("/oms/options/expiries", handlers.GetExpiries)
+	router.GET("/oms/options/chain", handlers.GetOptionChain)
+	router.POST("/oms/positions/expiry", handlers.HandleExpiries)
+
+	// Trading calendar
+	router.GET("/oms/calendar/status", handlers.GetMarketStatus)
+	router.GET("/oms/calendar/holidays", handlers.GetHolidays)
 
 	// General Order Routes
 	router.GET("/oms/orders", handlers.GetOrders)
 	router.PUT("/oms/order", handlers.CreateOrder)
 	router.POST("/oms/order/execute", handlers.ExecuteOrder)
 	router.DELETE("/oms/order/cancel", handlers.CancelOrder)
+	router.GET("/oms/orders/:id", handlers.GetOrder)
+	router.GET("/oms/orders/:id/timeline", handlers.GetOrderTimeline)
+
+	// Market data and the event stream
+	if opts.AcceptTicks {
+		router.POST("/oms/market/ticks", handlers.RecordTick)
+	}
+	router.GET("/oms/events/stream", handlers.StreamEvents)
 
 	return router
 }
@@ -65,31 +190,62 @@ func SetupRoutes(logger *log.Logger, omsService *service.OMSService) *gin.Engine
 // CreateScalperOrder handles creating a scalper order
 func (h *Handlers) CreateScalperOrder(c *gin.Context) {
     var order models.ScalperOrder
-    if err := c.ShouldBindJSON(&order); err != nil {
+    if err := h.bind(c, &order); err != nil {
         h.logger.Printf("Invalid input for scalper order: %v", err)
         c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
         return
     }
     h.logger.Println("CreateScalperOrder handlers.go in oms handler invoked") // Add this line for debugging
 
-    createdOrder, err := h.omsService.CreateScalperOrder(order)
+    createdOrder, err := h.service(c).CreateScalperOrder(order)
     if err != nil {
         h.logger.Printf("Order creation failed: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Order creation failed: " + err.Error()})
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "Order creation failed: " + err.Error()})
         return
     }
 
     c.JSON(http.StatusCreated, gin.H{"message": "Scalper order created successfully", "order": createdOrder})
 }
 
+// CreateMultiLegOrder places a multi-leg strategy order atomically
+func (h *Handlers) CreateMultiLegOrder(c *gin.Context) {
+    var req models.MultiLegOrder
+    if err := h.bind(c, &req); err != nil {
+        h.logger.Printf("Invalid input for multi-leg order: %v", err)
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+        return
+    }
+
+    summary, err := h.service(c).CreateMultiLegOrder(req)
+    if err != nil {
+        h.logger.Printf("Multi-leg order failed: %v", err)
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "Multi-leg order failed: " + err.Error()})
+        return
+    }
+
+    c.JSON(http.StatusCreated, gin.H{"message": "Multi-leg order placed successfully", "order": summary})
+}
+
+// GetMultiLegOrder reports a multi-leg order's legs, net premium and combined position
+func (h *Handlers) GetMultiLegOrder(c *gin.Context) {
+    summary, err := h.omsService.GetMultiLegOrder(c.Param("parentID"))
+    if err != nil {
+        h.logger.Printf("Failed to retrieve multi-leg order: %v", err)
+        c.JSON(http.StatusNotFound, gin.H{"error": "Failed to retrieve multi-leg order: " + err.Error()})
+        return
+    }
+
+    c.JSON(http.StatusOK, summary)
+}
+
 // ExecuteAllChildTrades executes all child trades for a parent order
 func (h *Handlers) ExecuteAllChildTrades(c *gin.Context) {
     parentID := c.Param("parentID")
     
-    err := h.omsService.ExecuteAllChildTrades(parentID)
+    err := h.service(c).ExecuteAllChildTrades(parentID)
     if err != nil {
         h.logger.Printf("Failed to execute all child trades: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to execute all child trades: " + err.Error()})
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "Failed to execute all child trades: " + err.Error()})
         return
     }
 
@@ -102,10 +258,10 @@ func (h *Handlers) ExecuteSpecificChild(c *gin.Context) {
     parentID := c.Param("parentID")
     childID := c.Param("childID")
 
-    err := h.omsService.ExecuteSpecificChild(parentID, childID)
+    err := h.service(c).ExecuteSpecificChild(parentID, childID)
     if err != nil {
         h.logger.Printf("Failed to execute specific child trade: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to execute specific child trade: " + err.Error()})
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "Failed to execute specific child trade: " + err.Error()})
         return
     }
 
@@ -115,16 +271,16 @@ func (h *Handlers) ExecuteSpecificChild(c *gin.Context) {
 // CreateCTC creates a CTC order
 func (h *Handlers) CreateCTC(c *gin.Context) {
     var ctcOrder models.CTCOrder
-    if err := c.ShouldBindJSON(&ctcOrder); err != nil {
+    if err := h.bind(c, &ctcOrder); err != nil {
         h.logger.Printf("Invalid input for CTC order: %v", err)
         c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
         return
     }
 
-    createdOrder, err := h.omsService.CreateCTC(ctcOrder)
+    createdOrder, err := h.service(c).CreateCTC(ctcOrder)
     if err != nil {
         h.logger.Printf("CTC order creation failed: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "CTC order creation failed: " + err.Error()})
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "CTC order creation failed: " + err.Error()})
         return
     }
 
@@ -133,10 +289,10 @@ func (h *Handlers) CreateCTC(c *gin.Context) {
 
 // ExitAllTrades exits all trades
 func (h *Handlers) ExitAllTrades(c *gin.Context) {
-    err := h.omsService.ExitAllTrades("someStringArgument")
+    err := h.service(c).ExitAllTrades("someStringArgument")
     if err != nil {
         h.logger.Printf("Failed to exit all trades: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to exit all trades: " + err.Error()})
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "Failed to exit all trades: " + err.Error()})
         return
     }
 
@@ -147,10 +303,10 @@ func (h *Handlers) ExitAllTrades(c *gin.Context) {
 func (h *Handlers) ExitChildTrades(c *gin.Context) {
     parentID := c.Param("parentID")
 
-    err := h.omsService.ExitChildTrades(parentID)
+    err := h.service(c).ExitChildTrades(parentID)
     if err != nil {
         h.logger.Printf("Failed to exit child trades: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to exit child trades: " + err.Error()})
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "Failed to exit child trades: " + err.Error()})
         return
     }
 
@@ -162,10 +318,10 @@ func (h *Handlers) ExitSpecificChild(c *gin.Context) {
     parentID := c.Param("parentID")
     childID := c.Param("childID")
 
-    err := h.omsService.ExitSpecificChild(parentID, childID)
+    err := h.service(c).ExitSpecificChild(parentID, childID)
     if err != nil {
         h.logger.Printf("Failed to exit specific child trade: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to exit specific child trade: " + err.Error()})
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "Failed to exit specific child trade: " + err.Error()})
         return
     }
 
@@ -176,10 +332,10 @@ func (h *Handlers) ExitSpecificChild(c *gin.Context) {
 func (h *Handlers) CancelAllChildOrders(c *gin.Context) {
     parentID := c.Param("parentID")
 
-    err := h.omsService.CancelAllChildOrders(parentID)
+    err := h.service(c).CancelAllChildOrders(parentID)
     if err != nil {
         h.logger.Printf("Failed to cancel all child orders: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel all child orders: " + err.Error()})
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "Failed to cancel all child orders: " + err.Error()})
         return
     }
 
@@ -191,10 +347,10 @@ func (h *Handlers) CancelSpecificChildOrder(c *gin.Context) {
     parentID := c.Param("parentID")
     childID := c.Param("childID")
 
-    err := h.omsService.CancelSpecificChildOrder(parentID, childID)
+    err := h.service(c).CancelSpecificChildOrder(parentID, childID)
     if err != nil {
         h.logger.Printf("Failed to cancel specific child order: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel specific child order: " + err.Error()})
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "Failed to cancel specific child order: " + err.Error()})
         return
     }
 
@@ -205,43 +361,88 @@ func (h *Handlers) CancelSpecificChildOrder(c *gin.Context) {
 func (h *Handlers) DeleteParentOrder(c *gin.Context) {
     parentID := c.Param("parentID")
 
-    err := h.omsService.DeleteParentOrder(parentID)
+    err := h.service(c).DeleteParentOrder(parentID)
     if err != nil {
         h.logger.Printf("Failed to delete parent order: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete parent order: " + err.Error()})
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "Failed to delete parent order: " + err.Error()})
         return
     }
 
     c.JSON(http.StatusOK, gin.H{"message": "Parent order deleted successfully"})
 }
 
-// ActivateStopLoss activates stop loss for a specific child order
+// ActivateStopLoss arms the stop loss of a child order
+func (h *Handlers) ActivateStopLoss(c *gin.Context) {
+    c.JSON(h.setStopLoss(h.service(c), c.Param("parentID"), c.Param("childID"), true))
+}
 
-// SyncPositions syncs positions
+// CancelStopLoss disarms the stop loss of a child order
+func (h *Handlers) CancelStopLoss(c *gin.Context) {
+    c.JSON(h.setStopLoss(h.service(c), c.Param("parentID"), c.Param("childID"), false))
+}
+
+// SyncPositions syncs positions, returning only those of the user_id query
+// parameter when it is set
 func (h *Handlers) SyncPositions(c *gin.Context) {
-    err := h.omsService.SyncPositions()
+    c.JSON(h.positions(h.service(c), c.Query("user_id")))
+}
+
+// GetPosition returns one position as stored, without repricing it
+func (h *Handlers) GetPosition(c *gin.Context) {
+    position, err := h.omsService.GetPosition(c.Param("id"))
     if err != nil {
-        h.logger.Printf("Failed to sync positions: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to sync positions: " + err.Error()})
+        h.logger.Printf("Failed to retrieve position: %v", err)
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "Failed to retrieve position: " + err.Error()})
         return
     }
 
-    c.JSON(http.StatusOK, gin.H{"message": "Positions synced successfully"})
+    c.JSON(http.StatusOK, position)
+}
+
+// ConvertPosition moves an open position to another product type
+func (h *Handlers) ConvertPosition(c *gin.Context) {
+    var req models.PositionConversion
+    if err := h.bind(c, &req); err != nil {
+        h.logger.Printf("Invalid input for position conversion: %v", err)
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+        return
+    }
+
+    position, err := h.service(c).ConvertPosition(req)
+    if err != nil {
+        h.logger.Printf("Position conversion failed: %v", err)
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "Position conversion failed: " + err.Error()})
+        return
+    }
+
+    c.JSON(http.StatusOK, gin.H{"message": "Position converted successfully", "position": position})
+}
+
+// SquareOffIntraday cancels open intraday orders and closes intraday positions immediately
+func (h *Handlers) SquareOffIntraday(c *gin.Context) {
+    report, err := h.service(c).SquareOffIntraday()
+    if err != nil {
+        h.logger.Printf("Intraday square-off failed: %v", err)
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "Intraday square-off failed: " + err.Error()})
+        return
+    }
+
+    c.JSON(http.StatusOK, report)
 }
 
 // ExecuteOrder executes an order
 func (h *Handlers) ExecuteOrder(c *gin.Context) {
     var order models.Order
-    if err := c.ShouldBindJSON(&order); err != nil {
+    if err := h.bind(c, &order); err != nil {
         h.logger.Printf("Invalid input for order execution: %v", err)
         c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
         return
     }
 
-    err := h.omsService.ExecuteOrder(order)
+    err := h.service(c).ExecuteOrder(order)
     if err != nil {
         h.logger.Printf("Order execution failed: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Order execution failed: " + err.Error()})
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "Order execution failed: " + err.Error()})
         return
     }
 
@@ -251,20 +452,13 @@ func (h *Handlers) ExecuteOrder(c *gin.Context) {
 // CancelOrder cancels an order
 func (h *Handlers) CancelOrder(c *gin.Context) {
     var order models.Order
-    if err := c.ShouldBindJSON(&order); err != nil {
+    if err := h.bind(c, &order); err != nil {
         h.logger.Printf("Invalid input for order cancellation: %v", err)
         c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
         return
     }
 
-    err := h.omsService.CancelOrder(order.ID) // Assuming 'ID' is the string field required
-    if err != nil {
-        h.logger.Printf("Order cancellation failed: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Order cancellation failed: " + err.Error()})
-        return
-    }
-
-    c.JSON(http.StatusOK, gin.H{"message": "Order cancelled successfully"})
+    c.JSON(h.cancelOrder(h.service(c), order.ID))
 }
 
 // GetTrades retrieves trades for a given parent ID
@@ -274,7 +468,7 @@ func (h *Handlers) GetTrades(c *gin.Context) {
     trades, err := h.omsService.GetTrades(parentID)
     if err != nil {
         h.logger.Printf("Failed to retrieve trades: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve trades: " + err.Error()})
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "Failed to retrieve trades: " + err.Error()})
         return
     }
 
@@ -284,42 +478,161 @@ func (h *Handlers) GetTrades(c *gin.Context) {
 // CreateOrder handles creating a new order
 func (h *Handlers) CreateOrder(c *gin.Context) {
     var order models.Order
-    if err := c.ShouldBindJSON(&order); err != nil {
+    if err := h.bind(c, &order); err != nil {
         h.logger.Printf("Invalid input for order: %v", err)
         c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
         return
     }
 
-    createdOrder, err := h.omsService.CreateOrder(order)
-    if err != nil {
-        h.logger.Printf("Order creation failed: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Order creation failed: " + err.Error()})
-        return
-    }
-
-    c.JSON(http.StatusCreated, gin.H{"message": "Order created successfully", "order": createdOrder})
+    c.JSON(h.createOrder(h.service(c), c.GetHeader(IdempotencyKeyHeader), order))
 }
 
-// ModifyOrder modifies an existing order
+// ModifyOrder changes the quantity, price or stop price of an unfilled order
 func (h *Handlers) ModifyOrder(c *gin.Context) {
-    // Implement logic to modify an order here
-    c.JSON(http.StatusNotImplemented, gin.H{"error": "Not implemented"})
+    var changes models.Order
+    if err := h.bind(c, &changes); err != nil {
+        h.logger.Printf("Invalid input for order modification: %v", err)
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+        return
+    }
+
+    c.JSON(h.modifyOrder(h.service(c), "", c.Param("parentID"), changes))
 }
 
-// ModifyChildOrder modifies an existing child order
+// ModifyChildOrder changes an unfilled child order of a parent order
 func (h *Handlers) ModifyChildOrder(c *gin.Context) {
-    // Implement logic to modify a child order here
-    c.JSON(http.StatusNotImplemented, gin.H{"error": "Not implemented"})
+    var changes models.Order
+    if err := h.bind(c, &changes); err != nil {
+        h.logger.Printf("Invalid input for child order modification: %v", err)
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+        return
+    }
+
+    c.JSON(h.modifyOrder(h.service(c), c.Param("parentID"), c.Param("childID"), changes))
 }
 
 // GetOrders retrieves all orders
 func (h *Handlers) GetOrders(c *gin.Context) {
-    orders, err := h.omsService.GetOrders(repository.OrderFilter{})
+    orders, err := h.omsService.GetOrders(repository.OrderFilter{UserID: c.Query("user_id")})
     if err != nil {
         h.logger.Printf("Failed to retrieve orders: %v", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve orders: " + err.Error()})
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "Failed to retrieve orders: " + err.Error()})
         return
     }
 
     c.JSON(http.StatusOK, orders)
-}
\ No newline at end of file
+}
+
+// GetOrder returns one order, with the user and account it was placed for
+func (h *Handlers) GetOrder(c *gin.Context) {
+    order, err := h.omsService.GetOrder(c.Param("id"))
+    if err != nil {
+        h.logger.Printf("Failed to retrieve order: %v", err)
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "Failed to retrieve order: " + err.Error()})
+        return
+    }
+
+    c.JSON(http.StatusOK, order)
+}
+
+// GetOrderTimeline returns every recorded change to an order and its positions
+func (h *Handlers) GetOrderTimeline(c *gin.Context) {
+    entries, err := h.omsService.Timeline(c.Param("id"))
+    if err != nil {
+        h.logger.Printf("Failed to retrieve order timeline: %v", err)
+        c.JSON(http.StatusNotFound, gin.H{"error": "Failed to retrieve order timeline: " + err.Error()})
+        return
+    }
+
+    c.JSON(http.StatusOK, entries)
+}
+
+// RecordTick stores a market price update and marks open positions to it
+func (h *Handlers) RecordTick(c *gin.Context) {
+    var condition models.MarketCondition
+    if err := h.bind(c, &condition); err != nil {
+        h.logger.Printf("Invalid input for tick: %v", err)
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+        return
+    }
+
+    if err := h.service(c).RecordTick(condition); err != nil {
+        h.logger.Printf("Failed to record tick: %v", err)
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to record tick: " + err.Error()})
+        return
+    }
+
+    c.JSON(http.StatusOK, gin.H{"message": "Tick recorded"})
+}
+
+// GetMarketStatus reports the current session phase of a segment (defaults to EQ)
+func (h *Handlers) GetMarketStatus(c *gin.Context) {
+    segment := models.Segment(c.DefaultQuery("segment", string(models.SegmentEquity)))
+
+    c.JSON(http.StatusOK, h.omsService.Calendar().Status(segment, time.Now()))
+}
+
+// GetHolidays lists the exchange holidays the OMS knows about
+func (h *Handlers) GetHolidays(c *gin.Context) {
+    c.JSON(http.StatusOK, h.omsService.Calendar().Holidays())
+}
+
+// GetInstrument returns the trading rules for a symbol
+func (h *Handlers) GetInstrument(c *gin.Context) {
+    master := h.omsService.Instruments()
+    if master == nil {
+        c.JSON(http.StatusNotFound, gin.H{"error": "No instrument master loaded"})
+        return
+    }
+
+    instrument, err := master.Lookup(c.Param("symbol"))
+    if err != nil {
+        c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+        return
+    }
+
+    c.JSON(http.StatusOK, instrument)
+}
+
+// GetExpiries lists the contract expiries available for an underlying
+func (h *Handlers) GetExpiries(c *gin.Context) {
+    master := h.omsService.Instruments()
+    if master == nil {
+        c.JSON(http.StatusNotFound, gin.H{"error": "No instrument master loaded"})
+        return
+    }
+
+    c.JSON(http.StatusOK, master.Expiries(c.Query("underlying")))
+}
+
+// GetOptionChain returns the calls and puts on an underlying for one expiry
+func (h *Handlers) GetOptionChain(c *gin.Context) {
+    master := h.omsService.Instruments()
+    if master == nil {
+        c.JSON(http.StatusNotFound, gin.H{"error": "No instrument master loaded"})
+        return
+    }
+
+    chain, err := master.OptionChain(c.Query("underlying"), c.Query("expiry"))
+    if err != nil {
+        h.logger.Printf("Option chain lookup failed: %v", err)
+        c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+        return
+    }
+
+    c.JSON(http.StatusOK, chain)
+}
+
+// HandleExpiries closes or flags positions in expiring contracts immediately
+func (h *Handlers) HandleExpiries(c *gin.Context) {
+    action := service.ExpiryAction(c.DefaultQuery("action", string(service.ExpiryClose)))
+
+    report, err := h.service(c).HandleExpiries(action)
+    if err != nil {
+        h.logger.Printf("Expiry run failed: %v", err)
+        c.JSON(statusOf(err, http.StatusInternalServerError), gin.H{"error": "Expiry run failed: " + err.Error()})
+        return
+    }
+
+    c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,276 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/calendar"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+	"github.com/Mukilan-T/laabhum-oms-go/service"
+	"github.com/gin-gonic/gin"
+)
+
+func TestHTTPNamesUsersOnlyWithServiceToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	omsService := service.NewOMSService(repository.NewInMemoryOrderRepository())
+	// A Monday mid-session, so orders are placed rather than rejected or queued
+	omsService.SetClock(func() time.Time { return time.Date(2026, 10, 19, 11, 0, 0, 0, calendar.IST) })
+	router := SetupRoutes(log.New(io.Discard, "", 0), omsService, nil, Options{ServiceToken: serviceToken})
+
+	tests := []struct {
+		name          string
+		authorization string
+		userID        string
+		accountID     string
+	}{
+		{"no token", "", "", ""},
+		{"wrong token", "Bearer guess", "", ""},
+		{"service token", "Bearer " + serviceToken, "trader-1", "acct-1"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// The owner in the body is never taken
+			body := `{"symbol":"NSE:INFY","quantity":1,"price":100,"side":"buy","type":"LIMIT","user_id":"someone-else"}`
+			req := httptest.NewRequest(http.MethodPut, "/oms/order", strings.NewReader(body))
+			req.Header.Set(UserIDHeader, "trader-1")
+			req.Header.Set(AccountIDHeader, "acct-1")
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			var reply struct {
+				Order models.Order `json:"order"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &reply); err != nil || recorder.Code != http.StatusCreated {
+				t.Fatalf("create returned %d: %s", recorder.Code, recorder.Body)
+			}
+			stored, err := omsService.GetOrder(reply.Order.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.UserID != tc.userID || stored.AccountID != tc.accountID {
+				t.Errorf("order placed for user %q and account %q, want %q and %q", stored.UserID, stored.AccountID, tc.userID, tc.accountID)
+			}
+		})
+	}
+}
+
+func TestHTTPReplaysOrdersAfterRestart(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dsn := filepath.Join(t.TempDir(), "oms.db")
+	var omsService *service.OMSService
+	// start opens the OMS on the same database, as after a restart
+	start := func() http.Handler {
+		repo, err := repository.NewSQLiteOrderRepository(dsn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { repo.Close() })
+		omsService = service.NewOMSService(repo)
+		omsService.SetClock(func() time.Time { return time.Date(2026, 10, 19, 11, 0, 0, 0, calendar.IST) })
+		return SetupRoutes(log.New(io.Discard, "", 0), omsService, nil, Options{ServiceToken: serviceToken})
+	}
+	submit := func(router http.Handler, quantity string) (int, models.Order) {
+		body := `{"symbol":"NSE:INFY","quantity":` + quantity + `,"price":100,"side":"buy","type":"LIMIT"}`
+		req := httptest.NewRequest(http.MethodPut, "/oms/order", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+serviceToken)
+		req.Header.Set(UserIDHeader, "trader-1")
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		var reply struct {
+			Order models.Order `json:"order"`
+		}
+		json.Unmarshal(recorder.Body.Bytes(), &reply)
+		return recorder.Code, reply.Order
+	}
+
+	status, first := submit(start(), "1")
+	if status != http.StatusCreated {
+		t.Fatalf("first submission returned %d", status)
+	}
+	router := start()
+	if status, replayed := submit(router, "1"); status != http.StatusOK || replayed.ID != first.ID {
+		t.Errorf("retry after a restart returned %d with order %s, want 200 with %s", status, replayed.ID, first.ID)
+	}
+	if status, _ := submit(router, "2"); status != http.StatusConflict {
+		t.Errorf("a different order under the key returned %d, want 409", status)
+	}
+
+	// Once the window has passed the key places a new order
+	omsService.SetIdempotencyWindow(time.Nanosecond)
+	status, second := submit(router, "2")
+	if status != http.StatusCreated || second.ID == first.ID {
+		t.Fatalf("the key after the window returned %d with order %s, want 201 with a new order", status, second.ID)
+	}
+	original, err := omsService.GetOrder(first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if original.ClientOrderID != "" {
+		t.Errorf("the original order kept key %q", original.ClientOrderID)
+	}
+}
+
+func TestHTTPSquareOffCancelsQueuedOrders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	omsService := service.NewOMSService(repository.NewInMemoryOrderRepository())
+	// Monday evening, when orders are only queued as AMOs, then mid-session
+	now := time.Date(2026, 10, 19, 20, 0, 0, 0, calendar.IST)
+	omsService.SetClock(func() time.Time { return now })
+	router := SetupRoutes(log.New(io.Discard, "", 0), omsService, nil, Options{})
+
+	place := func(product string, amo bool) models.Order {
+		body := fmt.Sprintf(`{"symbol":"NSE:INFY","quantity":1,"price":100,"side":"buy","type":"LIMIT","product":%q,"amo":%t}`, product, amo)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/oms/order", strings.NewReader(body)))
+		var reply struct {
+			Order models.Order `json:"order"`
+		}
+		json.Unmarshal(recorder.Body.Bytes(), &reply)
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("placing a %s order returned %d: %s", product, recorder.Code, recorder.Body)
+		}
+		return reply.Order
+	}
+	queued := place("MIS", true)
+	now = time.Date(2026, 10, 20, 11, 0, 0, 0, calendar.IST)
+	pending := place("MIS", false)
+	delivery := place("CNC", false)
+	if queued.Status != models.OrderStatusQueued || pending.Status != models.OrderStatusPending {
+		t.Fatalf("placed orders %s and %s, want queued and pending", queued.Status, pending.Status)
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/oms/positions/squareoff", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("square-off returned %d: %s", recorder.Code, recorder.Body)
+	}
+	for _, tc := range []struct {
+		order models.Order
+		want  models.OrderStatus
+	}{{queued, models.OrderStatusCancelled}, {pending, models.OrderStatusCancelled}, {delivery, models.OrderStatusPending}} {
+		stored, err := omsService.GetOrder(tc.order.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.Status != tc.want {
+			t.Errorf("%s %s order is %s after square-off, want %s", tc.order.Product, tc.order.Status, stored.Status, tc.want)
+		}
+	}
+}
+
+func TestHTTPChecksFundsPerAccount(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	omsService := service.NewOMSService(repository.NewInMemoryOrderRepository())
+	omsService.SetClock(func() time.Time { return time.Date(2026, 10, 19, 11, 0, 0, 0, calendar.IST) })
+	omsService.Accounts().SetOpeningBalance(10000)
+	router := SetupRoutes(log.New(io.Discard, "", 0), omsService, nil, Options{ServiceToken: serviceToken})
+
+	// Each order blocks 6000 of delivery margin
+	place := func(accountID string) int {
+		body := `{"symbol":"NSE:INFY","quantity":60,"price":100,"side":"buy","type":"LIMIT","product":"CNC"}`
+		req := httptest.NewRequest(http.MethodPut, "/oms/order", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+serviceToken)
+		req.Header.Set(UserIDHeader, "trader-1")
+		req.Header.Set(AccountIDHeader, accountID)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+	if status := place("acct-1"); status != http.StatusCreated {
+		t.Fatalf("first order in acct-1 returned %d", status)
+	}
+	if status := place("acct-1"); status == http.StatusCreated {
+		t.Errorf("acct-1 placed a second order beyond its balance")
+	}
+	if status := place("acct-2"); status != http.StatusCreated {
+		t.Errorf("acct-2 was refused an order its own balance covers: %d", status)
+	}
+
+	funds, err := omsService.AvailableFunds("acct-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if funds != 4000 {
+		t.Errorf("acct-1 has %.2f available, want 4000", funds)
+	}
+}
+
+// failingStore refuses to store orders for one symbol, as a store failing
+// part-way through a multi-leg order would
+type failingStore struct {
+	*repository.InMemoryOrderRepository
+	symbol string
+}
+
+func (s failingStore) CreateOrder(order models.Order) (models.Order, error) {
+	if order.Symbol == s.symbol {
+		return models.Order{}, errors.New("store unavailable")
+	}
+	return s.InMemoryOrderRepository.CreateOrder(order)
+}
+
+func TestHTTPMultiLegRollsBackPlacedLegs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	omsService := service.NewOMSService(failingStore{repository.NewInMemoryOrderRepository(), "NSE:SBIN"})
+	omsService.SetClock(func() time.Time { return time.Date(2026, 10, 19, 11, 0, 0, 0, calendar.IST) })
+	router := SetupRoutes(log.New(io.Discard, "", 0), omsService, nil, Options{})
+
+	// The limit leg rests, the market leg fills and the last leg fails to store
+	body := `{"name":"basket","quantity":1,"product":"MIS","legs":[
+		{"symbol":"NSE:INFY","side":"buy","ratio":1,"type":"LIMIT","price":100},
+		{"symbol":"NSE:TCS","side":"buy","ratio":1,"type":"MARKET","price":100},
+		{"symbol":"NSE:SBIN","side":"buy","ratio":1,"type":"LIMIT","price":100}]}`
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/oms/strategy/order", strings.NewReader(body)))
+	if recorder.Code == http.StatusCreated || recorder.Code == http.StatusOK {
+		t.Fatalf("a multi-leg order with a failing leg returned %d", recorder.Code)
+	}
+
+	orders, err := omsService.GetOrders(repository.OrderFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parentID string
+	for _, order := range orders {
+		if order.Type == models.MultiLegOrderType {
+			parentID = order.ID
+		}
+	}
+	summary, err := omsService.GetMultiLegOrder(parentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Parent.Status != models.OrderStatusRejected {
+		t.Errorf("parent is %s, want rejected", summary.Parent.Status)
+	}
+	if len(summary.Legs) != 2 {
+		t.Fatalf("got %d legs stored, want the 2 placed before the failure", len(summary.Legs))
+	}
+	if leg := summary.Legs[0]; leg.Symbol != "NSE:INFY" || leg.Status != models.OrderStatusCancelled {
+		t.Errorf("resting leg %s is %s, want cancelled", leg.Symbol, leg.Status)
+	}
+	if leg := summary.Legs[1]; leg.Symbol != "NSE:TCS" || leg.Status != models.OrderStatusExecuted {
+		t.Errorf("filled leg %s is %s, want executed", leg.Symbol, leg.Status)
+	}
+	positions, err := omsService.GetPositions("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(positions) != 0 || len(summary.Position) != 0 {
+		t.Errorf("positions %+v and net %+v left open after the rollback, want none", positions, summary.Position)
+	}
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// requestIDKey is where RequestID keeps the request's ID in the gin context
+const requestIDKey = "request_id"
+
+// RequestID tags every request with the X-Request-ID the client sent, or a
+// new one, and echoes it back so replies, logs and audit entries can be
+// matched up
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" {
+			id = uuid.NewString()
+		}
+		c.Set(requestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// requestID returns the ID RequestID gave the request
+func requestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+// Logger logs every request once it has been served
+func Logger(logger *log.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		path := c.Request.URL.Path
+		if c.Request.URL.RawQuery != "" {
+			path += "?" + c.Request.URL.RawQuery
+		}
+		logger.Printf("%s %s %d %s from %s request_id=%s",
+			c.Request.Method, path, c.Writer.Status(), time.Since(start).Round(time.Microsecond), c.ClientIP(), requestID(c))
+	}
+}
+
+// Recovery turns a panicking handler into a 500 reply carrying the request ID,
+// logging the panic and its stack
+func Recovery(logger *log.Logger) gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(logger.Writer(), func(c *gin.Context, err any) {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error":      "Internal server error",
+			"request_id": requestID(c),
+		})
+	})
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/Mukilan-T/laabhum-oms-go/audit"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	natsclient "github.com/Mukilan-T/laabhum-oms-go/pkg/nats"
+	"github.com/Mukilan-T/laabhum-oms-go/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+)
+
+// NATS subjects the OMS answers order commands on
+const (
+	SubjectCreateOrder = "oms.order.create"
+	SubjectCancelOrder = "oms.order.cancel"
+	SubjectModifyOrder = "oms.order.modify"
+	SubjectPositions   = "oms.positions.get"
+	natsQueue          = "oms"
+)
+
+// NATSRequest is the body of every order command sent over NATS
+type NATSRequest struct {
+	RequestID string       `json:"request_id,omitempty"`
+	UserID    string       `json:"user_id,omitempty"`    // Read only with the service token
+	AccountID string       `json:"account_id,omitempty"` // Read only with the service token
+	Reason    string       `json:"reason,omitempty"`
+	OrderID   string       `json:"order_id,omitempty"`  // order to cancel or modify
+	ParentID  string       `json:"parent_id,omitempty"` // parent when modifying a child order
+	Order     models.Order `json:"order"`               // order to create, or the changes to apply
+	// IdempotencyKey creates the order once however often the request is sent
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+	// ServiceToken lets the sender name the user and account it acts for
+	ServiceToken string `json:"service_token,omitempty"`
+}
+
+// NATSReply carries the HTTP status code and body the same command would get over HTTP
+type NATSReply struct {
+	Status int   `json:"status"`
+	Body   gin.H `json:"body"`
+}
+
+// NATSServer serves order commands over NATS request/reply
+type NATSServer struct {
+	handlers      *Handlers
+	client        *natsclient.NatsClient
+	subscriptions []*nats.Subscription
+}
+
+func NewNATSServer(logger *log.Logger, omsService *service.OMSService, client *natsclient.NatsClient, opts Options) *NATSServer {
+	handlers := NewHandlers(logger, omsService)
+	handlers.opts = opts
+	return &NATSServer{
+		handlers: handlers,
+		client:   client,
+	}
+}
+
+// Start subscribes to the order command subjects. Instances share a queue
+// group, so each command is handled by exactly one OMS.
+func (s *NATSServer) Start() error {
+	commands := map[string]func(*service.OMSService, NATSRequest) (int, gin.H){
+		SubjectCreateOrder: func(svc *service.OMSService, req NATSRequest) (int, gin.H) {
+			return s.handlers.createOrder(svc, req.IdempotencyKey, req.Order)
+		},
+		SubjectCancelOrder: func(svc *service.OMSService, req NATSRequest) (int, gin.H) {
+			return s.handlers.cancelOrder(svc, req.OrderID)
+		},
+		SubjectModifyOrder: func(svc *service.OMSService, req NATSRequest) (int, gin.H) {
+			return s.handlers.modifyOrder(svc, req.ParentID, req.OrderID, req.Order)
+		},
+		SubjectPositions: func(svc *service.OMSService, req NATSRequest) (int, gin.H) {
+			return s.handlers.positions(svc, "")
+		},
+	}
+
+	for subject, command := range commands {
+		subscription, err := s.client.QueueSubscribe(subject, natsQueue, s.serve(command))
+		if err != nil {
+			s.Stop()
+			return err
+		}
+		s.subscriptions = append(s.subscriptions, subscription)
+	}
+	return nil
+}
+
+// Stop unsubscribes from every subject
+func (s *NATSServer) Stop() error {
+	var firstErr error
+	for _, subscription := range s.subscriptions {
+		if err := subscription.Unsubscribe(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	s.subscriptions = nil
+	return firstErr
+}
+
+func (s *NATSServer) serve(command func(*service.OMSService, NATSRequest) (int, gin.H)) nats.MsgHandler {
+	return func(msg *nats.Msg) {
+		var (
+			req    NATSRequest
+			status int
+			body   gin.H
+		)
+		if len(msg.Data) > 0 {
+			if err := json.Unmarshal(msg.Data, &req); err != nil {
+				s.handlers.logger.Printf("Invalid input on %s: %v", msg.Subject, err)
+				status, body = http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()}
+			}
+		}
+		if body == nil {
+			if req.RequestID == "" {
+				req.RequestID = uuid.NewString()
+			}
+			source := audit.Source{
+				Actor:     audit.User(""),
+				Reason:    req.Reason,
+				RequestID: req.RequestID,
+			}
+			if s.handlers.opts.isServiceToken(req.ServiceToken) {
+				source.Actor = audit.User(req.UserID)
+				source.Account = req.AccountID
+			}
+			status, body = command(s.handlers.omsService.As(source), req)
+		}
+
+		if msg.Reply == "" {
+			return
+		}
+		if err := s.client.Publish(msg.Reply, NATSReply{Status: status, Body: body}); err != nil {
+			s.handlers.logger.Printf("Replying on %s: %v", msg.Subject, err)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/calendar"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	natsclient "github.com/Mukilan-T/laabhum-oms-go/pkg/nats"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+	"github.com/Mukilan-T/laabhum-oms-go/service"
+	natsserver "github.com/nats-io/nats-server/v2/test"
+)
+
+// serviceToken lets test callers name the user they act for
+const serviceToken = "s3cret"
+
+// natsReply is NATSReply with the body decoded into the fields commands answer with
+type natsReply struct {
+	Status int `json:"status"`
+	Body   struct {
+		Message  string       `json:"message"`
+		Error    string       `json:"error"`
+		Order    models.Order `json:"order"`
+		Replayed bool         `json:"replayed"`
+	} `json:"body"`
+}
+
+// startNATS serves order commands for a fresh OMS on an in-process NATS
+// server and returns a client to send them with
+func startNATS(t *testing.T) (*natsclient.NatsClient, *service.OMSService) {
+	t.Helper()
+	opts := natsserver.DefaultTestOptions
+	opts.Port = -1
+	server := natsserver.RunServer(&opts)
+	t.Cleanup(server.Shutdown)
+
+	omsService := service.NewOMSService(repository.NewInMemoryOrderRepository())
+	// A Monday mid-session, so orders are placed rather than rejected or queued
+	omsService.SetClock(func() time.Time { return time.Date(2026, 10, 19, 11, 0, 0, 0, calendar.IST) })
+
+	conn, err := natsclient.Connect(server.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Drain() })
+	natsServer := NewNATSServer(log.New(io.Discard, "", 0), omsService, conn, Options{ServiceToken: serviceToken})
+	if err := natsServer.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { natsServer.Stop() })
+
+	client, err := natsclient.Connect(server.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Drain() })
+	return client, omsService
+}
+
+func request(t *testing.T, client *natsclient.NatsClient, subject string, req interface{}) natsReply {
+	t.Helper()
+	var reply natsReply
+	if err := client.Request(subject, req, &reply, 2*time.Second); err != nil {
+		t.Fatalf("%s: %v", subject, err)
+	}
+	return reply
+}
+
+func limitOrder() models.Order {
+	return models.Order{Symbol: "NSE:INFY", Quantity: 5, Price: 100, Side: models.SideBuy,
+		Type: models.LimitOrder, Strategy: models.StrategyDayTrading}
+}
+
+func TestNATSOrderCommands(t *testing.T) {
+	client, omsService := startNATS(t)
+
+	created := request(t, client, SubjectCreateOrder, NATSRequest{UserID: "trader-1", Order: limitOrder()})
+	if created.Status != http.StatusCreated || created.Body.Message != "Order created successfully" {
+		t.Fatalf("create: %+v", created)
+	}
+	order := created.Body.Order
+	if order.ID == "" || order.Status != models.OrderStatusPending || order.Price != 100 {
+		t.Fatalf("created order %+v, want a pending limit order at 100", order)
+	}
+
+	changes := limitOrder()
+	changes.Price = 101
+	modified := request(t, client, SubjectModifyOrder, NATSRequest{OrderID: order.ID, Order: changes})
+	if modified.Status != http.StatusOK || modified.Body.Order.Price != 101 {
+		t.Fatalf("modify: %+v, want 200 with price 101", modified)
+	}
+	if stored, err := omsService.GetOrder(order.ID); err != nil || stored.Price != 101 {
+		t.Errorf("stored order after modify: %+v, %v", stored, err)
+	}
+
+	cancelled := request(t, client, SubjectCancelOrder, NATSRequest{OrderID: order.ID})
+	if cancelled.Status != http.StatusOK || cancelled.Body.Message != "Order cancelled successfully" {
+		t.Fatalf("cancel: %+v", cancelled)
+	}
+	if stored, err := omsService.GetOrder(order.ID); err != nil || stored.Status != models.OrderStatusCancelled {
+		t.Errorf("stored order after cancel: %+v, %v", stored, err)
+	}
+}
+
+func TestNATSCommandErrors(t *testing.T) {
+	client, _ := startNATS(t)
+
+	invalid := limitOrder()
+	invalid.Quantity = 0
+	tests := []struct {
+		name    string
+		subject string
+		req     NATSRequest
+		status  int
+		message string
+	}{
+		{"invalid order", SubjectCreateOrder, NATSRequest{Order: invalid}, http.StatusBadRequest, "Order creation failed: "},
+		{"cancel unknown order", SubjectCancelOrder, NATSRequest{OrderID: "missing"}, http.StatusNotFound, "Order cancellation failed: "},
+		{"modify unknown order", SubjectModifyOrder, NATSRequest{OrderID: "missing", Order: limitOrder()}, http.StatusNotFound, "Order modification failed: "},
+		{"modify another parent's child", SubjectModifyOrder, NATSRequest{ParentID: "other", OrderID: "missing", Order: limitOrder()}, http.StatusBadRequest, "Order modification failed: "},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reply := request(t, client, tc.subject, tc.req)
+			if reply.Status != tc.status {
+				t.Errorf("status %d, want %d (%s)", reply.Status, tc.status, reply.Body.Error)
+			}
+			if len(reply.Body.Error) <= len(tc.message) || reply.Body.Error[:len(tc.message)] != tc.message {
+				t.Errorf("error %q, want it to start %q", reply.Body.Error, tc.message)
+			}
+		})
+	}
+
+	t.Run("malformed request", func(t *testing.T) {
+		var reply natsReply
+		if err := client.Request(SubjectCreateOrder, []byte("{"), &reply, 2*time.Second); err != nil {
+			t.Fatal(err)
+		}
+		if reply.Status != http.StatusBadRequest {
+			t.Errorf("status %d, want 400", reply.Status)
+		}
+	})
+}
+
+func TestNATSCreateIsIdempotent(t *testing.T) {
+	client, omsService := startNATS(t)
+
+	req := NATSRequest{IdempotencyKey: "key-1", Order: limitOrder()}
+	first := request(t, client, SubjectCreateOrder, req)
+	second := request(t, client, SubjectCreateOrder, req)
+	if first.Status != http.StatusCreated || second.Status != http.StatusOK || !second.Body.Replayed {
+		t.Fatalf("statuses %d then %d (replayed %v), want 201 then a 200 replay", first.Status, second.Status, second.Body.Replayed)
+	}
+	if second.Body.Order.ID != first.Body.Order.ID {
+		t.Errorf("replay returned order %s, want %s", second.Body.Order.ID, first.Body.Order.ID)
+	}
+	orders, err := omsService.GetOrders(repository.OrderFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 1 {
+		t.Errorf("%d orders stored, want 1", len(orders))
+	}
+}
+
+func TestNATSNamesUsersOnlyWithServiceToken(t *testing.T) {
+	client, _ := startNATS(t)
+
+	tests := []struct {
+		name      string
+		token     string
+		userID    string
+		accountID string
+	}{
+		{"no token", "", "", ""},
+		{"wrong token", "guess", "", ""},
+		{"service token", serviceToken, "trader-1", "acct-1"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			order := limitOrder()
+			order.UserID = "someone-else" // Never taken from the order itself
+			created := request(t, client, SubjectCreateOrder,
+				NATSRequest{UserID: "trader-1", AccountID: "acct-1", ServiceToken: tc.token, Order: order})
+			if created.Status != http.StatusCreated {
+				t.Fatalf("create: %+v", created)
+			}
+			if got := created.Body.Order; got.UserID != tc.userID || got.AccountID != tc.accountID {
+				t.Errorf("order placed for user %q and account %q, want %q and %q", got.UserID, got.AccountID, tc.userID, tc.accountID)
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package api
+
+import (
+	"encoding/json"
+	"time"
+
+	omsv1 "github.com/Mukilan-T/laabhum-api-go/oms/v1"
+	orderv1 "github.com/Mukilan-T/laabhum-api-go/order/v1"
+	"github.com/Mukilan-T/laabhum-oms-go/audit"
+	"github.com/Mukilan-T/laabhum-oms-go/calendar"
+	"github.com/Mukilan-T/laabhum-oms-go/instruments"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/events"
+	"github.com/Mukilan-T/laabhum-oms-go/service"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Conversions between the OMS models and the gRPC messages. Orders and
+// their enums convert through the shared order contract; values the messages
+// have no enum for convert to the unspecified value and back to "".
+
+func timestampToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func timestampFromProto(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func ordersToProto(orders []models.Order) []*omsv1.Order {
+	out := make([]*omsv1.Order, 0, len(orders))
+	for _, order := range orders {
+		out = append(out, order.Proto())
+	}
+	return out
+}
+
+func positionToProto(position models.Position) *omsv1.Position {
+	return &omsv1.Position{
+		Id:            position.ID,
+		OrderId:       position.OrderID,
+		Symbol:        position.Symbol,
+		Quantity:      int32(position.Quantity),
+		EntryPrice:    position.EntryPrice,
+		CurrentPrice:  position.CurrentPrice,
+		StopLoss:      position.StopLoss,
+		TakeProfit:    position.TakeProfit,
+		Strategy:      string(position.Strategy),
+		Product:       position.Product.Proto(),
+		Contract:      position.Contract.Proto(),
+		OpenedAt:      timestampToProto(position.OpenedAt),
+		LastUpdatedAt: timestampToProto(position.LastUpdatedAt),
+		Status:        position.Status,
+		UserId:        position.UserID,
+		AccountId:     position.AccountID,
+	}
+}
+
+func tradeToProto(trade models.Trade) *omsv1.Trade {
+	return &omsv1.Trade{
+		Id:        trade.ID,
+		OrderId:   trade.OrderID,
+		Symbol:    trade.Symbol,
+		Quantity:  int32(trade.Quantity),
+		Price:     trade.Price,
+		TradeTime: timestampToProto(trade.TradeTime),
+	}
+}
+
+func scalperOrderToProto(order models.ScalperOrder) *omsv1.ScalperOrder {
+	return &omsv1.ScalperOrder{
+		Id:             order.ID,
+		Symbol:         order.Symbol,
+		Quantity:       int32(order.Quantity),
+		Price:          order.Price,
+		StopLoss:       order.StopLoss,
+		TakeProfit:     order.TakeProfit,
+		RiskPercentage: order.RiskPercentage,
+		CreatedAt:      order.CreatedAt,
+		ExpiresAt:      timestampToProto(order.ExpiresAt),
+	}
+}
+
+func scalperOrderFromProto(order *omsv1.ScalperOrder) models.ScalperOrder {
+	return models.ScalperOrder{
+		ID:             order.Id,
+		Symbol:         order.Symbol,
+		Quantity:       int(order.Quantity),
+		Price:          order.Price,
+		StopLoss:       order.StopLoss,
+		TakeProfit:     order.TakeProfit,
+		RiskPercentage: order.RiskPercentage,
+		CreatedAt:      order.CreatedAt,
+		ExpiresAt:      timestampFromProto(order.ExpiresAt),
+	}
+}
+
+func ctcOrderToProto(order models.CTCOrder) *omsv1.CTCOrder {
+	return &omsv1.CTCOrder{
+		Id:        order.ID,
+		ParentId:  order.ParentID,
+		Symbol:    order.Symbol,
+		Quantity:  int32(order.Quantity),
+		Price:     order.Price,
+		OrderType: order.OrderType,
+		CreatedAt: order.CreatedAt,
+	}
+}
+
+func ctcOrderFromProto(order *omsv1.CTCOrder) models.CTCOrder {
+	return models.CTCOrder{
+		ID:        order.Id,
+		ParentID:  order.ParentId,
+		Symbol:    order.Symbol,
+		Quantity:  int(order.Quantity),
+		Price:     order.Price,
+		OrderType: order.OrderType,
+		CreatedAt: order.CreatedAt,
+	}
+}
+
+func multiLegOrderFromProto(order *omsv1.MultiLegOrder) (models.MultiLegOrder, error) {
+	strategy, err := orderv1.TradeStrategyFromProto(order.Strategy)
+	if err != nil {
+		return models.MultiLegOrder{}, err
+	}
+	legs := make([]models.StrategyLeg, 0, len(order.Legs))
+	for _, leg := range order.Legs {
+		legs = append(legs, models.StrategyLeg{
+			Symbol: leg.Symbol,
+			Side:   orderv1.SideFromProto(leg.Side),
+			Ratio:  int(leg.Ratio),
+			Type:   orderv1.OrderTypeFromProto(leg.Type),
+			Price:  leg.Price,
+		})
+	}
+	return models.MultiLegOrder{
+		Name:     order.Name,
+		Quantity: int(order.Quantity),
+		Product:  orderv1.ProductFromProto(order.Product),
+		Strategy: strategy,
+		Legs:     legs,
+	}, nil
+}
+
+func multiLegSummaryToProto(summary *models.MultiLegSummary) *omsv1.MultiLegSummary {
+	position := make([]*omsv1.LegPosition, 0, len(summary.Position))
+	for _, leg := range summary.Position {
+		position = append(position, &omsv1.LegPosition{Symbol: leg.Symbol, Quantity: int32(leg.Quantity)})
+	}
+	return &omsv1.MultiLegSummary{
+		Parent:     summary.Parent.Proto(),
+		Legs:       ordersToProto(summary.Legs),
+		NetPremium: summary.NetPremium,
+		Position:   position,
+	}
+}
+
+func squareOffReportToProto(report *service.SquareOffReport) *omsv1.SquareOffReport {
+	return &omsv1.SquareOffReport{
+		RanAt:           timestampToProto(report.RanAt),
+		CancelledOrders: report.CancelledOrders,
+		ClosedPositions: report.ClosedPositions,
+		Errors:          report.Errors,
+	}
+}
+
+func expiryReportToProto(report *service.ExpiryReport) *omsv1.ExpiryReport {
+	return &omsv1.ExpiryReport{
+		RanAt:            timestampToProto(report.RanAt),
+		Action:           string(report.Action),
+		ClosedPositions:  report.ClosedPositions,
+		FlaggedPositions: report.FlaggedPositions,
+		Errors:           report.Errors,
+	}
+}
+
+func instrumentToProto(instrument *instruments.Instrument) *omsv1.Instrument {
+	if instrument == nil {
+		return nil
+	}
+	return &omsv1.Instrument{
+		Exchange:       instrument.Exchange,
+		Tradingsymbol:  instrument.Symbol,
+		Name:           instrument.Name,
+		Segment:        instrument.Segment.Proto(),
+		InstrumentType: string(instrument.Type),
+		Underlying:     instrument.Underlying,
+		Expiry:         instrument.Expiry,
+		Strike:         instrument.Strike,
+		TickSize:       instrument.TickSize,
+		LotSize:        int32(instrument.LotSize),
+		FreezeQuantity: int32(instrument.FreezeQuantity),
+		LowerCircuit:   instrument.LowerCircuit,
+		UpperCircuit:   instrument.UpperCircuit,
+	}
+}
+
+func optionChainToProto(chain *instruments.OptionChain) *omsv1.OptionChain {
+	rows := make([]*omsv1.OptionChainRow, 0, len(chain.Rows))
+	for _, row := range chain.Rows {
+		rows = append(rows, &omsv1.OptionChainRow{
+			Strike: row.Strike,
+			Call:   instrumentToProto(row.Call),
+			Put:    instrumentToProto(row.Put),
+		})
+	}
+	return &omsv1.OptionChain{Underlying: chain.Underlying, Expiry: chain.Expiry, Rows: rows}
+}
+
+func marketStatusToProto(status calendar.MarketStatus) *omsv1.MarketStatus {
+	return &omsv1.MarketStatus{
+		Segment:    status.Segment.Proto(),
+		Time:       timestampToProto(status.Time),
+		TradingDay: status.TradingDay,
+		Phase:      string(status.Phase),
+		NextOpen:   timestampToProto(status.NextOpen),
+		Holiday:    status.HolidayName,
+	}
+}
+
+func holidayToProto(holiday calendar.Holiday) *omsv1.Holiday {
+	out := &omsv1.Holiday{Date: holiday.Date, Description: holiday.Description}
+	for _, segment := range holiday.Segments {
+		out.Segments = append(out.Segments, segment.Proto())
+	}
+	return out
+}
+
+func tickFromProto(tick *omsv1.Tick) models.MarketCondition {
+	return models.MarketCondition{
+		Symbol:     tick.Symbol,
+		Price:      tick.Price,
+		Volume:     int(tick.Volume),
+		Volatility: tick.Volatility,
+		Trend:      tick.Trend,
+		Timestamp:  timestampFromProto(tick.Timestamp),
+	}
+}
+
+func timelineEntryToProto(entry audit.Entry) (*omsv1.TimelineEntry, error) {
+	out := &omsv1.TimelineEntry{
+		Seq:        entry.Sequence,
+		Timestamp:  timestampToProto(entry.Timestamp),
+		OrderId:    entry.OrderID,
+		PositionId: entry.PositionID,
+		Action:     entry.Action,
+		Actor:      entry.Actor,
+		Reason:     entry.Reason,
+		RequestId:  entry.RequestID,
+	}
+	var err error
+	if entry.Previous != nil {
+		if out.Previous, err = json.Marshal(entry.Previous); err != nil {
+			return nil, err
+		}
+	}
+	if entry.Current != nil {
+		if out.Current, err = json.Marshal(entry.Current); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func eventToProto(event events.Event) (*omsv1.Event, error) {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &omsv1.Event{
+		Sequence:  event.Sequence,
+		Type:      event.Type,
+		Timestamp: timestampToProto(event.Timestamp),
+		Data:      data,
+	}, nil
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/events"
+	"github.com/gin-gonic/gin"
+)
+
+// streamKeepAlive is how often an idle event stream sends a comment so proxies
+// and clients can tell a quiet stream from a dead connection
+const streamKeepAlive = 15 * time.Second
+
+// streamBuffer is how many events a slow stream client may fall behind by
+// before it has to catch up from the bus history
+const streamBuffer = 256
+
+// StreamEvents streams OMS events as server-sent events. A client resuming
+// after a disconnect passes the last sequence it saw as ?since= or the
+// Last-Event-ID header and receives everything after it; if those events have
+// already left the history it is sent a gap event and must resynchronise.
+func (h *Handlers) StreamEvents(c *gin.Context) {
+	stream, ok := h.omsService.Events().(events.Stream)
+	if !ok {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "Event stream not available"})
+		return
+	}
+
+	resume := c.Query("since")
+	if resume == "" {
+		resume = c.GetHeader("Last-Event-ID")
+	}
+	var last uint64
+	if resume != "" {
+		var err error
+		if last, err = strconv.ParseUint(resume, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid since: " + err.Error()})
+			return
+		}
+	}
+
+	// Subscribe before reading the history so nothing published in between is lost
+	ch := stream.Subscribe(streamBuffer)
+	defer stream.Unsubscribe(ch)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	w := c.Writer
+	following := resume != ""
+	if following {
+		if err := writeBacklog(w, stream, &last); err != nil {
+			return
+		}
+	}
+	w.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-h.opts.StopStreams:
+			return
+		case <-keepAlive.C:
+			if _, err := io.WriteString(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-ch:
+			if !ok {
+				return
+			}
+			switch {
+			case following && event.Sequence <= last:
+				continue
+			case following && event.Sequence > last+1:
+				// The subscription dropped events while the client was slow
+				if err := writeBacklog(w, stream, &last); err != nil {
+					return
+				}
+			default:
+				if err := writeEvent(w, event); err != nil {
+					return
+				}
+				last, following = event.Sequence, true
+			}
+		}
+		w.Flush()
+	}
+}
+
+// writeBacklog writes the retained events after *last, preceded by a gap event
+// when some of them have been dropped, and advances *last. After a gap the
+// client follows the stream from whatever the history still holds.
+func writeBacklog(w io.Writer, stream events.Stream, last *uint64) error {
+	backlog, complete := stream.Since(*last)
+	if !complete {
+		if _, err := fmt.Fprintf(w, "event: gap\ndata: {\"since\":%d}\n\n", *last); err != nil {
+			return err
+		}
+		*last = 0
+	}
+	for _, event := range backlog {
+		if err := writeEvent(w, event); err != nil {
+			return err
+		}
+		*last = event.Sequence
+	}
+	return nil
+}
+
+func writeEvent(w io.Writer, event events.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Sequence, event.Type, data)
+	return err
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	omsv1 "github.com/Mukilan-T/laabhum-api-go/oms/v1"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+	"github.com/Mukilan-T/laabhum-oms-go/service"
+)
+
+// ended fails the test unless done is closed soon
+func ended(t *testing.T, what string, done <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("%s still open after the streams were stopped", what)
+	}
+}
+
+func TestStopStreamsEndsEventStreams(t *testing.T) {
+	stop := make(chan struct{})
+	omsService := service.NewOMSService(repository.NewInMemoryOrderRepository())
+	server := httptest.NewServer(SetupRoutes(log.New(io.Discard, "", 0), omsService, nil, Options{StopStreams: stop}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/oms/events/stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("stream returned %d", resp.StatusCode)
+	}
+	httpDone := make(chan struct{})
+	go func() {
+		defer close(httpDone)
+		io.Copy(io.Discard, resp.Body)
+	}()
+
+	stream, err := startGRPC(t, Options{StopStreams: stop}).StreamEvents(context.Background(), &omsv1.StreamEventsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcDone := make(chan struct{})
+	go func() {
+		defer close(grpcDone)
+		for {
+			if _, err := stream.Recv(); err != nil {
+				return
+			}
+		}
+	}()
+
+	close(stop)
+	ended(t, "HTTP event stream", httpDone)
+	ended(t, "gRPC event stream", grpcDone)
+}
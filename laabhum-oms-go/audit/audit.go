@@ -0,0 +1,170 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Actions recorded in the trail
+const (
+	OrderCreated    = "order.created"
+	OrderAmended    = "order.amended"
+	OrderStatus     = "order.status"
+	OrderDeleted    = "order.deleted"
+	PositionOpened  = "position.opened"
+	PositionChanged = "position.changed"
+	PositionClosed  = "position.closed"
+)
+
+// RiskEngine is the actor for changes made by margin and risk checks
+const RiskEngine = "risk"
+
+// User names a trader or API client as the actor of a change
+func User(id string) string {
+	if id == "" {
+		id = "anonymous"
+	}
+	return "user:" + id
+}
+
+// anonymous is the actor of changes by callers that did not say who they are
+var anonymous = User("")
+
+// System names a scheduled OMS job as the actor of a change
+func System(job string) string {
+	return "system:" + job
+}
+
+// Source describes who made a change, why, and as part of which request
+type Source struct {
+	Actor     string `json:"actor"`
+	Account   string `json:"account,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// IsUser reports whether a trader or API client made the change, named or
+// not, rather than the OMS itself
+func (s Source) IsUser() bool {
+	return strings.HasPrefix(s.Actor, "user:")
+}
+
+// UserID returns the ID of the trader or API client who made the change, or
+// "" when they were not named or the OMS made it
+func (s Source) UserID() string {
+	if !s.IsUser() || s.Actor == anonymous {
+		return ""
+	}
+	return strings.TrimPrefix(s.Actor, "user:")
+}
+
+// Entry is one change to an order or position
+type Entry struct {
+	Sequence   uint64      `json:"seq"`
+	Timestamp  time.Time   `json:"timestamp"`
+	OrderID    string      `json:"order_id"`
+	PositionID string      `json:"position_id,omitempty"`
+	Action     string      `json:"action"`
+	Previous   interface{} `json:"previous,omitempty"`
+	Current    interface{} `json:"current,omitempty"`
+	Source
+}
+
+// Trail keeps every audit entry, indexed by the order it belongs to, and
+// optionally appends each one to a JSON lines file
+type Trail struct {
+	mutex    sync.RWMutex
+	sequence uint64
+	byOrder  map[string][]Entry
+	file     *os.File
+}
+
+func NewTrail() *Trail {
+	return &Trail{byOrder: make(map[string][]Entry)}
+}
+
+// OpenTrail loads the entries already in path and appends new ones to it
+func OpenTrail(path string) (*Trail, error) {
+	trail := NewTrail()
+
+	existing, err := os.Open(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		scanner := bufio.NewScanner(existing)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+		for line := 1; scanner.Scan(); line++ {
+			var entry Entry
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				existing.Close()
+				return nil, fmt.Errorf("%s line %d: %w", path, line, err)
+			}
+			trail.add(entry)
+		}
+		existing.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	trail.file, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return trail, nil
+}
+
+// Record stamps entry with the next sequence number and the current time and stores it
+func (t *Trail) Record(entry Entry) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	entry.Sequence = t.sequence + 1
+	entry.Timestamp = time.Now()
+	if t.file != nil {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if _, err := t.file.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	t.add(entry)
+	return nil
+}
+
+func (t *Trail) add(entry Entry) {
+	if entry.Sequence > t.sequence {
+		t.sequence = entry.Sequence
+	}
+	t.byOrder[entry.OrderID] = append(t.byOrder[entry.OrderID], entry)
+}
+
+// Timeline returns the changes to an order and its positions, oldest first
+func (t *Trail) Timeline(orderID string) []Entry {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	entries := append([]Entry(nil), t.byOrder[orderID]...)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Sequence < entries[j].Sequence })
+	return entries
+}
+
+// Close closes the trail file, if any
+func (t *Trail) Close() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.file == nil {
+		return nil
+	}
+	return t.file.Close()
+}
@@ -0,0 +1,180 @@
+package audit
+
+import (
+	"log"
+
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+	"github.com/google/uuid"
+)
+
+// Recorder wraps a repository and records an audit entry, attributed to its
+// source, for every order and position it changes
+type Recorder struct {
+	repository.OrderRepository
+	trail  *Trail
+	source Source
+}
+
+func NewRecorder(repo repository.OrderRepository, trail *Trail, source Source) *Recorder {
+	return &Recorder{OrderRepository: repo, trail: trail, source: source}
+}
+
+// record stores an entry. A failed write to the trail is logged rather than
+// failing a change that has already been applied.
+func (r *Recorder) record(entry Entry) {
+	entry.Source = r.source
+	if err := r.trail.Record(entry); err != nil {
+		log.Printf("Audit: failed to record %s for order %s: %v", entry.Action, entry.OrderID, err)
+	}
+}
+
+// order returns a copy of the stored order, or nil if there is none
+func (r *Recorder) order(id string) *models.Order {
+	order, err := r.OrderRepository.GetOrder(id)
+	if err != nil || order == nil {
+		return nil
+	}
+	copied := *order
+	return &copied
+}
+
+func (r *Recorder) position(id string) *models.Position {
+	position, err := r.OrderRepository.GetPosition(id)
+	if err != nil || position == nil {
+		return nil
+	}
+	copied := *position
+	return &copied
+}
+
+func (r *Recorder) recordOrder(action, id string, previous *models.Order) {
+	entry := Entry{OrderID: id, Action: action}
+	if previous != nil {
+		entry.Previous = previous
+	}
+	if current := r.order(id); current != nil {
+		entry.Current = current
+	}
+	r.record(entry)
+}
+
+func (r *Recorder) recordPosition(action string, previous, current *models.Position) {
+	entry := Entry{Action: action}
+	for _, position := range []*models.Position{current, previous} {
+		if position != nil {
+			entry.OrderID = position.OrderID
+			entry.PositionID = position.ID
+			break
+		}
+	}
+	if previous != nil {
+		entry.Previous = previous
+	}
+	if current != nil {
+		entry.Current = current
+	}
+	r.record(entry)
+}
+
+// CreateOrder stores order as placed by the user the recorder acts for. Orders
+// the OMS places itself keep the owner they were created with.
+func (r *Recorder) CreateOrder(order models.Order) (models.Order, error) {
+	if r.source.IsUser() {
+		order.UserID, order.AccountID = r.source.UserID(), r.source.Account
+	}
+	created, err := r.OrderRepository.CreateOrder(order)
+	if err != nil {
+		return created, err
+	}
+	r.recordOrder(OrderCreated, created.ID, nil)
+	return created, nil
+}
+
+func (r *Recorder) SaveOrder(order map[string]interface{}) error {
+	id, _ := order["ID"].(string)
+	previous := r.order(id)
+	if err := r.OrderRepository.SaveOrder(order); err != nil {
+		return err
+	}
+	action := OrderAmended
+	if previous == nil {
+		action = OrderCreated
+	}
+	r.recordOrder(action, id, previous)
+	return nil
+}
+
+func (r *Recorder) UpdateOrder(order models.Order) error {
+	previous := r.order(order.ID)
+	if err := r.OrderRepository.UpdateOrder(order); err != nil {
+		return err
+	}
+	action := OrderAmended
+	if previous != nil && previous.Status != order.Status {
+		action = OrderStatus
+	}
+	r.recordOrder(action, order.ID, previous)
+	return nil
+}
+
+func (r *Recorder) UpdateOrderStatus(id string, status models.OrderStatus) error {
+	previous := r.order(id)
+	if err := r.OrderRepository.UpdateOrderStatus(id, status); err != nil {
+		return err
+	}
+	r.recordOrder(OrderStatus, id, previous)
+	return nil
+}
+
+func (r *Recorder) ExecuteChildOrder(orderID string) error {
+	previous := r.order(orderID)
+	if err := r.OrderRepository.ExecuteChildOrder(orderID); err != nil {
+		return err
+	}
+	r.recordOrder(OrderStatus, orderID, previous)
+	return nil
+}
+
+func (r *Recorder) DeleteOrder(id string) error {
+	previous := r.order(id)
+	if err := r.OrderRepository.DeleteOrder(id); err != nil {
+		return err
+	}
+	entry := Entry{OrderID: id, Action: OrderDeleted}
+	if previous != nil {
+		entry.Previous = previous
+	}
+	r.record(entry)
+	return nil
+}
+
+func (r *Recorder) CreatePosition(position models.Position) error {
+	// Assign the ID here so the stored position can be read back for the entry
+	if position.ID == "" {
+		position.ID = uuid.New().String()
+	}
+	if err := r.OrderRepository.CreatePosition(position); err != nil {
+		return err
+	}
+	r.recordPosition(PositionOpened, nil, r.position(position.ID))
+	return nil
+}
+
+func (r *Recorder) UpdatePosition(position models.Position) error {
+	previous := r.position(position.ID)
+	if err := r.OrderRepository.UpdatePosition(position); err != nil {
+		return err
+	}
+	r.recordPosition(PositionChanged, previous, r.position(position.ID))
+	return nil
+}
+
+func (r *Recorder) ClosePosition(id string) error {
+	previous := r.position(id)
+	if err := r.OrderRepository.ClosePosition(id); err != nil {
+		return err
+	}
+	r.recordPosition(PositionClosed, previous, r.position(id))
+	return nil
+}
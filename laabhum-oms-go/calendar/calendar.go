@@ -0,0 +1,295 @@
+package calendar
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+)
+
+// IST is the exchange timezone all session times are expressed in
+var IST = time.FixedZone("IST", 5*60*60+30*60)
+
+const dateLayout = "2006-01-02"
+
+type SessionPhase string
+
+const (
+	PhasePreOpen   SessionPhase = "PRE_OPEN"
+	PhaseNormal    SessionPhase = "NORMAL"
+	PhasePostClose SessionPhase = "POST_CLOSE"
+	PhaseClosed    SessionPhase = "CLOSED"
+)
+
+// Session is one phase of the trading day, with start and end as "HH:MM" in IST
+type Session struct {
+	Phase SessionPhase `json:"phase"`
+	Start string       `json:"start"`
+	End   string       `json:"end"`
+}
+
+// Holiday closes the listed segments, or every segment when none are listed
+type Holiday struct {
+	Date        string           `json:"date"` // YYYY-MM-DD
+	Description string           `json:"description"`
+	Segments    []models.Segment `json:"segments,omitempty"`
+}
+
+// MarketStatus is a point-in-time view of a segment's trading session
+type MarketStatus struct {
+	Segment     models.Segment `json:"segment"`
+	Time        time.Time      `json:"time"`
+	TradingDay  bool           `json:"trading_day"`
+	Phase       SessionPhase   `json:"phase"`
+	NextOpen    time.Time      `json:"next_open"`
+	HolidayName string         `json:"holiday,omitempty"`
+}
+
+// DefaultSessions are the regular NSE/BSE/MCX session timings
+var DefaultSessions = map[models.Segment][]Session{
+	models.SegmentEquity: {
+		{Phase: PhasePreOpen, Start: "09:00", End: "09:15"},
+		{Phase: PhaseNormal, Start: "09:15", End: "15:30"},
+		{Phase: PhasePostClose, Start: "15:40", End: "16:00"},
+	},
+	models.SegmentFNO: {
+		{Phase: PhaseNormal, Start: "09:15", End: "15:30"},
+	},
+	models.SegmentCurrency: {
+		{Phase: PhaseNormal, Start: "09:00", End: "17:00"},
+	},
+	models.SegmentCommodity: {
+		{Phase: PhaseNormal, Start: "09:00", End: "23:30"},
+	},
+}
+
+type session struct {
+	phase      SessionPhase
+	start, end time.Duration // offsets from midnight IST
+}
+
+// Calendar answers whether and how a segment is trading at a given time
+type Calendar struct {
+	sessions map[models.Segment][]session
+	holidays map[string][]Holiday
+}
+
+func New(sessions map[models.Segment][]Session, holidays []Holiday) (*Calendar, error) {
+	c := &Calendar{
+		sessions: make(map[models.Segment][]session),
+		holidays: make(map[string][]Holiday),
+	}
+
+	for segment, list := range sessions {
+		for _, s := range list {
+			start, err := parseClock(s.Start)
+			if err != nil {
+				return nil, fmt.Errorf("segment %s %s start: %w", segment, s.Phase, err)
+			}
+			end, err := parseClock(s.End)
+			if err != nil {
+				return nil, fmt.Errorf("segment %s %s end: %w", segment, s.Phase, err)
+			}
+			if end <= start {
+				return nil, fmt.Errorf("segment %s %s ends before it starts", segment, s.Phase)
+			}
+			c.sessions[segment] = append(c.sessions[segment], session{phase: s.Phase, start: start, end: end})
+		}
+		sort.Slice(c.sessions[segment], func(i, j int) bool {
+			return c.sessions[segment][i].start < c.sessions[segment][j].start
+		})
+	}
+
+	for _, h := range holidays {
+		if _, err := time.ParseInLocation(dateLayout, h.Date, IST); err != nil {
+			return nil, fmt.Errorf("invalid holiday date %q: %w", h.Date, err)
+		}
+		c.holidays[h.Date] = append(c.holidays[h.Date], h)
+	}
+
+	return c, nil
+}
+
+// parseClock turns "HH:MM" into an offset from midnight
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// LoadHolidays reads a holiday list from a JSON array or a CSV file with
+// date,description[,segments] columns, where segments are separated by "|"
+func LoadHolidays(path string) ([]Holiday, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return readHolidaysCSV(file)
+	}
+
+	var holidays []Holiday
+	if err := json.NewDecoder(file).Decode(&holidays); err != nil {
+		return nil, fmt.Errorf("decoding holidays: %w", err)
+	}
+	return holidays, nil
+}
+
+func readHolidaysCSV(r io.Reader) ([]Holiday, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	var holidays []Holiday
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("line %d: expected date and description", line)
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "date") {
+			continue // header
+		}
+
+		holiday := Holiday{
+			Date:        strings.TrimSpace(record[0]),
+			Description: strings.TrimSpace(record[1]),
+		}
+		if len(record) > 2 && strings.TrimSpace(record[2]) != "" {
+			for _, segment := range strings.Split(record[2], "|") {
+				holiday.Segments = append(holiday.Segments, models.Segment(strings.TrimSpace(segment)))
+			}
+		}
+		holidays = append(holidays, holiday)
+	}
+	return holidays, nil
+}
+
+// Holidays returns every configured holiday in date order
+func (c *Calendar) Holidays() []Holiday {
+	holidays := []Holiday{}
+	for _, list := range c.holidays {
+		holidays = append(holidays, list...)
+	}
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date < holidays[j].Date })
+	return holidays
+}
+
+// holiday returns the holiday closing segment on t's date, if any
+func (c *Calendar) holiday(segment models.Segment, t time.Time) (Holiday, bool) {
+	for _, h := range c.holidays[t.In(IST).Format(dateLayout)] {
+		if len(h.Segments) == 0 {
+			return h, true
+		}
+		for _, s := range h.Segments {
+			if s == segment {
+				return h, true
+			}
+		}
+	}
+	return Holiday{}, false
+}
+
+// IsTradingDay reports whether segment trades at all on t's date
+func (c *Calendar) IsTradingDay(segment models.Segment, t time.Time) bool {
+	local := t.In(IST)
+	if local.Weekday() == time.Saturday || local.Weekday() == time.Sunday {
+		return false
+	}
+	if len(c.sessions[segment]) == 0 {
+		return false
+	}
+	_, closed := c.holiday(segment, local)
+	return !closed
+}
+
+func midnight(t time.Time) time.Time {
+	local := t.In(IST)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, IST)
+}
+
+// Phase returns the session phase segment is in at t
+func (c *Calendar) Phase(segment models.Segment, t time.Time) SessionPhase {
+	if !c.IsTradingDay(segment, t) {
+		return PhaseClosed
+	}
+	offset := t.Sub(midnight(t))
+	for _, s := range c.sessions[segment] {
+		if offset >= s.start && offset < s.end {
+			return s.phase
+		}
+	}
+	return PhaseClosed
+}
+
+// IsOpen reports whether segment is in its normal trading session at t
+func (c *Calendar) IsOpen(segment models.Segment, t time.Time) bool {
+	return c.Phase(segment, t) == PhaseNormal
+}
+
+// NextOpen returns the start of the first normal session strictly after t
+func (c *Calendar) NextOpen(segment models.Segment, t time.Time) (time.Time, error) {
+	var open time.Duration
+	found := false
+	for _, s := range c.sessions[segment] {
+		if s.phase == PhaseNormal {
+			open, found = s.start, true
+			break
+		}
+	}
+	if !found {
+		return time.Time{}, fmt.Errorf("segment %s has no normal session", segment)
+	}
+
+	day := midnight(t)
+	for i := 0; i < 366; i++ {
+		candidate := day.AddDate(0, 0, i).Add(open)
+		if candidate.After(t) && c.IsTradingDay(segment, candidate) {
+			return candidate, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("segment %s has no trading day within a year", segment)
+}
+
+// Status describes segment's session at t
+func (c *Calendar) Status(segment models.Segment, t time.Time) MarketStatus {
+	status := MarketStatus{
+		Segment:    segment,
+		Time:       t.In(IST),
+		TradingDay: c.IsTradingDay(segment, t),
+		Phase:      c.Phase(segment, t),
+	}
+	if h, closed := c.holiday(segment, t); closed {
+		status.HolidayName = h.Description
+	}
+	if next, err := c.NextOpen(segment, t); err == nil {
+		status.NextOpen = next
+	}
+	return status
+}
+
+// Segments lists the segments that have sessions configured
+func (c *Calendar) Segments() []models.Segment {
+	segments := make([]models.Segment, 0, len(c.sessions))
+	for segment := range c.sessions {
+		segments = append(segments, segment)
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i] < segments[j] })
+	return segments
+}
@@ -0,0 +1,52 @@
+// Command replay rebuilds OMS state from journal files and prints it as JSON,
+// optionally stopping at a point in time to inspect how a session unfolded.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/journal"
+)
+
+func main() {
+	dir := flag.String("journal", "journal", "journal directory whose segments are replayed in order")
+	until := flag.String("until", "", "stop before the first record after this RFC3339 time")
+	flag.Parse()
+
+	var cutoff time.Time
+	if *until != "" {
+		var err error
+		cutoff, err = time.Parse(time.RFC3339, *until)
+		if err != nil {
+			log.Fatalf("Invalid -until: %v", err)
+		}
+	}
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		var err error
+		paths, err = journal.Segments(*dir)
+		if err != nil {
+			log.Fatalf("Listing journal segments: %v", err)
+		}
+	}
+	if len(paths) == 0 {
+		log.Fatalf("No journal files found in %s", *dir)
+	}
+
+	repo, sequence, err := journal.Rebuild(paths, cutoff)
+	if err != nil {
+		log.Fatalf("Replay stopped after record %d: %v", sequence, err)
+	}
+	log.Printf("Replayed %d records from %d files", sequence, len(paths))
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(repo.Snapshot()); err != nil {
+		log.Fatalf("Encoding state: %v", err)
+	}
+}
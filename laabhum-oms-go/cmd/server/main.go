@@ -1,83 +1,258 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
+	"flag"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 
-	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/api"
+	"github.com/Mukilan-T/laabhum-oms-go/audit"
+	"github.com/Mukilan-T/laabhum-oms-go/calendar"
+	"github.com/Mukilan-T/laabhum-oms-go/config"
+	"github.com/Mukilan-T/laabhum-oms-go/fix"
+	"github.com/Mukilan-T/laabhum-oms-go/instruments"
+	"github.com/Mukilan-T/laabhum-oms-go/journal"
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/kafka"
+	natsclient "github.com/Mukilan-T/laabhum-oms-go/pkg/nats"
 	"github.com/Mukilan-T/laabhum-oms-go/repository"
 	"github.com/Mukilan-T/laabhum-oms-go/service"
-	"github.com/gorilla/mux"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 func main() {
-	repo := repository.NewInMemoryOrderRepository()
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("Configuration: %v", err)
+	}
+
+	// Requests are logged at both levels; gin's own output only at debug
+	accessLog := log.Default()
+	gin.SetMode(gin.ReleaseMode)
+	if cfg.LogLevel == config.LevelDebug {
+		gin.SetMode(gin.DebugMode)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var repo repository.OrderRepository
+	if journalDir := cfg.Repository.Journal; journalDir != "" {
+		journaled, err := journal.Recover(journalDir, cfg.Repository.JournalSync)
+		if err != nil {
+			log.Fatalf("Recovering journal from %s: %v", journalDir, err)
+		}
+		defer journaled.Close()
+		go journaled.RunSnapshots(ctx, log.Default(), cfg.Repository.SnapshotInterval)
+		state := journaled.Snapshot()
+		log.Printf("Recovered %d orders and %d positions from journal %s", len(state.Orders), len(state.Positions), journalDir)
+		repo = journaled
+	} else {
+		repo, err = repository.Open(cfg.Repository.Backend, cfg.Repository.DSN)
+		if err != nil {
+			log.Fatalf("Opening %s repository: %v", cfg.Repository.Backend, err)
+		}
+	}
 	omsService := service.NewOMSService(repo)
+	omsService.Accounts().SetOpeningBalance(cfg.Risk.AccountBalance)
+	omsService.SetMarginRules(cfg.Margin())
+	omsService.SetIdempotencyWindow(cfg.Orders.IdempotencyWindow)
 
-	r := mux.NewRouter()
-	r.HandleFunc("/orders", ordersHandler(omsService)).Methods(http.MethodGet, http.MethodPost)
+	// Domain events are relayed to Kafka when brokers are configured and always
+	// to the local event stream followed by the gateway
+	outbox, hasOutbox := repo.(repository.Outbox)
+	brokers := cfg.Kafka.Brokers
+	if len(brokers) > 0 && !hasOutbox {
+		log.Fatalf("The %s repository has no outbox to relay events from", cfg.Repository.Backend)
+	}
+	if hasOutbox {
+		var producer kafka.Sender
+		switch {
+		case len(brokers) == 0:
+		case len(brokers) == 1 && brokers[0] == "memory":
+			producer = kafka.NewMemoryBroker(3)
+		default:
+			syncProducer, err := kafka.SetupProducer(brokers)
+			if err != nil {
+				log.Fatalf("Connecting to Kafka: %v", err)
+			}
+			defer syncProducer.Close()
+			producer = syncProducer
+		}
+		relay := kafka.NewRelay(log.Default(), outbox, producer, cfg.Kafka.OutboxInterval)
+		relay.SetLocal(omsService.Events())
+		relay.SetMaxAttempts(cfg.Kafka.OutboxMaxAttempts)
+		relayDone := make(chan struct{})
+		go func() {
+			defer close(relayDone)
+			relay.Run(ctx)
+		}()
+		// Stop the relay before the producer it sends with is closed
+		defer func() {
+			cancel()
+			<-relayDone
+		}()
+	}
 
-	server := &http.Server{
-		Addr:    ":8081",
-		Handler: r,
+	if cfg.Repository.AuditLog != "" {
+		trail, err := audit.OpenTrail(cfg.Repository.AuditLog)
+		if err != nil {
+			log.Fatalf("Opening audit log: %v", err)
+		}
+		defer trail.Close()
+		omsService.SetAuditTrail(trail)
 	}
 
-	go func() {
-		log.Println("Server started at :8081")
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("ListenAndServe: %v", err)
+	var holidays []calendar.Holiday
+	if path := cfg.Sessions.Holidays; path != "" {
+		holidays, err = calendar.LoadHolidays(path)
+		if err != nil {
+			log.Fatalf("Loading holidays: %v", err)
 		}
-	}()
+		log.Printf("Loaded %d exchange holidays from %s", len(holidays), path)
+	}
+	cal, err := calendar.New(cfg.Sessions.Segments, holidays)
+	if err != nil {
+		log.Fatalf("Trading calendar: %v", err)
+	}
+	omsService.SetCalendar(cal)
 
-	// Graceful shutdown
-	ch := make(chan os.Signal, 1)
-	signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
-	<-ch
+	if path := cfg.Instruments; path != "" {
+		master, err := instruments.Load(path)
+		if err != nil {
+			log.Fatalf("Loading instruments: %v", err)
+		}
+		omsService.SetInstruments(master)
+		log.Printf("Loaded %d instruments from %s", len(master.List()), path)
+	}
 
-	log.Println("Shutting down server...")
-	if err := server.Close(); err != nil {
-		log.Fatalf("Server close error: %v", err)
+	// Event streams end when shutdown starts instead of holding the drain open
+	// until it times out; other requests are left to finish
+	stopStreams := make(chan struct{})
+	apiOptions := api.Options{
+		AcceptTicks:  cfg.Market.AcceptTicks,
+		ServiceToken: cfg.Auth.ServiceToken,
+		StopStreams:  stopStreams,
+	}
+	if cfg.Auth.ServiceToken == "" {
+		log.Printf("No service token: callers cannot name the user and account they act for, so orders are placed anonymously")
 	}
-}
 
-func ordersHandler(omsService *service.OMSService) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodGet {
-			filter := repository.OrderFilter{}
-			orders, err := omsService.GetOrders(filter)
-			if err != nil {
-				http.Error(w, "Error retrieving orders: "+err.Error(), http.StatusInternalServerError)
-				return
-			}
-			response, err := json.Marshal(orders)
-			if err != nil {
-				http.Error(w, "Error marshalling response: "+err.Error(), http.StatusInternalServerError)
-				return
-			}
-			w.Header().Set("Content-Type", "application/json")
-			w.Write(response)
-		} else if r.Method == http.MethodPost {
-			var order models.Order
-			if err := json.NewDecoder(r.Body).Decode(&order); err != nil {
-				http.Error(w, "Invalid order data: "+err.Error(), http.StatusBadRequest)
-				return
-			}
-			createdOrder, err := omsService.CreateOrder(order)
+	if natsURL := cfg.NATS.URL; natsURL != "" {
+		client, err := natsclient.Connect(natsURL)
+		if err != nil {
+			log.Fatalf("Connecting to NATS: %v", err)
+		}
+		defer client.Drain()
+		natsServer := api.NewNATSServer(log.Default(), omsService, client, apiOptions)
+		if err := natsServer.Start(); err != nil {
+			log.Fatalf("Subscribing to NATS order commands: %v", err)
+		}
+		defer natsServer.Stop()
+		log.Printf("Serving order commands over NATS at %s", natsURL)
+	}
+
+	if grpcAddress := cfg.GRPC.Address; grpcAddress != "" {
+		listener, err := net.Listen("tcp", grpcAddress)
+		if err != nil {
+			log.Fatalf("Listening for gRPC on %s: %v", grpcAddress, err)
+		}
+		grpcAPI := api.NewGRPCServer(log.Default(), omsService, apiOptions)
+		serverOptions := grpcAPI.ServerOptions()
+		scheme := "plaintext"
+		if cfg.GRPC.TLSCert != "" {
+			creds, err := credentials.NewServerTLSFromFile(cfg.GRPC.TLSCert, cfg.GRPC.TLSKey)
 			if err != nil {
-				http.Error(w, "Error creating order: "+err.Error(), http.StatusInternalServerError)
-				return
+				log.Fatalf("Loading the gRPC TLS certificate: %v", err)
 			}
-			response, err := json.Marshal(createdOrder)
-			if err != nil {
-				http.Error(w, "Error marshalling response: "+err.Error(), http.StatusInternalServerError)
-				return
+			serverOptions = append(serverOptions, grpc.Creds(creds))
+			scheme = "TLS"
+		}
+		grpcServer := grpc.NewServer(serverOptions...)
+		grpcAPI.Register(grpcServer)
+		go func() {
+			log.Printf("Serving the OMS gRPC API at %s over %s", grpcAddress, scheme)
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatalf("gRPC Serve: %v", err)
 			}
-			w.Header().Set("Content-Type", "application/json")
-			w.Write(response)
+		}()
+		defer grpcServer.GracefulStop()
+	}
+
+	if cfg.FIX.Address != "" {
+		acceptor, err := fix.NewAcceptor(log.Default(), omsService, fix.Config{
+			Address:      cfg.FIX.Address,
+			SenderCompID: cfg.FIX.CompID,
+			Clients:      cfg.FIX.Clients,
+			StoreDir:     cfg.FIX.StoreDir,
+		})
+		if err != nil {
+			log.Fatalf("FIX acceptor: %v", err)
+		}
+		if err := acceptor.Start(); err != nil {
+			log.Fatalf("Listening for FIX on %s: %v", cfg.FIX.Address, err)
+		}
+		defer acceptor.Stop()
+		log.Printf("Accepting FIX sessions for %s at %s", cfg.FIX.CompID, acceptor.Addr())
+	}
+
+	squareOff, err := service.NewSquareOffScheduler(log.Default(), omsService, service.SquareOffConfig{
+		Cutoff:      cfg.Sessions.SquareOff,
+		WarningLead: cfg.Sessions.SquareOffWarning,
+	})
+	if err != nil {
+		log.Fatalf("Square-off scheduler: %v", err)
+	}
+	go squareOff.Run(ctx)
+	go service.NewAMOReleaser(log.Default(), omsService).Run(ctx)
+
+	expiry, err := service.NewExpiryScheduler(log.Default(), omsService, service.ExpiryConfig{
+		RunAt:  cfg.Sessions.ExpiryRun,
+		Action: cfg.Sessions.ExpiryAction,
+	})
+	if err != nil {
+		log.Fatalf("Expiry scheduler: %v", err)
+	}
+	go expiry.Run(ctx)
+
+	router := api.SetupRoutes(log.Default(), omsService, accessLog, apiOptions)
+
+	listener, err := net.Listen("tcp", cfg.HTTP.Address)
+	if err != nil {
+		log.Fatalf("Listening for HTTP on %s: %v", cfg.HTTP.Address, err)
+	}
+	server := &http.Server{Handler: router}
+	server.RegisterOnShutdown(func() { close(stopStreams) })
+
+	go func() {
+		log.Printf("Serving the OMS HTTP API at %s", listener.Addr())
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("HTTP Serve: %v", err)
 		}
+	}()
+
+	// Graceful shutdown
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
+	<-ch
+
+	shutdownTimeout := cfg.HTTP.ShutdownTimeout
+	log.Printf("Shutting down server, draining HTTP requests for up to %s...", shutdownTimeout)
+	drain, cancelDrain := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelDrain()
+	if err := server.Shutdown(drain); err != nil {
+		log.Printf("HTTP requests still running after %s, closing them: %v", shutdownTimeout, err)
+		server.Close()
 	}
+	cancel()
 }
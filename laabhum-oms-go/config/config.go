@@ -0,0 +1,518 @@
+// Package config loads the OMS configuration. Every setting has a default,
+// which a YAML file overrides, then OMS_* environment variables, then command
+// line flags. The result is validated as a whole so every mistake is
+// reported at startup rather than the first one.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/calendar"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/kafka"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+	"github.com/Mukilan-T/laabhum-oms-go/service"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPath is the file read when no -config flag or OMS_CONFIG is given.
+// Unlike an explicit path, it may be missing.
+const DefaultPath = "oms.yaml"
+
+// Log levels, from most to least verbose
+const (
+	LevelDebug = "debug" // gin debug output and request logs
+	LevelInfo  = "info"  // request logs
+)
+
+// Config is everything the OMS server is started with
+type Config struct {
+	LogLevel string `yaml:"log_level"`
+	HTTP     struct {
+		Address         string        `yaml:"address"`
+		ShutdownTimeout time.Duration `yaml:"shutdown_timeout"` // How long in-flight requests get to finish
+	} `yaml:"http"`
+	GRPC struct {
+		Address string `yaml:"address"` // Disabled when empty
+		TLSCert string `yaml:"tls_cert"`
+		TLSKey  string `yaml:"tls_key"`
+	} `yaml:"grpc"`
+	Auth struct {
+		ServiceToken string `yaml:"service_token"` // Bearer token the gateway calls the OMS with
+	} `yaml:"auth"`
+	Repository struct {
+		Backend          string        `yaml:"backend"` // memory or sqlite
+		DSN              string        `yaml:"dsn"`
+		Journal          string        `yaml:"journal"` // Directory replayed on startup, memory backend only
+		JournalSync      bool          `yaml:"journal_sync"`
+		SnapshotInterval time.Duration `yaml:"snapshot_interval"`
+		AuditLog         string        `yaml:"audit_log"`
+	} `yaml:"repository"`
+	Kafka struct {
+		Brokers           []string      `yaml:"brokers"` // ["memory"] for an in-process broker
+		OutboxInterval    time.Duration `yaml:"outbox_interval"`
+		OutboxMaxAttempts int           `yaml:"outbox_max_attempts"` // Refusals before an event is dead-lettered
+	} `yaml:"kafka"`
+	NATS struct {
+		URL string `yaml:"url"`
+	} `yaml:"nats"`
+	FIX struct {
+		Address  string            `yaml:"address"` // Disabled when empty
+		CompID   string            `yaml:"comp_id"`
+		Clients  map[string]string `yaml:"clients"`   // Password of each CompID allowed to log on
+		StoreDir string            `yaml:"store_dir"` // In memory when empty, which loses sequence numbers on restart
+	} `yaml:"fix"`
+	Orders struct {
+		IdempotencyWindow time.Duration `yaml:"idempotency_window"` // How long a resubmitted order returns the original; later ones place a new order
+	} `yaml:"orders"`
+	Market struct {
+		AcceptTicks bool `yaml:"accept_ticks"` // Let callers post prices that mark positions to market
+	} `yaml:"market"`
+	Risk struct {
+		AccountBalance float64                        `yaml:"account_balance"` // Cash each account opens with
+		Margin         map[models.ProductType]float64 `yaml:"margin"`          // Share of order value blocked per product
+	} `yaml:"risk"`
+	Sessions struct {
+		Segments         map[models.Segment][]calendar.Session `yaml:"segments"`
+		Holidays         string                                `yaml:"holidays"` // Holiday list file, JSON or CSV
+		SquareOff        string                                `yaml:"square_off"`
+		SquareOffWarning time.Duration                         `yaml:"square_off_warning"`
+		ExpiryRun        string                                `yaml:"expiry_run"`
+		ExpiryAction     service.ExpiryAction                  `yaml:"expiry_action"`
+	} `yaml:"sessions"`
+	Instruments string `yaml:"instruments"` // Instrument master dump, JSON or CSV
+}
+
+// Default returns the configuration the OMS runs with when nothing is set
+func Default() Config {
+	var c Config
+	c.LogLevel = LevelInfo
+	c.HTTP.Address = ":8081"
+	c.HTTP.ShutdownTimeout = 15 * time.Second
+	c.GRPC.Address = "127.0.0.1:9091"
+	c.Repository.Backend = repository.BackendMemory
+	c.Repository.DSN = "oms.db"
+	c.Repository.JournalSync = true
+	c.Repository.SnapshotInterval = 5 * time.Minute
+	c.Kafka.OutboxInterval = time.Second
+	c.Kafka.OutboxMaxAttempts = kafka.DefaultMaxAttempts
+	c.FIX.CompID = "LAABHUM"
+	c.FIX.StoreDir = "fix"
+	c.Orders.IdempotencyWindow = service.DefaultIdempotencyWindow
+	c.Risk.AccountBalance = service.DefaultAccountBalance
+	c.Risk.Margin = make(map[models.ProductType]float64)
+	for product, rule := range service.DefaultMarginRules {
+		c.Risk.Margin[product] = rule.Fraction
+	}
+	// Copied so a config file never edits the package defaults
+	c.Sessions.Segments = make(map[models.Segment][]calendar.Session, len(calendar.DefaultSessions))
+	for segment, sessions := range calendar.DefaultSessions {
+		c.Sessions.Segments[segment] = append([]calendar.Session(nil), sessions...)
+	}
+	c.Sessions.SquareOff = service.DefaultSquareOffConfig.Cutoff
+	c.Sessions.SquareOffWarning = service.DefaultSquareOffConfig.WarningLead
+	c.Sessions.ExpiryRun = service.DefaultExpiryConfig.RunAt
+	c.Sessions.ExpiryAction = service.DefaultExpiryConfig.Action
+	return c
+}
+
+// Load builds the configuration from the defaults, the YAML file, the
+// environment and args, in that order, and validates it
+func Load(args []string) (*Config, error) {
+	flags := flag.NewFlagSet("oms", flag.ContinueOnError)
+	path := flags.String("config", "", "YAML configuration file (default "+DefaultPath+" when present; env OMS_CONFIG)")
+	// Flags parse into a scratch config and are applied last, once the file
+	// and environment have been layered over the defaults
+	scratch := Default()
+	for _, s := range settings {
+		flags.Var(s.field(&scratch), s.name, s.usage+" (env "+s.env()+")")
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	c := Default()
+	explicit := true
+	if *path == "" {
+		*path = os.Getenv("OMS_CONFIG")
+	}
+	if *path == "" {
+		*path, explicit = DefaultPath, false
+	}
+	if err := c.readFile(*path, explicit); err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, s := range settings {
+		if value, ok := os.LookupEnv(s.env()); ok {
+			if err := s.field(&c).Set(value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", s.env(), err))
+			}
+		}
+	}
+	flags.Visit(func(f *flag.Flag) {
+		if s, ok := settingsByName[f.Name]; ok {
+			// The flag already parsed, so its value sets cleanly
+			_ = s.field(&c).Set(f.Value.String())
+		}
+	})
+	if err := c.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return &c, nil
+}
+
+// readFile layers the YAML file at path over c. A missing file is only an
+// error when the path was given explicitly.
+func (c *Config) readFile(path string, explicit bool) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("opening config file: %w", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("decoding config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate reports every invalid setting
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(name string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	switch c.LogLevel {
+	case LevelDebug, LevelInfo:
+	default:
+		check("log_level", fmt.Errorf("unknown level %q, want debug or info", c.LogLevel))
+	}
+
+	check("http.address", address(c.HTTP.Address, true))
+	if c.HTTP.ShutdownTimeout <= 0 {
+		check("http.shutdown_timeout", errors.New("must be positive"))
+	}
+	check("grpc.address", address(c.GRPC.Address, false))
+	if (c.GRPC.TLSCert == "") != (c.GRPC.TLSKey == "") {
+		check("grpc.tls_cert", errors.New("tls_cert and tls_key must be set together"))
+	}
+	// Off the loopback interface the gRPC API is reachable by other hosts,
+	// so callers must authenticate over TLS
+	if c.GRPC.Address != "" && !loopback(c.GRPC.Address) {
+		if c.Auth.ServiceToken == "" {
+			check("auth.service_token", fmt.Errorf("required to serve gRPC on %s", c.GRPC.Address))
+		}
+		if c.GRPC.TLSCert == "" {
+			check("grpc.tls_cert", fmt.Errorf("required to serve gRPC on %s", c.GRPC.Address))
+		}
+	}
+
+	switch c.Repository.Backend {
+	case repository.BackendMemory:
+	case repository.BackendSQLite:
+		if c.Repository.DSN == "" {
+			check("repository.dsn", errors.New("required by the sqlite backend"))
+		}
+	default:
+		check("repository.backend", fmt.Errorf("unknown backend %q, want memory or sqlite", c.Repository.Backend))
+	}
+	if c.Repository.Journal != "" && c.Repository.Backend != repository.BackendMemory {
+		check("repository.journal", fmt.Errorf("requires the memory backend, not %s", c.Repository.Backend))
+	}
+	if c.Repository.SnapshotInterval <= 0 {
+		check("repository.snapshot_interval", errors.New("must be positive"))
+	}
+
+	if !(len(c.Kafka.Brokers) == 1 && c.Kafka.Brokers[0] == "memory") {
+		for _, broker := range c.Kafka.Brokers {
+			check("kafka.brokers", address(broker, true))
+		}
+	}
+	if c.Kafka.OutboxInterval <= 0 {
+		check("kafka.outbox_interval", errors.New("must be positive"))
+	}
+	if c.Kafka.OutboxMaxAttempts <= 0 {
+		check("kafka.outbox_max_attempts", errors.New("must be positive"))
+	}
+	if c.NATS.URL != "" {
+		if u, err := url.Parse(c.NATS.URL); err != nil {
+			check("nats.url", err)
+		} else if u.Scheme != "nats" && u.Scheme != "tls" {
+			check("nats.url", fmt.Errorf("scheme %q is not nats or tls", u.Scheme))
+		}
+	}
+
+	check("fix.address", address(c.FIX.Address, false))
+	if c.FIX.Address != "" && c.FIX.CompID == "" {
+		check("fix.comp_id", errors.New("required when FIX is enabled"))
+	}
+	if c.FIX.Address != "" && len(c.FIX.Clients) == 0 {
+		check("fix.clients", errors.New("required when FIX is enabled"))
+	}
+	for compID, password := range c.FIX.Clients {
+		if compID == "" || password == "" {
+			check("fix.clients", fmt.Errorf("client %q needs a CompID and a password", compID))
+		}
+	}
+
+	if c.Orders.IdempotencyWindow <= 0 {
+		check("orders.idempotency_window", errors.New("must be positive"))
+	}
+
+	if c.Risk.AccountBalance <= 0 {
+		check("risk.account_balance", errors.New("must be positive"))
+	}
+	for _, product := range []models.ProductType{models.ProductMIS, models.ProductCNC, models.ProductNRML} {
+		if _, ok := c.Risk.Margin[product]; !ok {
+			check("risk.margin", fmt.Errorf("no margin for %s", product))
+		}
+	}
+	for product, fraction := range c.Risk.Margin {
+		if !product.IsValid() {
+			check("risk.margin", fmt.Errorf("unknown product %q", product))
+		}
+		if fraction <= 0 || fraction > 1 {
+			check("risk.margin", fmt.Errorf("%s margin %v is not in (0, 1]", product, fraction))
+		}
+	}
+
+	for segment := range c.Sessions.Segments {
+		if !segment.IsValid() {
+			check("sessions.segments", fmt.Errorf("unknown segment %q", segment))
+		}
+	}
+	_, err := calendar.New(c.Sessions.Segments, nil)
+	check("sessions.segments", err)
+	check("sessions.square_off", clock(c.Sessions.SquareOff))
+	if c.Sessions.SquareOffWarning < 0 {
+		check("sessions.square_off_warning", errors.New("must not be negative"))
+	}
+	check("sessions.expiry_run", clock(c.Sessions.ExpiryRun))
+	switch c.Sessions.ExpiryAction {
+	case service.ExpiryClose, service.ExpiryFlag:
+	default:
+		check("sessions.expiry_action", fmt.Errorf("unknown action %q, want close or flag", c.Sessions.ExpiryAction))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Margin returns the risk margins as the service's margin rules
+func (c *Config) Margin() map[models.ProductType]service.MarginRule {
+	rules := make(map[models.ProductType]service.MarginRule, len(c.Risk.Margin))
+	for product, fraction := range c.Risk.Margin {
+		rules[product] = service.MarginRule{Fraction: fraction}
+	}
+	return rules
+}
+
+// address checks a host:port listen or dial address, which may be left
+// empty when the listener it configures is optional
+func address(value string, required bool) error {
+	if value == "" {
+		if required {
+			return errors.New("required")
+		}
+		return nil
+	}
+	if _, _, err := net.SplitHostPort(value); err != nil {
+		return err
+	}
+	return nil
+}
+
+// loopback reports whether a listen address only accepts local connections
+func loopback(value string) bool {
+	host, _, err := net.SplitHostPort(value)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// clock checks an "HH:MM" time of day
+func clock(value string) error {
+	if _, err := time.Parse("15:04", value); err != nil {
+		return fmt.Errorf("invalid time of day %q, want HH:MM", value)
+	}
+	return nil
+}
+
+// setting is a configuration value that can also be given as a flag and an
+// environment variable
+type setting struct {
+	name  string // Flag name; the environment variable is OMS_ and the name in upper snake case
+	usage string
+	field func(c *Config) flag.Value
+}
+
+func (s setting) env() string {
+	return "OMS_" + strings.ToUpper(strings.ReplaceAll(s.name, "-", "_"))
+}
+
+var settings = []setting{
+	{"log-level", "debug or info", func(c *Config) flag.Value { return (*stringValue)(&c.LogLevel) }},
+	{"http", "address to serve the OMS HTTP API on", func(c *Config) flag.Value { return (*stringValue)(&c.HTTP.Address) }},
+	{"shutdown-timeout", "how long in-flight HTTP requests are given to finish on shutdown", func(c *Config) flag.Value { return (*durationValue)(&c.HTTP.ShutdownTimeout) }},
+	{"grpc", "address to serve the OMS gRPC API on (disabled when empty)", func(c *Config) flag.Value { return (*stringValue)(&c.GRPC.Address) }},
+	{"grpc-tls-cert", "PEM certificate the gRPC API is served with over TLS", func(c *Config) flag.Value { return (*stringValue)(&c.GRPC.TLSCert) }},
+	{"grpc-tls-key", "PEM private key of the gRPC certificate", func(c *Config) flag.Value { return (*stringValue)(&c.GRPC.TLSKey) }},
+	{"service-token", "bearer token gRPC callers must present, and any caller naming a user", func(c *Config) flag.Value { return (*stringValue)(&c.Auth.ServiceToken) }},
+	{"repository", "order repository backend (memory or sqlite)", func(c *Config) flag.Value { return (*stringValue)(&c.Repository.Backend) }},
+	{"dsn", "database path for the sqlite repository", func(c *Config) flag.Value { return (*stringValue)(&c.Repository.DSN) }},
+	{"journal", "directory for the order journal; replayed on startup (memory repository only)", func(c *Config) flag.Value { return (*stringValue)(&c.Repository.Journal) }},
+	{"journal-sync", "fsync the journal after every record", func(c *Config) flag.Value { return (*boolValue)(&c.Repository.JournalSync) }},
+	{"snapshot-interval", "how often to snapshot journaled state", func(c *Config) flag.Value { return (*durationValue)(&c.Repository.SnapshotInterval) }},
+	{"audit-log", "file the order audit trail is appended to and reloaded from", func(c *Config) flag.Value { return (*stringValue)(&c.Repository.AuditLog) }},
+	{"kafka-brokers", "comma-separated Kafka brokers to relay domain events to, or \"memory\" for an in-process broker", func(c *Config) flag.Value { return (*listValue)(&c.Kafka.Brokers) }},
+	{"outbox-interval", "how often the outbox is relayed to Kafka", func(c *Config) flag.Value { return (*durationValue)(&c.Kafka.OutboxInterval) }},
+	{"outbox-max-attempts", "how often Kafka may refuse an event before it is dead-lettered", func(c *Config) flag.Value { return (*intValue)(&c.Kafka.OutboxMaxAttempts) }},
+	{"nats", "NATS server URL to serve order commands on (disabled when empty)", func(c *Config) flag.Value { return (*stringValue)(&c.NATS.URL) }},
+	{"fix", "address to accept FIX 4.4 order-entry sessions on (disabled when empty)", func(c *Config) flag.Value { return (*stringValue)(&c.FIX.Address) }},
+	{"fix-comp-id", "SenderCompID of the OMS on FIX sessions", func(c *Config) flag.Value { return (*stringValue)(&c.FIX.CompID) }},
+	{"fix-clients", "comma-separated COMPID=password pairs of the clients allowed to log on over FIX", func(c *Config) flag.Value { return (*mapValue)(&c.FIX.Clients) }},
+	{"fix-store", "directory FIX sequence numbers, sent messages and client order IDs are kept in (memory when empty)", func(c *Config) flag.Value { return (*stringValue)(&c.FIX.StoreDir) }},
+	{"idempotency-window", "how long a resubmission under the same idempotency key or client order ID returns the original order", func(c *Config) flag.Value { return (*durationValue)(&c.Orders.IdempotencyWindow) }},
+	{"accept-ticks", "accept market price ticks over HTTP and gRPC", func(c *Config) flag.Value { return (*boolValue)(&c.Market.AcceptTicks) }},
+	{"account-balance", "capital each account opens with for margin", func(c *Config) flag.Value { return (*floatValue)(&c.Risk.AccountBalance) }},
+	{"holidays", "path to an exchange holiday list (JSON or CSV)", func(c *Config) flag.Value { return (*stringValue)(&c.Sessions.Holidays) }},
+	{"square-off", "time of day in IST intraday positions are squared off", func(c *Config) flag.Value { return (*stringValue)(&c.Sessions.SquareOff) }},
+	{"square-off-warning", "how long before the square-off to warn", func(c *Config) flag.Value { return (*durationValue)(&c.Sessions.SquareOffWarning) }},
+	{"expiry-run", "time of day in IST expiring positions are handled", func(c *Config) flag.Value { return (*stringValue)(&c.Sessions.ExpiryRun) }},
+	{"expiry-action", "what to do with expiring positions (close or flag)", func(c *Config) flag.Value { return (*stringValue)((*string)(&c.Sessions.ExpiryAction)) }},
+	{"instruments", "path to an instrument master dump (JSON or CSV)", func(c *Config) flag.Value { return (*stringValue)(&c.Instruments) }},
+}
+
+var settingsByName = func() map[string]setting {
+	byName := make(map[string]setting, len(settings))
+	for _, s := range settings {
+		byName[s.name] = s
+	}
+	return byName
+}()
+
+// flag.Value adapters pointing into a Config
+
+type stringValue string
+
+func (v *stringValue) String() string     { return string(*v) }
+func (v *stringValue) Set(s string) error { *v = stringValue(s); return nil }
+
+type boolValue bool
+
+func (v *boolValue) String() string   { return fmt.Sprint(bool(*v)) }
+func (v *boolValue) IsBoolFlag() bool { return true }
+func (v *boolValue) Set(s string) error {
+	switch strings.ToLower(s) {
+	case "1", "t", "true", "yes", "on":
+		*v = true
+	case "0", "f", "false", "no", "off":
+		*v = false
+	default:
+		return fmt.Errorf("invalid boolean %q", s)
+	}
+	return nil
+}
+
+type durationValue time.Duration
+
+func (v *durationValue) String() string { return time.Duration(*v).String() }
+func (v *durationValue) Set(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*v = durationValue(d)
+	return nil
+}
+
+type intValue int
+
+func (v *intValue) String() string { return fmt.Sprint(int(*v)) }
+func (v *intValue) Set(s string) error {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("invalid integer %q", s)
+	}
+	*v = intValue(i)
+	return nil
+}
+
+type floatValue float64
+
+func (v *floatValue) String() string { return fmt.Sprint(float64(*v)) }
+func (v *floatValue) Set(s string) error {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %q", s)
+	}
+	*v = floatValue(f)
+	return nil
+}
+
+// listValue is a comma-separated list; an empty string clears it
+type listValue []string
+
+func (v *listValue) String() string { return strings.Join(*v, ",") }
+func (v *listValue) Set(s string) error {
+	*v = nil
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*v = append(*v, item)
+		}
+	}
+	return nil
+}
+
+type mapValue map[string]string
+
+func (v *mapValue) String() string {
+	pairs := make([]string, 0, len(*v))
+	for key := range *v {
+		// Values are secrets and left out
+		pairs = append(pairs, key+"=...")
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+func (v *mapValue) Set(s string) error {
+	*v = make(map[string]string)
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return fmt.Errorf("invalid pair %q, want KEY=VALUE", item)
+		}
+		(*v)[strings.TrimSpace(key)] = value
+	}
+	return nil
+}
@@ -0,0 +1,295 @@
+package fix
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/events"
+	"github.com/Mukilan-T/laabhum-oms-go/service"
+)
+
+// logonTimeout is how long a new connection has to send its Logon
+const logonTimeout = 10 * time.Second
+
+// Config configures an Acceptor
+type Config struct {
+	// Address the acceptor listens on
+	Address string
+	// SenderCompID is the OMS's CompID; clients send it as their TargetCompID
+	SenderCompID string
+	// Clients maps the CompID of each client allowed to log on to the
+	// Password (tag 554) its Logon must carry
+	Clients map[string]string
+	// StoreDir keeps sequence numbers, sent messages and client order IDs
+	// across restarts; empty keeps them in memory, losing them on restart
+	StoreDir string
+}
+
+// Acceptor accepts FIX 4.4 order-entry sessions and maps their orders onto
+// the OMS. Each client CompID has at most one session at a time. Execution
+// reports for fills and cancels made elsewhere in the OMS are sent to the
+// session that placed the order, or kept for resending if it is offline.
+type Acceptor struct {
+	logger     *log.Logger
+	omsService *service.OMSService
+	config     Config
+	orders     *orderBook
+	listener   net.Listener
+
+	mutex    sync.Mutex
+	stores   map[SessionID]Store
+	sessions map[SessionID]*Session
+
+	wg   sync.WaitGroup
+	done chan struct{}
+}
+
+func NewAcceptor(logger *log.Logger, omsService *service.OMSService, config Config) (*Acceptor, error) {
+	if config.SenderCompID == "" {
+		return nil, errors.New("FIX acceptor needs a SenderCompID")
+	}
+	if len(config.Clients) == 0 {
+		return nil, errors.New("FIX acceptor needs at least one client")
+	}
+	for compID, password := range config.Clients {
+		if password == "" {
+			return nil, fmt.Errorf("FIX client %s has no password", compID)
+		}
+	}
+	orders, err := openOrderBook(config.StoreDir)
+	if err != nil {
+		return nil, err
+	}
+	return &Acceptor{
+		logger:     logger,
+		omsService: omsService,
+		config:     config,
+		orders:     orders,
+		stores:     make(map[SessionID]Store),
+		sessions:   make(map[SessionID]*Session),
+		done:       make(chan struct{}),
+	}, nil
+}
+
+// Start listens for sessions and follows the OMS event stream for fills
+func (a *Acceptor) Start() error {
+	listener, err := net.Listen("tcp", a.config.Address)
+	if err != nil {
+		return err
+	}
+	a.listener = listener
+
+	a.wg.Add(2)
+	go a.accept()
+	go a.followEvents()
+	return nil
+}
+
+// Addr returns the address the acceptor is listening on
+func (a *Acceptor) Addr() net.Addr {
+	return a.listener.Addr()
+}
+
+// Stop logs out every session, stops listening and closes the stores
+func (a *Acceptor) Stop() error {
+	close(a.done)
+	err := a.listener.Close()
+
+	a.mutex.Lock()
+	sessions := make([]*Session, 0, len(a.sessions))
+	for _, session := range a.sessions {
+		sessions = append(sessions, session)
+	}
+	a.mutex.Unlock()
+	for _, session := range sessions {
+		session.Logout("OMS shutting down")
+	}
+	a.wg.Wait()
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	for _, store := range a.stores {
+		if closeErr := store.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	if closeErr := a.orders.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (a *Acceptor) accept() {
+	defer a.wg.Done()
+	for {
+		conn, err := a.listener.Accept()
+		if err != nil {
+			select {
+			case <-a.done:
+			default:
+				a.logger.Printf("FIX acceptor: %v", err)
+			}
+			return
+		}
+		a.wg.Add(1)
+		go func() {
+			defer a.wg.Done()
+			a.serve(conn)
+		}()
+	}
+}
+
+// serve waits for a Logon, then runs the session it opens
+func (a *Acceptor) serve(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	conn.SetReadDeadline(time.Now().Add(logonTimeout))
+	msg, err := readLogon(reader)
+	if err != nil {
+		a.logger.Printf("FIX acceptor: %s: %v", conn.RemoteAddr(), err)
+		conn.Close()
+		return
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	id := SessionID{SenderCompID: msg.String(TagTargetCompID), TargetCompID: msg.String(TagSenderCompID)}
+	if id.SenderCompID != a.config.SenderCompID || !a.allowed(id.TargetCompID, msg.String(TagPassword)) {
+		a.logger.Printf("FIX acceptor: %s: refusing logon from %q to %q", conn.RemoteAddr(), id.TargetCompID, id.SenderCompID)
+		conn.Close()
+		return
+	}
+
+	session, err := a.open(conn, reader, id)
+	if err != nil {
+		a.logger.Printf("FIX acceptor: %s: %v", id, err)
+		conn.Close()
+		return
+	}
+	defer a.closed(session)
+
+	if err := session.logon(msg); err != nil {
+		a.logger.Printf("FIX %s: logon failed: %v", id, err)
+		return
+	}
+	if err := session.run(); !ended(err) {
+		a.logger.Printf("FIX %s: %v", id, err)
+	}
+	a.logger.Printf("FIX %s: session ended", id)
+}
+
+func readLogon(reader *bufio.Reader) (*Message, error) {
+	raw, err := ReadMessage(reader)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Type() != MsgLogon {
+		return nil, fmt.Errorf("first message is %q, not Logon", msg.Type())
+	}
+	return msg, nil
+}
+
+// allowed reports whether targetCompID is a client logging on with its password
+func (a *Acceptor) allowed(targetCompID, password string) bool {
+	want, ok := a.config.Clients[targetCompID]
+	return ok && subtle.ConstantTimeCompare([]byte(password), []byte(want)) == 1
+}
+
+// open registers a session for id, refusing a second concurrent one
+func (a *Acceptor) open(conn net.Conn, reader *bufio.Reader, id SessionID) (*Session, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if _, active := a.sessions[id]; active {
+		return nil, errors.New("already logged on")
+	}
+	store, err := a.store(id)
+	if err != nil {
+		return nil, err
+	}
+	session := newSession(a.logger, conn, reader, id, store, a.handle)
+	a.sessions[id] = session
+	return session, nil
+}
+
+func (a *Acceptor) closed(session *Session) {
+	session.Close()
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.sessions[session.ID] == session {
+		delete(a.sessions, session.ID)
+	}
+}
+
+// store returns the store of id, opening it on first use. Callers hold a.mutex.
+func (a *Acceptor) store(id SessionID) (Store, error) {
+	if store, ok := a.stores[id]; ok {
+		return store, nil
+	}
+	var store Store = NewMemoryStore()
+	if a.config.StoreDir != "" {
+		fileStore, err := OpenFileStore(a.config.StoreDir, id)
+		if err != nil {
+			return nil, err
+		}
+		store = fileStore
+	}
+	a.stores[id] = store
+	return store, nil
+}
+
+// deliver sends msg on the session id, or records it for resending when the
+// client next logs on and finds the gap
+func (a *Acceptor) deliver(id SessionID, msg *Message) error {
+	a.mutex.Lock()
+	session := a.sessions[id]
+	if session == nil {
+		defer a.mutex.Unlock()
+		store, err := a.store(id)
+		if err != nil {
+			return err
+		}
+		_, err = prepare(store, id, msg)
+		return err
+	}
+	a.mutex.Unlock()
+	return session.Send(msg)
+}
+
+// followEvents reports fills and cancels of FIX orders made outside their session
+func (a *Acceptor) followEvents() {
+	defer a.wg.Done()
+	bus, ok := a.omsService.Events().(events.Stream)
+	if !ok {
+		a.logger.Printf("FIX acceptor: no event stream, fills will not be reported")
+		return
+	}
+	ch := bus.Subscribe(256)
+	defer bus.Unsubscribe(ch)
+
+	for {
+		select {
+		case <-a.done:
+			return
+		case event, ok := <-ch:
+			if !ok {
+				return
+			}
+			domain, ok := event.Data.(events.DomainEvent)
+			if !ok {
+				continue
+			}
+			switch domain.Type {
+			case events.TradeExecuted, events.OrderCancelled:
+				a.reportEvent(domain)
+			}
+		}
+	}
+}
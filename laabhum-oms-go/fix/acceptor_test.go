@@ -0,0 +1,239 @@
+package fix
+
+import (
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/calendar"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/kafka"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+	"github.com/Mukilan-T/laabhum-oms-go/service"
+)
+
+const (
+	omsCompID    = "LAABHUM"
+	clientCompID = "ALGO1"
+	password     = "s3cret"
+)
+
+var discard = log.New(io.Discard, "", 0)
+
+// clientID is the session as the client sees it
+var clientID = SessionID{SenderCompID: clientCompID, TargetCompID: omsCompID}
+
+// startAcceptor accepts FIX sessions for a fresh OMS on a local port. The
+// relay publishes the OMS events the acceptor reports unsolicited fills and
+// cancels from.
+func startAcceptor(t *testing.T) (*Acceptor, *service.OMSService, *kafka.Relay) {
+	t.Helper()
+	repo := repository.NewInMemoryOrderRepository()
+	omsService := service.NewOMSService(repo)
+	// A Monday mid-session, so orders are placed rather than rejected or queued
+	omsService.SetClock(func() time.Time { return time.Date(2026, 10, 19, 11, 0, 0, 0, calendar.IST) })
+	relay := kafka.NewRelay(discard, repo, kafka.NewMemoryBroker(1), time.Second)
+	relay.SetLocal(omsService.Events())
+
+	acceptor, err := NewAcceptor(discard, omsService, Config{
+		Address:      "127.0.0.1:0",
+		SenderCompID: omsCompID,
+		Clients:      map[string]string{clientCompID: password},
+		StoreDir:     t.TempDir(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := acceptor.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { acceptor.Stop() })
+	return acceptor, omsService, relay
+}
+
+func dial(t *testing.T, acceptor *Acceptor, store Store, reset bool) *Initiator {
+	t.Helper()
+	initiator, err := Dial(discard, acceptor.Addr().String(), clientID, password, store, time.Second, reset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(initiator.Close)
+	return initiator
+}
+
+func newOrderSingle(clOrdID, ordType string) *Message {
+	return NewMessage(MsgNewOrderSingle).
+		Set(TagClOrdID, clOrdID).
+		Set(TagSymbol, "NSE:INFY").
+		Set(TagSide, "1").
+		Set(TagOrdType, ordType).
+		SetInt(TagOrderQty, 5).
+		SetFloat(TagPrice, 100).
+		SetTime(TagTransactTime, time.Now())
+}
+
+// receive waits for the next execution report and checks its ExecType and OrdStatus
+func receive(t *testing.T, initiator *Initiator, execType, ordStatus string) *Message {
+	t.Helper()
+	msg, err := initiator.Receive(2 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Type() != MsgExecutionReport || msg.String(TagExecType) != execType || msg.String(TagOrdStatus) != ordStatus {
+		t.Fatalf("got %s with ExecType %q and OrdStatus %q, want an execution report with %q and %q",
+			msg.Type(), msg.String(TagExecType), msg.String(TagOrdStatus), execType, ordStatus)
+	}
+	return msg
+}
+
+func TestNewAcceptorNeedsClients(t *testing.T) {
+	omsService := service.NewOMSService(repository.NewInMemoryOrderRepository())
+	for name, clients := range map[string]map[string]string{
+		"no clients":  nil,
+		"no password": {clientCompID: ""},
+	} {
+		if _, err := NewAcceptor(discard, omsService, Config{SenderCompID: omsCompID, Clients: clients}); err == nil {
+			t.Errorf("%s: acceptor created", name)
+		}
+	}
+}
+
+func TestLogonChecksCredentials(t *testing.T) {
+	acceptor, _, _ := startAcceptor(t)
+
+	tests := []struct {
+		name     string
+		id       SessionID
+		password string
+		ok       bool
+	}{
+		{"wrong password", clientID, "guess", false},
+		{"no password", clientID, "", false},
+		{"unknown client", SessionID{SenderCompID: "ALGO2", TargetCompID: omsCompID}, password, false},
+		{"another acceptor", SessionID{SenderCompID: clientCompID, TargetCompID: "OTHER"}, password, false},
+		{"client", clientID, password, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			initiator, err := Dial(discard, acceptor.Addr().String(), tc.id, tc.password, NewMemoryStore(), time.Second, true)
+			if tc.ok != (err == nil) {
+				t.Fatalf("logon: %v, want success %v", err, tc.ok)
+			}
+			if initiator != nil {
+				initiator.Close()
+			}
+		})
+	}
+}
+
+func TestNewOrderSingle(t *testing.T) {
+	acceptor, omsService, _ := startAcceptor(t)
+	initiator := dial(t, acceptor, NewMemoryStore(), true)
+
+	if err := initiator.Send(newOrderSingle("limit-1", "2")); err != nil {
+		t.Fatal(err)
+	}
+	ack := receive(t, initiator, ExecNew, StatusNew)
+	if ack.String(TagClOrdID) != "limit-1" || ack.String(TagLeavesQty) != "5" || ack.String(TagCumQty) != "0" {
+		t.Errorf("limit order acknowledged with ClOrdID %q, LeavesQty %q and CumQty %q",
+			ack.String(TagClOrdID), ack.String(TagLeavesQty), ack.String(TagCumQty))
+	}
+	order, err := omsService.GetOrder(ack.String(TagOrderID))
+	if err != nil || order.Status != models.OrderStatusPending || order.Quantity != 5 {
+		t.Errorf("stored order %+v, %v, want a pending order for 5", order, err)
+	}
+
+	// A market order fills at once: acknowledged as new, then traded
+	if err := initiator.Send(newOrderSingle("market-1", "1")); err != nil {
+		t.Fatal(err)
+	}
+	ack = receive(t, initiator, ExecNew, StatusNew)
+	fill := receive(t, initiator, ExecTrade, StatusFilled)
+	if fill.String(TagOrderID) != ack.String(TagOrderID) || fill.String(TagLastQty) != "5" || fill.String(TagCumQty) != "5" || fill.String(TagLeavesQty) != "0" {
+		t.Errorf("fill of %s: OrderID %q, LastQty %q, CumQty %q, LeavesQty %q", ack.String(TagOrderID),
+			fill.String(TagOrderID), fill.String(TagLastQty), fill.String(TagCumQty), fill.String(TagLeavesQty))
+	}
+
+	if err := initiator.Send(newOrderSingle("limit-1", "2")); err != nil {
+		t.Fatal(err)
+	}
+	if reject := receive(t, initiator, ExecRejected, StatusRejected); reject.String(TagOrdRejReason) != "6" {
+		t.Errorf("duplicate ClOrdID rejected with reason %q, want 6", reject.String(TagOrdRejReason))
+	}
+}
+
+func TestReportsMissedWhileOfflineAreResent(t *testing.T) {
+	acceptor, omsService, relay := startAcceptor(t)
+	store := NewMemoryStore()
+	initiator := dial(t, acceptor, store, true)
+
+	if err := initiator.Send(newOrderSingle("limit-1", "2")); err != nil {
+		t.Fatal(err)
+	}
+	orderID := receive(t, initiator, ExecNew, StatusNew).String(TagOrderID)
+	initiator.Close()
+	waitFor(t, "the session to end", func() bool {
+		acceptor.mutex.Lock()
+		defer acceptor.mutex.Unlock()
+		return len(acceptor.sessions) == 0
+	})
+
+	// Cancelled elsewhere in the OMS while the client is offline
+	if err := omsService.CancelOrder(orderID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := relay.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	sent := store.NextTargetSeqNum()
+	waitFor(t, "the cancel to be stored for resending", func() bool {
+		acceptor.mutex.Lock()
+		defer acceptor.mutex.Unlock()
+		return acceptor.stores[SessionID{SenderCompID: omsCompID, TargetCompID: clientCompID}].NextSenderSeqNum() > sent
+	})
+
+	// Logging on again without a reset finds the gap and has it resent
+	initiator = dial(t, acceptor, store, false)
+	cancel := receive(t, initiator, ExecCanceled, StatusCanceled)
+	if cancel.String(TagOrderID) != orderID || cancel.String(TagClOrdID) != "limit-1" || !cancel.Bool(TagPossDupFlag) {
+		t.Errorf("resent cancel has OrderID %q, ClOrdID %q and PossDupFlag %q", cancel.String(TagOrderID),
+			cancel.String(TagClOrdID), cancel.String(TagPossDupFlag))
+	}
+}
+
+func TestReportClaimsArePruned(t *testing.T) {
+	book, err := openOrderBook("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !book.report("order-1", models.OrderStatusExecuted) {
+		t.Fatal("first report not claimed")
+	}
+	if book.report("order-1", models.OrderStatusExecuted) {
+		t.Fatal("second report of the same fill claimed")
+	}
+	if len(book.reported) != 0 {
+		t.Errorf("%d claims kept after both observers reported", len(book.reported))
+	}
+
+	// A claim nobody else finds is dropped once it is older than claimTTL
+	book.report("order-2", models.OrderStatusCancelled)
+	book.reported["order-2"] = claim{status: models.OrderStatusCancelled, at: time.Now().Add(-2 * claimTTL)}
+	book.pruned = time.Time{}
+	book.report("order-3", models.OrderStatusCancelled)
+	if _, ok := book.reported["order-2"]; ok || len(book.reported) != 1 {
+		t.Errorf("claims after pruning: %v, want only order-3", book.reported)
+	}
+}
+
+func waitFor(t *testing.T, what string, done func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !done() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
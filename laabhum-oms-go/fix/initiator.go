@@ -0,0 +1,94 @@
+package fix
+
+import (
+	"bufio"
+	"errors"
+	"log"
+	"net"
+	"time"
+)
+
+// Initiator is the client end of a session, used by order-entry clients and
+// to exercise the acceptor in-process
+type Initiator struct {
+	*Session
+	messages chan *Message
+}
+
+// Dial connects to a FIX acceptor and logs on as id.SenderCompID with
+// password. With reset both sides start their sequence numbers over;
+// otherwise the session carries on from the numbers in store and any gap is resent.
+func Dial(logger *log.Logger, address string, id SessionID, password string, store Store, heartbeat time.Duration, reset bool) (*Initiator, error) {
+	if heartbeat < time.Second {
+		return nil, errors.New("heartbeat must be at least a second")
+	}
+	if reset {
+		if err := store.Reset(); err != nil {
+			return nil, err
+		}
+	}
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &Initiator{messages: make(chan *Message, 256)}
+	i.Session = newSession(logger, conn, bufio.NewReader(conn), id, store, i.receive)
+	i.initiator = true
+	i.heartbeat = heartbeat
+
+	logon := NewMessage(MsgLogon).
+		SetInt(TagEncryptMethod, 0).
+		SetInt(TagHeartBtInt, int(heartbeat/time.Second)).
+		Set(TagUsername, id.SenderCompID).
+		Set(TagPassword, password)
+	if reset {
+		logon.SetBool(TagResetSeqNumFlag, true)
+	}
+	if err := i.Send(logon); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	go func() {
+		defer close(i.messages)
+		if err := i.run(); !ended(err) {
+			logger.Printf("FIX %s: %v", id, err)
+		}
+	}()
+
+	select {
+	case <-i.LoggedOn():
+		return i, nil
+	case <-i.Done():
+		return nil, errors.New("acceptor ended the session during logon")
+	case <-time.After(logonTimeout):
+		i.Close()
+		return nil, errors.New("timed out waiting for Logon")
+	}
+}
+
+func (i *Initiator) receive(s *Session, msg *Message) {
+	select {
+	case i.messages <- msg:
+	case <-s.Done():
+	}
+}
+
+// Messages delivers the application messages received, and is closed when
+// the session ends
+func (i *Initiator) Messages() <-chan *Message {
+	return i.messages
+}
+
+// Receive waits up to timeout for the next application message
+func (i *Initiator) Receive(timeout time.Duration) (*Message, error) {
+	select {
+	case msg, ok := <-i.messages:
+		if !ok {
+			return nil, errors.New("session ended")
+		}
+		return msg, nil
+	case <-time.After(timeout):
+		return nil, errors.New("timed out waiting for a message")
+	}
+}
@@ -0,0 +1,353 @@
+// Package fix implements a FIX 4.4 order-entry acceptor for the OMS and a
+// matching initiator. Sessions keep their sequence numbers and sent messages
+// in a Store, so both survive a restart and gaps can be resent on request.
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// BeginString is the FIX version spoken by this package
+const BeginString = "FIX.4.4"
+
+const (
+	soh = '\x01'
+	// maxBodyLength bounds the messages a counterparty can make us buffer
+	maxBodyLength = 64 << 10
+	timeFormat    = "20060102-15:04:05.000"
+)
+
+// Tags used by the session and order-entry messages
+const (
+	TagAccount             = 1
+	TagAvgPx               = 6
+	TagBeginSeqNo          = 7
+	TagBeginString         = 8
+	TagBodyLength          = 9
+	TagCheckSum            = 10
+	TagClOrdID             = 11
+	TagCumQty              = 14
+	TagEndSeqNo            = 16
+	TagExecID              = 17
+	TagLastPx              = 31
+	TagLastQty             = 32
+	TagMsgSeqNum           = 34
+	TagMsgType             = 35
+	TagNewSeqNo            = 36
+	TagOrderID             = 37
+	TagOrderQty            = 38
+	TagOrdStatus           = 39
+	TagOrdType             = 40
+	TagOrigClOrdID         = 41
+	TagPossDupFlag         = 43
+	TagPrice               = 44
+	TagRefSeqNum           = 45
+	TagSenderCompID        = 49
+	TagSendingTime         = 52
+	TagSide                = 54
+	TagSymbol              = 55
+	TagTargetCompID        = 56
+	TagText                = 58
+	TagTimeInForce         = 59
+	TagTransactTime        = 60
+	TagEncryptMethod       = 98
+	TagStopPx              = 99
+	TagCxlRejReason        = 102
+	TagOrdRejReason        = 103
+	TagHeartBtInt          = 108
+	TagTestReqID           = 112
+	TagOrigSendingTime     = 122
+	TagGapFillFlag         = 123
+	TagResetSeqNumFlag     = 141
+	TagExecType            = 150
+	TagLeavesQty           = 151
+	TagRefMsgType          = 372
+	TagSessionRejectReason = 373
+	TagCxlRejResponseTo    = 434
+	TagUsername            = 553
+	TagPassword            = 554
+)
+
+// Message types
+const (
+	MsgHeartbeat                 = "0"
+	MsgTestRequest               = "1"
+	MsgResendRequest             = "2"
+	MsgReject                    = "3"
+	MsgSequenceReset             = "4"
+	MsgLogout                    = "5"
+	MsgExecutionReport           = "8"
+	MsgOrderCancelReject         = "9"
+	MsgLogon                     = "A"
+	MsgNewOrderSingle            = "D"
+	MsgOrderCancelRequest        = "F"
+	MsgOrderCancelReplaceRequest = "G"
+)
+
+// headerTags are written straight after MsgType, in this order
+var headerTags = []int{TagSenderCompID, TagTargetCompID, TagMsgSeqNum, TagPossDupFlag, TagSendingTime, TagOrigSendingTime}
+
+// ErrGarbled means a message failed its framing, body length or checksum
+// checks. Garbled messages are ignored without consuming a sequence number.
+var ErrGarbled = errors.New("garbled FIX message")
+
+// Field is one tag=value pair
+type Field struct {
+	Tag   int
+	Value string
+}
+
+// Message is a FIX message as an ordered list of fields. BeginString,
+// BodyLength and CheckSum are computed when the message is encoded.
+type Message struct {
+	Fields []Field
+}
+
+// NewMessage starts a message of msgType
+func NewMessage(msgType string) *Message {
+	return (&Message{}).Set(TagMsgType, msgType)
+}
+
+// Set replaces the value of tag, adding the field if it is missing
+func (m *Message) Set(tag int, value string) *Message {
+	for i := range m.Fields {
+		if m.Fields[i].Tag == tag {
+			m.Fields[i].Value = value
+			return m
+		}
+	}
+	m.Fields = append(m.Fields, Field{Tag: tag, Value: value})
+	return m
+}
+
+func (m *Message) SetInt(tag, value int) *Message {
+	return m.Set(tag, strconv.Itoa(value))
+}
+
+func (m *Message) SetFloat(tag int, value float64) *Message {
+	return m.Set(tag, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+func (m *Message) SetBool(tag int, value bool) *Message {
+	if value {
+		return m.Set(tag, "Y")
+	}
+	return m.Set(tag, "N")
+}
+
+func (m *Message) SetTime(tag int, value time.Time) *Message {
+	return m.Set(tag, value.UTC().Format(timeFormat))
+}
+
+// Remove deletes tag from the message
+func (m *Message) Remove(tag int) *Message {
+	fields := m.Fields[:0]
+	for _, field := range m.Fields {
+		if field.Tag != tag {
+			fields = append(fields, field)
+		}
+	}
+	m.Fields = fields
+	return m
+}
+
+// Get returns the value of tag and whether it is present
+func (m *Message) Get(tag int) (string, bool) {
+	for _, field := range m.Fields {
+		if field.Tag == tag {
+			return field.Value, true
+		}
+	}
+	return "", false
+}
+
+// String returns the value of tag, or "" when it is missing
+func (m *Message) String(tag int) string {
+	value, _ := m.Get(tag)
+	return value
+}
+
+// Int returns the value of tag as an integer
+func (m *Message) Int(tag int) (int, error) {
+	value, ok := m.Get(tag)
+	if !ok {
+		return 0, fmt.Errorf("required tag %d missing", tag)
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("tag %d: %q is not an integer", tag, value)
+	}
+	return parsed, nil
+}
+
+// Float returns the value of tag as a decimal
+func (m *Message) Float(tag int) (float64, error) {
+	value, ok := m.Get(tag)
+	if !ok {
+		return 0, fmt.Errorf("required tag %d missing", tag)
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("tag %d: %q is not a number", tag, value)
+	}
+	return parsed, nil
+}
+
+// Bool reports whether tag is present and set to Y
+func (m *Message) Bool(tag int) bool {
+	return m.String(tag) == "Y"
+}
+
+// Type returns the MsgType
+func (m *Message) Type() string {
+	return m.String(TagMsgType)
+}
+
+// SeqNum returns the MsgSeqNum, or 0 when it is missing or malformed
+func (m *Message) SeqNum() int {
+	seqNum, _ := m.Int(TagMsgSeqNum)
+	return seqNum
+}
+
+// isAdmin reports whether msgType is a session-level message. Admin messages
+// are never resent; a sequence reset fills their place instead.
+func isAdmin(msgType string) bool {
+	switch msgType {
+	case MsgHeartbeat, MsgTestRequest, MsgResendRequest, MsgReject, MsgSequenceReset, MsgLogout, MsgLogon:
+		return true
+	}
+	return false
+}
+
+// Bytes encodes the message with its BeginString, BodyLength and CheckSum
+func (m *Message) Bytes() []byte {
+	var body bytes.Buffer
+	writeField := func(tag int, value string) {
+		body.WriteString(strconv.Itoa(tag))
+		body.WriteByte('=')
+		body.WriteString(value)
+		body.WriteByte(soh)
+	}
+
+	written := map[int]bool{TagBeginString: true, TagBodyLength: true, TagCheckSum: true}
+	for _, tag := range append([]int{TagMsgType}, headerTags...) {
+		if value, ok := m.Get(tag); ok {
+			writeField(tag, value)
+		}
+		written[tag] = true
+	}
+	for _, field := range m.Fields {
+		if !written[field.Tag] {
+			writeField(field.Tag, field.Value)
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "8=%s%c9=%d%c", BeginString, soh, body.Len(), soh)
+	out.Write(body.Bytes())
+	fmt.Fprintf(&out, "10=%03d%c", checksum(out.Bytes()), soh)
+	return out.Bytes()
+}
+
+func checksum(data []byte) int {
+	var sum int
+	for _, b := range data {
+		sum += int(b)
+	}
+	return sum % 256
+}
+
+// Parse decodes a raw message, checking its BeginString, BodyLength and CheckSum
+func Parse(raw []byte) (*Message, error) {
+	if len(raw) == 0 || raw[len(raw)-1] != soh {
+		return nil, fmt.Errorf("%w: not terminated by SOH", ErrGarbled)
+	}
+	msg := &Message{}
+	bodyStart, checksumStart := -1, -1
+	for offset := 0; offset < len(raw); {
+		end := bytes.IndexByte(raw[offset:], soh) + offset
+		tagEnd := bytes.IndexByte(raw[offset:end], '=')
+		if tagEnd <= 0 {
+			return nil, fmt.Errorf("%w: malformed field at byte %d", ErrGarbled, offset)
+		}
+		tag, err := strconv.Atoi(string(raw[offset : offset+tagEnd]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: malformed tag at byte %d", ErrGarbled, offset)
+		}
+		if tag == TagCheckSum {
+			checksumStart = offset
+		}
+		msg.Fields = append(msg.Fields, Field{Tag: tag, Value: string(raw[offset+tagEnd+1 : end])})
+		offset = end + 1
+		if tag == TagBodyLength {
+			bodyStart = offset
+		}
+	}
+
+	if len(msg.Fields) < 4 || msg.Fields[0].Tag != TagBeginString || msg.Fields[1].Tag != TagBodyLength ||
+		msg.Fields[2].Tag != TagMsgType || msg.Fields[len(msg.Fields)-1].Tag != TagCheckSum {
+		return nil, fmt.Errorf("%w: header or trailer out of order", ErrGarbled)
+	}
+	if msg.Fields[0].Value != BeginString {
+		return nil, fmt.Errorf("%w: BeginString %q, expected %s", ErrGarbled, msg.Fields[0].Value, BeginString)
+	}
+	if length, err := strconv.Atoi(msg.Fields[1].Value); err != nil || length != checksumStart-bodyStart {
+		return nil, fmt.Errorf("%w: BodyLength %s does not match %d", ErrGarbled, msg.Fields[1].Value, checksumStart-bodyStart)
+	}
+	if sum := fmt.Sprintf("%03d", checksum(raw[:checksumStart])); sum != msg.Fields[len(msg.Fields)-1].Value {
+		return nil, fmt.Errorf("%w: CheckSum %s, computed %s", ErrGarbled, msg.Fields[len(msg.Fields)-1].Value, sum)
+	}
+	msg.Fields = msg.Fields[2 : len(msg.Fields)-1]
+	return msg, nil
+}
+
+// ReadMessage reads the next raw message from r, using its BodyLength to find
+// the end. Bytes before a BeginString are skipped.
+func ReadMessage(r *bufio.Reader) ([]byte, error) {
+	var begin []byte
+	for {
+		field, err := r.ReadSlice(soh)
+		if err != nil {
+			if errors.Is(err, bufio.ErrBufferFull) {
+				continue
+			}
+			return nil, err
+		}
+		if bytes.HasPrefix(field, []byte("8=")) {
+			begin = append([]byte(nil), field...)
+			break
+		}
+	}
+
+	lengthField, err := r.ReadSlice(soh)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(lengthField, []byte("9=")) {
+		return nil, fmt.Errorf("%w: BodyLength must follow BeginString", ErrGarbled)
+	}
+	length, err := strconv.Atoi(string(lengthField[2 : len(lengthField)-1]))
+	if err != nil || length <= 0 || length > maxBodyLength {
+		return nil, fmt.Errorf("%w: BodyLength %q", ErrGarbled, lengthField[2:len(lengthField)-1])
+	}
+
+	raw := make([]byte, 0, len(begin)+len(lengthField)+length+7)
+	raw = append(raw, begin...)
+	raw = append(raw, lengthField...)
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	raw = append(raw, body...)
+	trailer, err := r.ReadSlice(soh)
+	if err != nil {
+		return nil, err
+	}
+	return append(raw, trailer...), nil
+}
@@ -0,0 +1,579 @@
+package fix
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/audit"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/events"
+	"github.com/Mukilan-T/laabhum-oms-go/service"
+	"github.com/google/uuid"
+)
+
+// ExecType and OrdStatus values (tags 150 and 39)
+const (
+	ExecNew          = "0"
+	ExecCanceled     = "4"
+	ExecReplaced     = "5"
+	ExecRejected     = "8"
+	ExecPendingNew   = "A"
+	ExecTrade        = "F"
+	StatusNew        = "0"
+	StatusFilled     = "2"
+	StatusCanceled   = "4"
+	StatusRejected   = "8"
+	StatusPendingNew = "A"
+)
+
+// OrdRejReason and CxlRejReason values (tags 103 and 102)
+const (
+	OrdRejDuplicateOrder = 6
+	OrdRejOther          = 99
+	CxlRejTooLate        = 0
+	CxlRejUnknownOrder   = 1
+	CxlRejOther          = 99
+)
+
+// claimTTL is how long a claimed execution report is remembered for the other
+// observer of the change to find
+const claimTTL = 10 * time.Minute
+
+// claim is an execution report sent by the session or the event stream
+type claim struct {
+	status models.OrderStatus
+	at     time.Time
+}
+
+// bookEntry ties a client order ID on a session to the OMS order it placed
+type bookEntry struct {
+	Session SessionID `json:"session"`
+	ClOrdID string    `json:"cl_ord_id"`
+	OrderID string    `json:"order_id"`
+}
+
+// orderBook remembers which session placed each OMS order under which client
+// order IDs, so cancels can name an order by ClOrdID and fills made elsewhere
+// reach the right session. With a directory it is kept in orders.jsonl there.
+type orderBook struct {
+	mutex    sync.Mutex
+	byClient map[SessionID]map[string]string
+	byOrder  map[string]bookEntry
+	reported map[string]claim
+	pruned   time.Time
+	file     *os.File
+}
+
+func openOrderBook(dir string) (*orderBook, error) {
+	b := &orderBook{
+		byClient: make(map[SessionID]map[string]string),
+		byOrder:  make(map[string]bookEntry),
+		reported: make(map[string]claim),
+	}
+	if dir == "" {
+		return b, nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, "orders.jsonl")
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry bookEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// A line torn by a crash is the last one written
+			break
+		}
+		b.index(entry)
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	b.file = file
+	return b, nil
+}
+
+func (b *orderBook) index(entry bookEntry) {
+	clients, ok := b.byClient[entry.Session]
+	if !ok {
+		clients = make(map[string]string)
+		b.byClient[entry.Session] = clients
+	}
+	clients[entry.ClOrdID] = entry.OrderID
+	b.byOrder[entry.OrderID] = entry
+}
+
+// add records clOrdID as the latest client order ID of orderID
+func (b *orderBook) add(id SessionID, clOrdID, orderID string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	entry := bookEntry{Session: id, ClOrdID: clOrdID, OrderID: orderID}
+	if b.file != nil {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if _, err := b.file.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	b.index(entry)
+	return nil
+}
+
+// lookup returns the OMS order a session placed under clOrdID
+func (b *orderBook) lookup(id SessionID, clOrdID string) (string, bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	orderID, ok := b.byClient[id][clOrdID]
+	return orderID, ok
+}
+
+// owner returns the session and latest client order ID of orderID
+func (b *orderBook) owner(orderID string) (bookEntry, bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	entry, ok := b.byOrder[orderID]
+	return entry, ok
+}
+
+// report claims the execution report for orderID reaching status, so a fill
+// or cancel is reported once whether the session or the event stream sees it
+// first. The second to see it finds the claim and removes it; claims nobody
+// else finds are dropped after claimTTL.
+func (b *orderBook) report(orderID string, status models.OrderStatus) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	now := time.Now()
+	b.prune(now)
+	if claimed, ok := b.reported[orderID]; ok && claimed.status == status {
+		delete(b.reported, orderID)
+		return false
+	}
+	b.reported[orderID] = claim{status: status, at: now}
+	return true
+}
+
+// unclaim releases a claim made by report when the change did not happen
+func (b *orderBook) unclaim(orderID string, status models.OrderStatus) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.reported[orderID].status == status {
+		delete(b.reported, orderID)
+	}
+}
+
+// prune drops claims older than claimTTL, at most once per claimTTL. Callers
+// hold b.mutex.
+func (b *orderBook) prune(now time.Time) {
+	if now.Sub(b.pruned) < claimTTL {
+		return
+	}
+	b.pruned = now
+	for orderID, claimed := range b.reported {
+		if now.Sub(claimed.at) > claimTTL {
+			delete(b.reported, orderID)
+		}
+	}
+}
+
+func (b *orderBook) Close() error {
+	if b.file == nil {
+		return nil
+	}
+	return b.file.Close()
+}
+
+// handle maps the application messages of a session onto the OMS
+func (a *Acceptor) handle(s *Session, msg *Message) {
+	switch msg.Type() {
+	case MsgNewOrderSingle:
+		a.newOrderSingle(s, msg)
+	case MsgOrderCancelRequest:
+		a.cancelOrder(s, msg)
+	case MsgOrderCancelReplaceRequest:
+		a.replaceOrder(s, msg)
+	default:
+		s.reject(msg, RejectInvalidMsgType, fmt.Sprintf("MsgType %s is not supported", msg.Type()))
+	}
+}
+
+// service returns the OMS acting for the session, tagged with the client order ID
+func (a *Acceptor) service(s *Session, clOrdID string) *service.OMSService {
+	return a.omsService.As(audit.Source{
+		Actor:     audit.User(s.ID.TargetCompID),
+		RequestID: clOrdID,
+	})
+}
+
+func (a *Acceptor) newOrderSingle(s *Session, msg *Message) {
+	clOrdID := msg.String(TagClOrdID)
+	if clOrdID == "" {
+		s.reject(msg, RejectRequiredTagMissing, "ClOrdID is required")
+		return
+	}
+	if _, seen := a.orders.lookup(s.ID, clOrdID); seen {
+		// A resent order we already accepted needs no second answer
+		if msg.Bool(TagPossDupFlag) {
+			return
+		}
+		a.send(s, rejectReport(msg, OrdRejDuplicateOrder, "duplicate ClOrdID "+clOrdID))
+		return
+	}
+
+	order, err := orderFromMessage(msg)
+	if err == nil {
+		var created *models.Order
+		created, err = a.service(s, clOrdID).CreateOrder(order)
+		order = models.Order{}
+		if created != nil {
+			order = *created
+		}
+	}
+	if err != nil {
+		a.logger.Printf("FIX %s: order %s rejected: %v", s.ID, clOrdID, err)
+		a.send(s, rejectReport(msg, OrdRejOther, err.Error()))
+		return
+	}
+
+	// The fill is claimed before the order is recorded, so the trade event
+	// cannot report it ahead of the acknowledgement
+	filled := order.Status == models.OrderStatusExecuted && a.orders.report(order.ID, order.Status)
+	if err := a.orders.add(s.ID, clOrdID, order.ID); err != nil {
+		a.logger.Printf("FIX %s: recording order %s: %v", s.ID, clOrdID, err)
+	}
+	// The order is acknowledged as new, then filled in a Trade report of its own
+	ack, execType := order, ExecNew
+	switch order.Status {
+	case models.OrderStatusQueued:
+		execType = ExecPendingNew
+	case models.OrderStatusExecuted:
+		ack.Status = models.OrderStatusPending
+	}
+	a.send(s, executionReport(execType, ack, clOrdID, ""))
+	if filled {
+		a.send(s, fillReport(order, clOrdID))
+	}
+}
+
+func (a *Acceptor) cancelOrder(s *Session, msg *Message) {
+	clOrdID, origClOrdID := msg.String(TagClOrdID), msg.String(TagOrigClOrdID)
+	orderID, ok := a.resolve(s, msg)
+	if !ok {
+		a.send(s, cancelReject(msg, MsgOrderCancelRequest, "", StatusRejected, CxlRejUnknownOrder, "unknown order"))
+		return
+	}
+	svc := a.service(s, clOrdID)
+	order, err := svc.GetOrder(orderID)
+	if err != nil {
+		a.send(s, cancelReject(msg, MsgOrderCancelRequest, "", StatusRejected, CxlRejUnknownOrder, err.Error()))
+		return
+	}
+	if !cancellable(order.Status) {
+		a.send(s, cancelReject(msg, MsgOrderCancelRequest, orderID, ordStatus(order.Status), CxlRejTooLate, fmt.Sprintf("order is %s", order.Status)))
+		return
+	}
+
+	// Claimed first so the cancel event does not also produce an unsolicited report
+	claimed := a.orders.report(orderID, models.OrderStatusCancelled)
+	if err := svc.CancelOrder(orderID); err != nil {
+		if claimed {
+			a.orders.unclaim(orderID, models.OrderStatusCancelled)
+		}
+		a.send(s, cancelReject(msg, MsgOrderCancelRequest, orderID, ordStatus(order.Status), CxlRejOther, err.Error()))
+		return
+	}
+	if clOrdID != "" {
+		if err := a.orders.add(s.ID, clOrdID, orderID); err != nil {
+			a.logger.Printf("FIX %s: recording cancel %s: %v", s.ID, clOrdID, err)
+		}
+	}
+	order.Status = models.OrderStatusCancelled
+	a.send(s, executionReport(ExecCanceled, *order, clOrdID, origClOrdID))
+}
+
+func (a *Acceptor) replaceOrder(s *Session, msg *Message) {
+	clOrdID, origClOrdID := msg.String(TagClOrdID), msg.String(TagOrigClOrdID)
+	orderID, ok := a.resolve(s, msg)
+	if !ok {
+		a.send(s, cancelReject(msg, MsgOrderCancelReplaceRequest, "", StatusRejected, CxlRejUnknownOrder, "unknown order"))
+		return
+	}
+	if clOrdID == "" {
+		s.reject(msg, RejectRequiredTagMissing, "ClOrdID is required")
+		return
+	}
+
+	var changes models.Order
+	var err error
+	if _, present := msg.Get(TagOrderQty); present {
+		changes.Quantity, err = quantity(msg)
+	}
+	if _, present := msg.Get(TagPrice); present && err == nil {
+		changes.Price, err = msg.Float(TagPrice)
+	}
+	if _, present := msg.Get(TagStopPx); present && err == nil {
+		changes.StopPrice, err = msg.Float(TagStopPx)
+	}
+	var modified *models.Order
+	if err == nil {
+		modified, err = a.service(s, clOrdID).ModifyOrder(orderID, changes)
+	}
+	if err != nil {
+		reason, status := CxlRejOther, StatusRejected
+		if order, getErr := a.omsService.GetOrder(orderID); getErr == nil {
+			status = ordStatus(order.Status)
+			if !cancellable(order.Status) {
+				reason = CxlRejTooLate
+			}
+		}
+		a.send(s, cancelReject(msg, MsgOrderCancelReplaceRequest, orderID, status, reason, err.Error()))
+		return
+	}
+	if err := a.orders.add(s.ID, clOrdID, orderID); err != nil {
+		a.logger.Printf("FIX %s: recording replace %s: %v", s.ID, clOrdID, err)
+	}
+	a.send(s, executionReport(ExecReplaced, *modified, clOrdID, origClOrdID))
+}
+
+// resolve finds the order a cancel or replace refers to, by OrderID or by
+// OrigClOrdID, as long as the session placed it
+func (a *Acceptor) resolve(s *Session, msg *Message) (string, bool) {
+	if orderID := msg.String(TagOrderID); orderID != "" && orderID != "NONE" {
+		entry, ok := a.orders.owner(orderID)
+		return orderID, ok && entry.Session == s.ID
+	}
+	return a.orders.lookup(s.ID, msg.String(TagOrigClOrdID))
+}
+
+func (a *Acceptor) send(s *Session, msg *Message) {
+	if err := s.Send(msg); err != nil {
+		a.logger.Printf("FIX %s: sending %s: %v", s.ID, msg.Type(), err)
+	}
+}
+
+// reportEvent sends unsolicited fills and cancels of FIX orders to the
+// session that placed them
+func (a *Acceptor) reportEvent(event events.DomainEvent) {
+	var orderID string
+	var status models.OrderStatus
+	switch event.Type {
+	case events.TradeExecuted:
+		var trade events.TradePayload
+		if err := json.Unmarshal(event.Data, &trade); err != nil {
+			return
+		}
+		orderID, status = trade.OrderID, models.OrderStatusExecuted
+	case events.OrderCancelled:
+		var payload events.OrderPayload
+		if err := json.Unmarshal(event.Data, &payload); err != nil {
+			return
+		}
+		orderID, status = payload.OrderID, models.OrderStatusCancelled
+	}
+
+	entry, ok := a.orders.owner(orderID)
+	if !ok || !a.orders.report(orderID, status) {
+		return
+	}
+	order, err := a.omsService.GetOrder(orderID)
+	if err != nil {
+		a.logger.Printf("FIX %s: reporting %s of %s: %v", entry.Session, event.Type, orderID, err)
+		return
+	}
+	order.Status = status
+	report := executionReport(ExecCanceled, *order, entry.ClOrdID, "")
+	if status == models.OrderStatusExecuted {
+		report = fillReport(*order, entry.ClOrdID)
+	}
+	if err := a.deliver(entry.Session, report); err != nil {
+		a.logger.Printf("FIX %s: reporting %s of %s: %v", entry.Session, event.Type, orderID, err)
+	}
+}
+
+// orderFromMessage reads the order of a NewOrderSingle
+func orderFromMessage(msg *Message) (models.Order, error) {
+	var order models.Order
+	order.Symbol = msg.String(TagSymbol)
+	if order.Symbol == "" {
+		return order, errors.New("Symbol is required")
+	}
+	switch msg.String(TagSide) {
+	case "1":
+		order.Side = models.SideBuy
+	case "2":
+		order.Side = models.SideSell
+	default:
+		return order, fmt.Errorf("unsupported Side %q", msg.String(TagSide))
+	}
+	switch msg.String(TagOrdType) {
+	case "1":
+		order.Type = models.MarketOrder
+	case "2":
+		order.Type = models.LimitOrder
+	case "3", "4":
+		order.Type = models.StopOrder
+	default:
+		return order, fmt.Errorf("unsupported OrdType %q", msg.String(TagOrdType))
+	}
+
+	// At the Opening queues the order as an AMO until its segment opens
+	switch msg.String(TagTimeInForce) {
+	case "", "0":
+	case "2":
+		order.AMO = true
+	default:
+		return order, fmt.Errorf("unsupported TimeInForce %q", msg.String(TagTimeInForce))
+	}
+
+	var err error
+	if order.Quantity, err = quantity(msg); err != nil {
+		return order, err
+	}
+	if order.Price, err = msg.Float(TagPrice); err != nil {
+		return order, err
+	}
+	if _, present := msg.Get(TagStopPx); present {
+		if order.StopPrice, err = msg.Float(TagStopPx); err != nil {
+			return order, err
+		}
+	}
+	return order, nil
+}
+
+// quantity reads OrderQty, which the OMS only takes in whole units
+func quantity(msg *Message) (int, error) {
+	qty, err := msg.Float(TagOrderQty)
+	if err != nil {
+		return 0, err
+	}
+	if qty != math.Trunc(qty) || qty > math.MaxInt32 {
+		return 0, fmt.Errorf("OrderQty %s is not a whole number of units", msg.String(TagOrderQty))
+	}
+	return int(qty), nil
+}
+
+// cancellable reports whether an order in status can still be cancelled or replaced
+func cancellable(status models.OrderStatus) bool {
+	switch status {
+	case models.OrderStatusExecuted, models.OrderStatusCancelled, models.OrderStatusDeleted, models.OrderStatusRejected:
+		return false
+	}
+	return true
+}
+
+func ordStatus(status models.OrderStatus) string {
+	switch status {
+	case models.OrderStatusQueued:
+		return StatusPendingNew
+	case models.OrderStatusExecuted:
+		return StatusFilled
+	case models.OrderStatusCancelled, models.OrderStatusDeleted:
+		return StatusCanceled
+	case models.OrderStatusRejected:
+		return StatusRejected
+	}
+	return StatusNew
+}
+
+func side(side models.Side) string {
+	if side == models.SideSell {
+		return "2"
+	}
+	return "1"
+}
+
+// executionReport describes order after a change of execType. Orders fill
+// in full, so the quantities are either all open or all done.
+func executionReport(execType string, order models.Order, clOrdID, origClOrdID string) *Message {
+	msg := NewMessage(MsgExecutionReport).
+		Set(TagOrderID, order.ID).
+		Set(TagExecID, uuid.NewString()).
+		Set(TagExecType, execType).
+		Set(TagOrdStatus, ordStatus(order.Status)).
+		Set(TagSymbol, order.Symbol).
+		Set(TagSide, side(order.Side)).
+		SetInt(TagOrderQty, order.Quantity).
+		SetFloat(TagPrice, order.Price).
+		SetTime(TagTransactTime, time.Now())
+	if clOrdID != "" {
+		msg.Set(TagClOrdID, clOrdID)
+	}
+	if origClOrdID != "" {
+		msg.Set(TagOrigClOrdID, origClOrdID)
+	}
+	if order.StopPrice > 0 {
+		msg.SetFloat(TagStopPx, order.StopPrice)
+	}
+	switch order.Status {
+	case models.OrderStatusExecuted:
+		msg.SetInt(TagCumQty, order.Quantity).SetInt(TagLeavesQty, 0).SetFloat(TagAvgPx, order.Price)
+	case models.OrderStatusCancelled, models.OrderStatusDeleted, models.OrderStatusRejected:
+		msg.SetInt(TagCumQty, 0).SetInt(TagLeavesQty, 0).SetFloat(TagAvgPx, 0)
+	default:
+		msg.SetInt(TagCumQty, 0).SetInt(TagLeavesQty, order.Quantity).SetFloat(TagAvgPx, 0)
+	}
+	return msg
+}
+
+// fillReport reports the single fill of an executed order
+func fillReport(order models.Order, clOrdID string) *Message {
+	return executionReport(ExecTrade, order, clOrdID, "").
+		SetInt(TagLastQty, order.Quantity).
+		SetFloat(TagLastPx, order.Price)
+}
+
+// rejectReport refuses a NewOrderSingle the OMS did not accept
+func rejectReport(msg *Message, reason int, text string) *Message {
+	report := NewMessage(MsgExecutionReport).
+		Set(TagOrderID, "NONE").
+		Set(TagExecID, uuid.NewString()).
+		Set(TagClOrdID, msg.String(TagClOrdID)).
+		Set(TagExecType, ExecRejected).
+		Set(TagOrdStatus, StatusRejected).
+		SetInt(TagOrdRejReason, reason).
+		Set(TagSymbol, msg.String(TagSymbol)).
+		Set(TagSide, msg.String(TagSide)).
+		SetInt(TagCumQty, 0).
+		SetInt(TagLeavesQty, 0).
+		SetFloat(TagAvgPx, 0).
+		SetTime(TagTransactTime, time.Now()).
+		Set(TagText, text)
+	if qty, ok := msg.Get(TagOrderQty); ok {
+		report.Set(TagOrderQty, qty)
+	}
+	return report
+}
+
+// cancelReject refuses an OrderCancelRequest or OrderCancelReplaceRequest.
+// status is the order's current OrdStatus; an unknown order has OrderID
+// NONE and status Rejected.
+func cancelReject(msg *Message, responseTo, orderID, status string, reason int, text string) *Message {
+	if orderID == "" {
+		orderID = "NONE"
+	}
+	responseCode := "1"
+	if responseTo == MsgOrderCancelReplaceRequest {
+		responseCode = "2"
+	}
+	return NewMessage(MsgOrderCancelReject).
+		Set(TagOrderID, orderID).
+		Set(TagClOrdID, msg.String(TagClOrdID)).
+		Set(TagOrigClOrdID, msg.String(TagOrigClOrdID)).
+		Set(TagOrdStatus, status).
+		Set(TagCxlRejResponseTo, responseCode).
+		SetInt(TagCxlRejReason, reason).
+		Set(TagText, text)
+}
@@ -0,0 +1,493 @@
+package fix
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Session reject reasons (tag 373)
+const (
+	RejectRequiredTagMissing = 1
+	RejectValueIncorrect     = 5
+	RejectCompIDProblem      = 9
+	RejectInvalidMsgType     = 11
+)
+
+const (
+	writeTimeout  = 10 * time.Second
+	logoutTimeout = 2 * time.Second
+)
+
+// errLoggedOut ends a session that logged out cleanly
+var errLoggedOut = errors.New("logged out")
+
+// SessionID names a session by the CompIDs of its two ends, seen from our side
+type SessionID struct {
+	SenderCompID string
+	TargetCompID string
+}
+
+func (id SessionID) String() string {
+	return id.SenderCompID + "-" + id.TargetCompID
+}
+
+// Handler receives the application messages of a logged-on session
+type Handler func(s *Session, msg *Message)
+
+// Session runs the FIX session protocol over one connection: logon,
+// heartbeats and test requests, sequence number checks, resend requests and
+// gap fills, and logout. Application messages are passed to its Handler.
+type Session struct {
+	ID        SessionID
+	logger    *log.Logger
+	conn      net.Conn
+	reader    *bufio.Reader
+	store     Store
+	heartbeat time.Duration
+	handler   Handler
+	initiator bool
+
+	// mutex serialises writes, so resent messages are not interleaved with new ones
+	mutex      sync.Mutex
+	lastSent   time.Time
+	loggingOut bool
+
+	received        atomic.Int64
+	testRequestSent atomic.Bool
+	// resendTarget is the highest sequence number seen while a resend we
+	// requested is outstanding; only the read loop touches it
+	resendTarget int
+
+	loggedOn  chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newSession(logger *log.Logger, conn net.Conn, reader *bufio.Reader, id SessionID, store Store, handler Handler) *Session {
+	s := &Session{
+		ID:       id,
+		logger:   logger,
+		conn:     conn,
+		reader:   reader,
+		store:    store,
+		handler:  handler,
+		lastSent: time.Now(),
+		loggedOn: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	s.received.Store(time.Now().UnixNano())
+	return s
+}
+
+// Send stamps msg with the session header and the next sequence number and
+// writes it. Application messages are kept in the store for resending.
+func (s *Session) Send(msg *Message) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	raw, err := prepare(s.store, s.ID, msg)
+	if err != nil {
+		return err
+	}
+	return s.write(raw)
+}
+
+// prepare stamps msg with the next sequence number of store and records it
+// there, returning the encoded message
+func prepare(store Store, id SessionID, msg *Message) ([]byte, error) {
+	seqNum := store.NextSenderSeqNum()
+	raw := stamp(msg, id, seqNum).Bytes()
+	if !isAdmin(msg.Type()) {
+		if err := store.SaveMessage(seqNum, raw); err != nil {
+			return nil, err
+		}
+	}
+	if err := store.SetNextSenderSeqNum(seqNum + 1); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+func stamp(msg *Message, id SessionID, seqNum int) *Message {
+	return msg.Set(TagSenderCompID, id.SenderCompID).
+		Set(TagTargetCompID, id.TargetCompID).
+		SetInt(TagMsgSeqNum, seqNum).
+		SetTime(TagSendingTime, time.Now())
+}
+
+func (s *Session) write(raw []byte) error {
+	s.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := s.conn.Write(raw); err != nil {
+		return err
+	}
+	s.lastSent = time.Now()
+	return nil
+}
+
+// Logout asks the counterparty to end the session, closing the connection
+// if it does not answer in time
+func (s *Session) Logout(text string) error {
+	msg := NewMessage(MsgLogout)
+	if text != "" {
+		msg.Set(TagText, text)
+	}
+	s.mutex.Lock()
+	s.loggingOut = true
+	s.mutex.Unlock()
+	time.AfterFunc(logoutTimeout, s.Close)
+	return s.Send(msg)
+}
+
+// Close drops the connection without logging out
+func (s *Session) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.conn.Close()
+	})
+}
+
+// Done is closed once the session has ended
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// LoggedOn is closed once the logon handshake has completed
+func (s *Session) LoggedOn() <-chan struct{} {
+	return s.loggedOn
+}
+
+func (s *Session) isLoggedOn() bool {
+	select {
+	case <-s.loggedOn:
+		return true
+	default:
+		return false
+	}
+}
+
+// ended reports whether err is a normal end of a session: a logout, the
+// counterparty hanging up or our side closing the connection
+func ended(err error) bool {
+	return err == nil || errors.Is(err, errLoggedOut) || errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed)
+}
+
+// run reads and processes messages until the connection ends
+func (s *Session) run() error {
+	defer s.Close()
+	go s.monitor()
+	for {
+		raw, err := ReadMessage(s.reader)
+		if errors.Is(err, ErrGarbled) {
+			s.logger.Printf("FIX %s: ignoring message: %v", s.ID, err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		msg, err := Parse(raw)
+		if err != nil {
+			s.logger.Printf("FIX %s: ignoring message: %v", s.ID, err)
+			continue
+		}
+		s.received.Store(time.Now().UnixNano())
+		s.testRequestSent.Store(false)
+		if err := s.process(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// monitor sends heartbeats when we have been quiet and test requests when the
+// counterparty has, and drops a connection that does not answer either
+func (s *Session) monitor() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case now := <-ticker.C:
+			if !s.isLoggedOn() {
+				continue
+			}
+			s.mutex.Lock()
+			idle := now.Sub(s.lastSent)
+			s.mutex.Unlock()
+			if idle >= s.heartbeat {
+				if err := s.Send(NewMessage(MsgHeartbeat)); err != nil {
+					s.logger.Printf("FIX %s: sending heartbeat: %v", s.ID, err)
+				}
+			}
+
+			silent := now.Sub(time.Unix(0, s.received.Load()))
+			switch {
+			case silent > 2*s.heartbeat+s.heartbeat/5:
+				s.logger.Printf("FIX %s: no messages for %v, disconnecting", s.ID, silent.Round(time.Second))
+				s.Close()
+				return
+			case silent > s.heartbeat+s.heartbeat/5 && !s.testRequestSent.Load():
+				s.testRequestSent.Store(true)
+				request := NewMessage(MsgTestRequest).Set(TagTestReqID, now.UTC().Format(timeFormat))
+				if err := s.Send(request); err != nil {
+					s.logger.Printf("FIX %s: sending test request: %v", s.ID, err)
+				}
+			}
+		}
+	}
+}
+
+func (s *Session) process(msg *Message) error {
+	if msg.String(TagSenderCompID) != s.ID.TargetCompID || msg.String(TagTargetCompID) != s.ID.SenderCompID {
+		s.reject(msg, RejectCompIDProblem, "CompID problem")
+		s.Logout("CompID problem")
+		return fmt.Errorf("message from %s to %s on session %s", msg.String(TagSenderCompID), msg.String(TagTargetCompID), s.ID)
+	}
+	seqNum, err := msg.Int(TagMsgSeqNum)
+	if err != nil {
+		s.Logout("MsgSeqNum missing or malformed")
+		return err
+	}
+
+	msgType := msg.Type()
+	if msgType == MsgLogon {
+		return s.logon(msg)
+	}
+	if !s.isLoggedOn() {
+		s.Logout("first message must be Logon")
+		return fmt.Errorf("%s message before Logon", msgType)
+	}
+	// In reset mode the new sequence number applies whatever MsgSeqNum says
+	if msgType == MsgSequenceReset && !msg.Bool(TagGapFillFlag) {
+		return s.sequenceReset(msg)
+	}
+
+	expected := s.store.NextTargetSeqNum()
+	switch {
+	case seqNum > expected:
+		// Logout and resend requests are honoured before filling our own gap
+		switch msgType {
+		case MsgLogout:
+			return s.logout(msg)
+		case MsgResendRequest:
+			if err := s.resend(msg); err != nil {
+				return err
+			}
+		}
+		return s.requestResend(expected, seqNum)
+	case seqNum < expected:
+		if msg.Bool(TagPossDupFlag) {
+			return nil
+		}
+		text := fmt.Sprintf("MsgSeqNum too low, expecting %d but received %d", expected, seqNum)
+		s.Logout(text)
+		return errors.New(text)
+	}
+
+	if err := s.store.SetNextTargetSeqNum(seqNum + 1); err != nil {
+		return err
+	}
+	if s.resendTarget != 0 && seqNum >= s.resendTarget {
+		s.resendTarget = 0
+	}
+
+	switch msgType {
+	case MsgHeartbeat:
+	case MsgTestRequest:
+		return s.Send(NewMessage(MsgHeartbeat).Set(TagTestReqID, msg.String(TagTestReqID)))
+	case MsgResendRequest:
+		return s.resend(msg)
+	case MsgReject:
+		s.logger.Printf("FIX %s: message %s rejected: %s", s.ID, msg.String(TagRefSeqNum), msg.String(TagText))
+	case MsgSequenceReset:
+		return s.gapFill(msg, seqNum)
+	case MsgLogout:
+		return s.logout(msg)
+	default:
+		s.handler(s, msg)
+	}
+	return nil
+}
+
+// logon completes the handshake. The acceptor answers with its own Logon;
+// the initiator receives that answer here.
+func (s *Session) logon(msg *Message) error {
+	if s.isLoggedOn() {
+		s.reject(msg, RejectInvalidMsgType, "already logged on")
+		return nil
+	}
+	heartBtInt, err := msg.Int(TagHeartBtInt)
+	if err != nil || heartBtInt <= 0 {
+		s.Logout("HeartBtInt must be a positive number of seconds")
+		return fmt.Errorf("logon with HeartBtInt %q", msg.String(TagHeartBtInt))
+	}
+	reset := msg.Bool(TagResetSeqNumFlag)
+	if reset && !s.initiator {
+		if err := s.store.Reset(); err != nil {
+			return err
+		}
+	}
+
+	seqNum, expected := msg.SeqNum(), s.store.NextTargetSeqNum()
+	if seqNum < expected {
+		text := fmt.Sprintf("MsgSeqNum too low, expecting %d but received %d", expected, seqNum)
+		s.Logout(text)
+		return errors.New(text)
+	}
+
+	if !s.initiator {
+		s.heartbeat = time.Duration(heartBtInt) * time.Second
+		reply := NewMessage(MsgLogon).SetInt(TagEncryptMethod, 0).SetInt(TagHeartBtInt, heartBtInt)
+		if reset {
+			reply.SetBool(TagResetSeqNumFlag, true)
+		}
+		if err := s.Send(reply); err != nil {
+			return err
+		}
+	}
+	close(s.loggedOn)
+	s.logger.Printf("FIX %s: logged on", s.ID)
+
+	if seqNum > expected {
+		return s.requestResend(expected, seqNum)
+	}
+	return s.store.SetNextTargetSeqNum(seqNum + 1)
+}
+
+// logout answers a Logout we did not start and ends the session
+func (s *Session) logout(msg *Message) error {
+	s.mutex.Lock()
+	initiated := s.loggingOut
+	s.mutex.Unlock()
+	if !initiated {
+		if err := s.Send(NewMessage(MsgLogout)); err != nil {
+			return err
+		}
+	}
+	if text := msg.String(TagText); text != "" {
+		s.logger.Printf("FIX %s: logged out: %s", s.ID, text)
+	}
+	return errLoggedOut
+}
+
+// requestResend asks for everything from begin on, unless a request is already outstanding
+func (s *Session) requestResend(begin, seen int) error {
+	if s.resendTarget != 0 {
+		if seen > s.resendTarget {
+			s.resendTarget = seen
+		}
+		return nil
+	}
+	s.resendTarget = seen
+	s.logger.Printf("FIX %s: expected MsgSeqNum %d but received %d, requesting resend", s.ID, begin, seen)
+	return s.Send(NewMessage(MsgResendRequest).SetInt(TagBeginSeqNo, begin).SetInt(TagEndSeqNo, 0))
+}
+
+// resend answers a ResendRequest. Stored application messages go out again
+// flagged as possible duplicates; admin messages are replaced by gap fills.
+func (s *Session) resend(msg *Message) error {
+	begin, err := msg.Int(TagBeginSeqNo)
+	if err != nil {
+		s.reject(msg, RejectRequiredTagMissing, err.Error())
+		return nil
+	}
+	end, err := msg.Int(TagEndSeqNo)
+	if err != nil {
+		s.reject(msg, RejectRequiredTagMissing, err.Error())
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	last := s.store.NextSenderSeqNum() - 1
+	if end == 0 || end > last {
+		end = last
+	}
+	if begin < 1 || begin > end {
+		return nil
+	}
+	stored, err := s.store.Messages(begin, end)
+	if err != nil {
+		return err
+	}
+	s.logger.Printf("FIX %s: resending %d to %d", s.ID, begin, end)
+
+	gapStart := 0
+	fillGap := func(next int) error {
+		if gapStart == 0 {
+			return nil
+		}
+		fill := NewMessage(MsgSequenceReset).SetBool(TagGapFillFlag, true).SetInt(TagNewSeqNo, next)
+		stamp(fill, s.ID, gapStart).SetBool(TagPossDupFlag, true)
+		gapStart = 0
+		return s.write(fill.Bytes())
+	}
+	for seqNum := begin; seqNum <= end; seqNum++ {
+		original, err := Parse(stored[seqNum])
+		if stored[seqNum] == nil || err != nil {
+			if gapStart == 0 {
+				gapStart = seqNum
+			}
+			continue
+		}
+		if err := fillGap(seqNum); err != nil {
+			return err
+		}
+		original.Set(TagOrigSendingTime, original.String(TagSendingTime)).
+			SetBool(TagPossDupFlag, true).
+			SetTime(TagSendingTime, time.Now())
+		if err := s.write(original.Bytes()); err != nil {
+			return err
+		}
+	}
+	return fillGap(end + 1)
+}
+
+// gapFill skips the sequence numbers the counterparty chose not to resend
+func (s *Session) gapFill(msg *Message, seqNum int) error {
+	newSeqNo, err := msg.Int(TagNewSeqNo)
+	if err != nil {
+		s.reject(msg, RejectRequiredTagMissing, err.Error())
+		return nil
+	}
+	if newSeqNo <= seqNum {
+		s.reject(msg, RejectValueIncorrect, "NewSeqNo must move the sequence forward")
+		return nil
+	}
+	if s.resendTarget != 0 && newSeqNo > s.resendTarget {
+		s.resendTarget = 0
+	}
+	return s.store.SetNextTargetSeqNum(newSeqNo)
+}
+
+// sequenceReset applies a SequenceReset in reset mode
+func (s *Session) sequenceReset(msg *Message) error {
+	newSeqNo, err := msg.Int(TagNewSeqNo)
+	if err != nil {
+		s.reject(msg, RejectRequiredTagMissing, err.Error())
+		return nil
+	}
+	if expected := s.store.NextTargetSeqNum(); newSeqNo < expected {
+		s.reject(msg, RejectValueIncorrect, fmt.Sprintf("NewSeqNo %d is below the expected %d", newSeqNo, expected))
+		return nil
+	}
+	s.resendTarget = 0
+	return s.store.SetNextTargetSeqNum(newSeqNo)
+}
+
+// reject refuses a message at the session level
+func (s *Session) reject(ref *Message, reason int, text string) {
+	msg := NewMessage(MsgReject).
+		SetInt(TagRefSeqNum, ref.SeqNum()).
+		Set(TagRefMsgType, ref.Type()).
+		SetInt(TagSessionRejectReason, reason).
+		Set(TagText, text)
+	if err := s.Send(msg); err != nil {
+		s.logger.Printf("FIX %s: sending reject: %v", s.ID, err)
+	}
+}
@@ -0,0 +1,242 @@
+package fix
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Store keeps a session's next sequence numbers and the application messages
+// it sent, so both survive a reconnect and a gap can be resent on request
+type Store interface {
+	NextSenderSeqNum() int
+	NextTargetSeqNum() int
+	SetNextSenderSeqNum(seqNum int) error
+	SetNextTargetSeqNum(seqNum int) error
+	// SaveMessage records an encoded message sent with seqNum
+	SaveMessage(seqNum int, raw []byte) error
+	// Messages returns the saved messages numbered begin to end inclusive, by sequence number
+	Messages(begin, end int) (map[int][]byte, error)
+	// Reset starts both sequences over at 1 and forgets saved messages
+	Reset() error
+	Close() error
+}
+
+// MemoryStore is a Store that lasts as long as the process
+type MemoryStore struct {
+	mutex    sync.Mutex
+	sender   int
+	target   int
+	messages map[int][]byte
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sender: 1, target: 1, messages: make(map[int][]byte)}
+}
+
+func (s *MemoryStore) NextSenderSeqNum() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.sender
+}
+
+func (s *MemoryStore) NextTargetSeqNum() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.target
+}
+
+func (s *MemoryStore) SetNextSenderSeqNum(seqNum int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.sender = seqNum
+	return nil
+}
+
+func (s *MemoryStore) SetNextTargetSeqNum(seqNum int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.target = seqNum
+	return nil
+}
+
+func (s *MemoryStore) SaveMessage(seqNum int, raw []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.messages[seqNum] = append([]byte(nil), raw...)
+	return nil
+}
+
+func (s *MemoryStore) Messages(begin, end int) (map[int][]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	found := make(map[int][]byte)
+	for seqNum, raw := range s.messages {
+		if seqNum >= begin && seqNum <= end {
+			found[seqNum] = raw
+		}
+	}
+	return found, nil
+}
+
+func (s *MemoryStore) Reset() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.sender, s.target = 1, 1
+	s.messages = make(map[int][]byte)
+	return nil
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+// FileStore is a Store kept in two files named after the session: a
+// sequence number file rewritten on every change, and an append-only log of
+// sent messages, each framed as "seqnum length\n" followed by the message
+type FileStore struct {
+	MemoryStore
+	seqNumsPath string
+	bodyPath    string
+	body        *os.File
+}
+
+// OpenFileStore opens the store for session in dir, loading what an earlier
+// run left behind
+func OpenFileStore(dir string, session SessionID) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	name := session.String()
+	s := &FileStore{
+		MemoryStore: MemoryStore{sender: 1, target: 1, messages: make(map[int][]byte)},
+		seqNumsPath: filepath.Join(dir, name+".seqnums"),
+		bodyPath:    filepath.Join(dir, name+".body"),
+	}
+	if err := s.loadSeqNums(); err != nil {
+		return nil, err
+	}
+	if err := s.loadMessages(); err != nil {
+		return nil, err
+	}
+	body, err := os.OpenFile(s.bodyPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	s.body = body
+	return s, nil
+}
+
+func (s *FileStore) loadSeqNums() error {
+	data, err := os.ReadFile(s.seqNumsPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Sscanf(string(data), "%d %d", &s.sender, &s.target); err != nil {
+		return fmt.Errorf("reading %s: %w", s.seqNumsPath, err)
+	}
+	return nil
+}
+
+func (s *FileStore) loadMessages() error {
+	file, err := os.Open(s.bodyPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadString('\n')
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var seqNum, length int
+		if _, err := fmt.Sscanf(strings.TrimSpace(line), "%d %d", &seqNum, &length); err != nil {
+			return fmt.Errorf("reading %s: %w", s.bodyPath, err)
+		}
+		raw := make([]byte, length)
+		if _, err := io.ReadFull(reader, raw); err != nil {
+			// A message torn by a crash was never acknowledged as sent
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			return err
+		}
+		s.messages[seqNum] = raw
+	}
+}
+
+// writeSeqNums replaces the sequence number file, renaming a complete copy
+// into place so a crash leaves either the old or the new numbers
+func (s *FileStore) writeSeqNums(sender, target int) error {
+	tmp := s.seqNumsPath + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.Itoa(sender)+" "+strconv.Itoa(target)+"\n"), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.seqNumsPath)
+}
+
+func (s *FileStore) SetNextSenderSeqNum(seqNum int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := s.writeSeqNums(seqNum, s.target); err != nil {
+		return err
+	}
+	s.sender = seqNum
+	return nil
+}
+
+func (s *FileStore) SetNextTargetSeqNum(seqNum int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := s.writeSeqNums(s.sender, seqNum); err != nil {
+		return err
+	}
+	s.target = seqNum
+	return nil
+}
+
+func (s *FileStore) SaveMessage(seqNum int, raw []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	record := append([]byte(fmt.Sprintf("%d %d\n", seqNum, len(raw))), raw...)
+	if _, err := s.body.Write(record); err != nil {
+		return err
+	}
+	s.messages[seqNum] = append([]byte(nil), raw...)
+	return nil
+}
+
+func (s *FileStore) Reset() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := s.body.Truncate(0); err != nil {
+		return err
+	}
+	if err := s.writeSeqNums(1, 1); err != nil {
+		return err
+	}
+	s.sender, s.target = 1, 1
+	s.messages = make(map[int][]byte)
+	return nil
+}
+
+func (s *FileStore) Close() error {
+	return s.body.Close()
+}
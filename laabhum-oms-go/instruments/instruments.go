@@ -0,0 +1,463 @@
+package instruments
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/calendar"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+)
+
+// DefaultExchange is assumed for symbols given without an exchange prefix
+const DefaultExchange = "NSE"
+
+var ErrUnknownInstrument = errors.New("unknown instrument")
+
+// expiryLayout is the date format of contract expiries
+const expiryLayout = "2006-01-02"
+
+// Instrument describes the trading rules of a single exchange-listed symbol
+type Instrument struct {
+	Exchange       string                `json:"exchange"`
+	Symbol         string                `json:"tradingsymbol"`
+	Name           string                `json:"name,omitempty"`
+	Segment        models.Segment        `json:"segment"`
+	Type           models.InstrumentType `json:"instrument_type"`
+	Underlying     string                `json:"underlying,omitempty"` // derivatives only
+	Expiry         string                `json:"expiry,omitempty"`     // YYYY-MM-DD, derivatives only
+	Strike         float64               `json:"strike,omitempty"`     // options only
+	TickSize       float64               `json:"tick_size"`
+	LotSize        int                   `json:"lot_size"`
+	FreezeQuantity int                   `json:"freeze_quantity,omitempty"` // 0 means no freeze limit
+	LowerCircuit   float64               `json:"lower_circuit,omitempty"`   // 0 means no lower band
+	UpperCircuit   float64               `json:"upper_circuit,omitempty"`   // 0 means no upper band
+}
+
+// UnmarshalJSON reads an instrument as exchange dumps write it. Their segments
+// ("NFO-OPT") and instrument types ("fut") are normalized by Replace rather
+// than rejected by the order contract's strict decoding.
+func (i *Instrument) UnmarshalJSON(data []byte) error {
+	type plain Instrument
+	var raw struct {
+		plain
+		Segment string `json:"segment"`
+		Type    string `json:"instrument_type"`
+	}
+	raw.plain = plain(*i)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*i = Instrument(raw.plain)
+	i.Segment = models.Segment(raw.Segment)
+	i.Type = models.InstrumentType(raw.Type)
+	return nil
+}
+
+// Key returns the instrument's normalized EXCHANGE:SYMBOL form
+func (i Instrument) Key() string {
+	return strings.ToUpper(i.Exchange) + ":" + strings.ToUpper(i.Symbol)
+}
+
+// IsDerivative reports whether the instrument is a futures or options contract
+func (i Instrument) IsDerivative() bool {
+	return i.Type == models.InstrumentFuture || i.IsOption()
+}
+
+// IsOption reports whether the instrument is a call or put
+func (i Instrument) IsOption() bool {
+	return i.Type == models.InstrumentCall || i.Type == models.InstrumentPut
+}
+
+// Expired reports whether the contract's expiry day has ended at t
+func (i Instrument) Expired(t time.Time) bool {
+	return ExpiryPassed(i.Expiry, t)
+}
+
+// ExpiryPassed reports whether the expiry day (YYYY-MM-DD) has ended at t in IST
+func ExpiryPassed(expiry string, t time.Time) bool {
+	day, err := time.ParseInLocation(expiryLayout, expiry, calendar.IST)
+	if err != nil {
+		return false
+	}
+	return !t.Before(day.AddDate(0, 0, 1))
+}
+
+// ExpiresOn reports whether the expiry day (YYYY-MM-DD) is t's date in IST
+func ExpiresOn(expiry string, t time.Time) bool {
+	return expiry == t.In(calendar.IST).Format(expiryLayout)
+}
+
+// Contract returns the derivative terms of the instrument, or nil for cash instruments
+func (i Instrument) Contract() *models.Contract {
+	if !i.IsDerivative() {
+		return nil
+	}
+	return &models.Contract{
+		Type:       i.Type,
+		Underlying: i.Underlying,
+		Expiry:     i.Expiry,
+		Strike:     i.Strike,
+	}
+}
+
+// Normalize turns "reliance", "nse:reliance" or " NSE:RELIANCE " into "NSE:RELIANCE"
+func Normalize(symbol string) string {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if symbol == "" {
+		return ""
+	}
+	exchange, tradingSymbol, found := strings.Cut(symbol, ":")
+	if !found {
+		return DefaultExchange + ":" + symbol
+	}
+	return strings.TrimSpace(exchange) + ":" + strings.TrimSpace(tradingSymbol)
+}
+
+// ParseSegment maps exchange dump segment names such as "NSE", "NFO-OPT" or
+// "MCX-FUT" onto OMS segments
+func ParseSegment(value string) (models.Segment, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	switch models.Segment(value) {
+	case models.SegmentEquity, models.SegmentFNO, models.SegmentCurrency, models.SegmentCommodity:
+		return models.Segment(value), nil
+	}
+
+	prefix, _, _ := strings.Cut(value, "-")
+	switch prefix {
+	case "NSE", "BSE":
+		return models.SegmentEquity, nil
+	case "NFO", "BFO":
+		return models.SegmentFNO, nil
+	case "CDS", "BCD":
+		return models.SegmentCurrency, nil
+	case "MCX":
+		return models.SegmentCommodity, nil
+	}
+	return "", fmt.Errorf("unknown segment %q", value)
+}
+
+// Master is the set of tradable instruments keyed by EXCHANGE:SYMBOL
+type Master struct {
+	mutex       sync.RWMutex
+	instruments map[string]Instrument
+}
+
+func NewMaster(list []Instrument) (*Master, error) {
+	m := &Master{instruments: make(map[string]Instrument)}
+	if err := m.Replace(list); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Load builds a master from a JSON array or a CSV instrument dump
+func Load(path string) (*Master, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var list []Instrument
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		list, err = readCSV(file)
+	} else {
+		err = json.NewDecoder(file).Decode(&list)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading instruments from %s: %w", path, err)
+	}
+	return NewMaster(list)
+}
+
+// Replace swaps in a new instrument list, e.g. after the daily dump refresh
+func (m *Master) Replace(list []Instrument) error {
+	instruments := make(map[string]Instrument, len(list))
+	for _, inst := range list {
+		if inst.Exchange == "" || inst.Symbol == "" {
+			return fmt.Errorf("instrument %+v: exchange and tradingsymbol are required", inst)
+		}
+		if inst.TickSize <= 0 {
+			return fmt.Errorf("instrument %s: tick size must be positive", inst.Key())
+		}
+		if inst.LotSize <= 0 {
+			inst.LotSize = 1
+		}
+		segment := string(inst.Segment)
+		if segment == "" {
+			segment = inst.Exchange
+		}
+		parsed, err := ParseSegment(segment)
+		if err != nil {
+			return fmt.Errorf("instrument %s: %w", inst.Key(), err)
+		}
+		inst.Segment = parsed
+		if err := inst.normalizeContract(); err != nil {
+			return err
+		}
+		instruments[inst.Key()] = inst
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.instruments = instruments
+	return nil
+}
+
+// normalizeContract fills in the instrument type and checks derivative fields
+func (i *Instrument) normalizeContract() error {
+	i.Type = models.InstrumentType(strings.ToUpper(string(i.Type)))
+	if i.Type == "" {
+		i.Type = models.InstrumentEquity
+	}
+	switch i.Type {
+	case models.InstrumentEquity:
+		return nil
+	case models.InstrumentFuture, models.InstrumentCall, models.InstrumentPut:
+	default:
+		return fmt.Errorf("instrument %s: unknown instrument type %q", i.Key(), i.Type)
+	}
+
+	if i.Underlying == "" {
+		i.Underlying = i.Name
+	}
+	i.Underlying = strings.ToUpper(i.Underlying)
+	if i.Underlying == "" {
+		return fmt.Errorf("instrument %s: derivatives need an underlying", i.Key())
+	}
+	if _, err := time.Parse(expiryLayout, i.Expiry); err != nil {
+		return fmt.Errorf("instrument %s: invalid expiry %q", i.Key(), i.Expiry)
+	}
+	if i.IsOption() && i.Strike <= 0 {
+		return fmt.Errorf("instrument %s: options need a positive strike", i.Key())
+	}
+	return nil
+}
+
+// Lookup finds an instrument by symbol in any accepted form
+func (m *Master) Lookup(symbol string) (Instrument, error) {
+	key := Normalize(symbol)
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	inst, ok := m.instruments[key]
+	if !ok {
+		return Instrument{}, fmt.Errorf("%w: %s", ErrUnknownInstrument, key)
+	}
+	return inst, nil
+}
+
+// List returns every instrument sorted by key
+func (m *Master) List() []Instrument {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	list := make([]Instrument, 0, len(m.instruments))
+	for _, inst := range m.instruments {
+		list = append(list, inst)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Key() < list[j].Key() })
+	return list
+}
+
+// OptionChainRow pairs the call and put listed at one strike
+type OptionChainRow struct {
+	Strike float64     `json:"strike"`
+	Call   *Instrument `json:"call,omitempty"`
+	Put    *Instrument `json:"put,omitempty"`
+}
+
+// OptionChain is every option on an underlying for one expiry
+type OptionChain struct {
+	Underlying string           `json:"underlying"`
+	Expiry     string           `json:"expiry"`
+	Rows       []OptionChainRow `json:"rows"`
+}
+
+// Expiries lists the distinct contract expiries for underlying in date order
+func (m *Master) Expiries(underlying string) []string {
+	underlying = strings.ToUpper(strings.TrimSpace(underlying))
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	seen := make(map[string]bool)
+	expiries := []string{}
+	for _, inst := range m.instruments {
+		if inst.IsDerivative() && inst.Underlying == underlying && !seen[inst.Expiry] {
+			seen[inst.Expiry] = true
+			expiries = append(expiries, inst.Expiry)
+		}
+	}
+	sort.Strings(expiries)
+	return expiries
+}
+
+// OptionChain returns the calls and puts on underlying for expiry, ordered by strike
+func (m *Master) OptionChain(underlying, expiry string) (*OptionChain, error) {
+	underlying = strings.ToUpper(strings.TrimSpace(underlying))
+	if _, err := time.Parse(expiryLayout, expiry); err != nil {
+		return nil, fmt.Errorf("invalid expiry %q", expiry)
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	rows := make(map[float64]*OptionChainRow)
+	for _, inst := range m.instruments {
+		if !inst.IsOption() || inst.Underlying != underlying || inst.Expiry != expiry {
+			continue
+		}
+		row, ok := rows[inst.Strike]
+		if !ok {
+			row = &OptionChainRow{Strike: inst.Strike}
+			rows[inst.Strike] = row
+		}
+		contract := inst
+		if inst.Type == models.InstrumentCall {
+			row.Call = &contract
+		} else {
+			row.Put = &contract
+		}
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no options on %s expiring %s", ErrUnknownInstrument, underlying, expiry)
+	}
+
+	chain := &OptionChain{Underlying: underlying, Expiry: expiry}
+	for _, row := range rows {
+		chain.Rows = append(chain.Rows, *row)
+	}
+	sort.Slice(chain.Rows, func(i, j int) bool { return chain.Rows[i].Strike < chain.Rows[j].Strike })
+	return chain, nil
+}
+
+// ValidatePrice checks price against the tick size and daily circuit limits
+func (i Instrument) ValidatePrice(price float64) error {
+	ticks := price / i.TickSize
+	if math.Abs(ticks-math.Round(ticks)) > 1e-6 {
+		return fmt.Errorf("price %.4f for %s is not a multiple of tick size %.4f", price, i.Key(), i.TickSize)
+	}
+	if i.LowerCircuit > 0 && price < i.LowerCircuit {
+		return fmt.Errorf("price %.2f for %s is below the lower circuit %.2f", price, i.Key(), i.LowerCircuit)
+	}
+	if i.UpperCircuit > 0 && price > i.UpperCircuit {
+		return fmt.Errorf("price %.2f for %s is above the upper circuit %.2f", price, i.Key(), i.UpperCircuit)
+	}
+	return nil
+}
+
+// ValidateQuantity checks quantity against the lot size and freeze limit
+func (i Instrument) ValidateQuantity(quantity int) error {
+	if quantity%i.LotSize != 0 {
+		return fmt.Errorf("quantity %d for %s is not a multiple of lot size %d", quantity, i.Key(), i.LotSize)
+	}
+	if i.FreezeQuantity > 0 && quantity > i.FreezeQuantity {
+		return fmt.Errorf("quantity %d for %s exceeds the freeze quantity %d", quantity, i.Key(), i.FreezeQuantity)
+	}
+	return nil
+}
+
+// readCSV parses an exchange instrument dump. Columns are matched by header
+// name so dumps with extra columns load unchanged.
+func readCSV(r io.Reader) ([]Instrument, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"exchange", "tradingsymbol", "tick_size"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing %q column", required)
+		}
+	}
+
+	var list []Instrument
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		number := func(name string) (float64, error) {
+			value := field(name)
+			if value == "" {
+				return 0, nil
+			}
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return 0, fmt.Errorf("line %d: %s: %w", line, name, err)
+			}
+			return n, nil
+		}
+
+		inst := Instrument{
+			Exchange:   field("exchange"),
+			Symbol:     field("tradingsymbol"),
+			Name:       field("name"),
+			Type:       models.InstrumentType(field("instrument_type")),
+			Underlying: field("underlying"),
+			Expiry:     field("expiry"),
+		}
+
+		segment := field("segment")
+		if segment == "" {
+			segment = inst.Exchange
+		}
+		if inst.Segment, err = ParseSegment(segment); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if inst.TickSize, err = number("tick_size"); err != nil {
+			return nil, err
+		}
+		if inst.Strike, err = number("strike"); err != nil {
+			return nil, err
+		}
+		if inst.LowerCircuit, err = number("lower_circuit"); err != nil {
+			return nil, err
+		}
+		if inst.UpperCircuit, err = number("upper_circuit"); err != nil {
+			return nil, err
+		}
+		lot, err := number("lot_size")
+		if err != nil {
+			return nil, err
+		}
+		freeze, err := number("freeze_quantity")
+		if err != nil {
+			return nil, err
+		}
+		inst.LotSize, inst.FreezeQuantity = int(lot), int(freeze)
+
+		list = append(list, inst)
+	}
+	return list, nil
+}
@@ -0,0 +1,328 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+)
+
+// Record types written to the journal. Each record carries the state of the
+// affected entity after the command was applied, so replay never has to
+// re-run business logic.
+const (
+	OrderCreated    = "order.created"
+	OrderAmended    = "order.amended"
+	OrderFilled     = "order.filled"
+	OrderCancelled  = "order.cancelled"
+	OrderStatus     = "order.status"
+	OrderDeleted    = "order.deleted"
+	PositionOpened  = "position.opened"
+	PositionChanged = "position.changed"
+	PositionClosed  = "position.closed"
+	MarketCondition = "market.condition"
+	OutboxSent      = "outbox.sent" // A relayed event, by outbox ID
+	OutboxDead      = "outbox.dead" // An event given up on, as a DeadLetter
+)
+
+const (
+	activeFile       = "journal.wal"
+	snapshotFile     = "snapshot.json"
+	segmentPrefix    = "journal-"
+	segmentExtension = ".wal"
+	headerSize       = 8
+	maxRecordSize    = 16 << 20
+)
+
+var (
+	// ErrCorrupt means a record inside the journal failed its checksum or broke
+	// the sequence. A damaged final record is treated as a torn write instead.
+	ErrCorrupt = errors.New("journal corrupt")
+	crcTable   = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// Record is one journal entry. Outbox holds the domain events the change
+// queued, so undelivered events survive a restart along with the change.
+type Record struct {
+	Sequence  uint64                     `json:"seq"`
+	Type      string                     `json:"type"`
+	Timestamp time.Time                  `json:"timestamp"`
+	Data      json.RawMessage            `json:"data"`
+	Outbox    []repository.OutboxMessage `json:"outbox,omitempty"`
+}
+
+// DeadLetter is the data of an OutboxDead record
+type DeadLetter struct {
+	ID    int64  `json:"id"`
+	Error string `json:"error,omitempty"`
+}
+
+// Journal is an append-only write-ahead log. Every record is framed as a
+// big-endian payload length, a CRC-32C of the payload and the JSON payload.
+type Journal struct {
+	mutex    sync.Mutex
+	dir      string
+	file     *os.File
+	sequence uint64
+	sync     bool
+}
+
+// Open opens or creates the active journal in dir. Records already on disk are
+// not applied; use Recover to rebuild state and continue the sequence.
+func Open(dir string, sync bool) (*Journal, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(filepath.Join(dir, activeFile), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{dir: dir, file: file, sync: sync}, nil
+}
+
+// Dir returns the journal directory
+func (j *Journal) Dir() string {
+	return j.dir
+}
+
+// Sequence returns the sequence number of the last record written
+func (j *Journal) Sequence() uint64 {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	return j.sequence
+}
+
+// Append writes a record with the outbox messages the change queued and,
+// when the journal was opened with sync, flushes it to stable storage before
+// returning
+func (j *Journal) Append(recordType string, data interface{}, outbox ...repository.OutboxMessage) (Record, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return Record{}, fmt.Errorf("encoding %s: %w", recordType, err)
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	record := Record{
+		Sequence:  j.sequence + 1,
+		Type:      recordType,
+		Timestamp: time.Now().UTC(),
+		Data:      payload,
+		Outbox:    outbox,
+	}
+	if err := writeRecord(j.file, record); err != nil {
+		return Record{}, err
+	}
+	if j.sync {
+		if err := j.file.Sync(); err != nil {
+			return Record{}, err
+		}
+	}
+	j.sequence = record.Sequence
+	return record, nil
+}
+
+// Rotate archives the active journal as a segment named after its last
+// sequence number and starts a new, empty one
+func (j *Journal) Rotate() error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	info, err := j.file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == 0 {
+		return nil
+	}
+	if err := j.file.Sync(); err != nil {
+		return err
+	}
+	if err := j.file.Close(); err != nil {
+		return err
+	}
+
+	active := filepath.Join(j.dir, activeFile)
+	segment := filepath.Join(j.dir, fmt.Sprintf("%s%020d%s", segmentPrefix, j.sequence, segmentExtension))
+	if err := os.Rename(active, segment); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(active, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	j.file = file
+	return nil
+}
+
+// Compact deletes the archived segments whose records all have sequence
+// numbers at or below through, typically the sequence of a snapshot
+func (j *Journal) Compact(through uint64) error {
+	segments, err := Segments(j.dir)
+	if err != nil {
+		return err
+	}
+	for _, path := range segments {
+		last, ok := segmentSequence(path)
+		if !ok || last > through {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close flushes and closes the active journal
+func (j *Journal) Close() error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if err := j.file.Sync(); err != nil {
+		j.file.Close()
+		return err
+	}
+	return j.file.Close()
+}
+
+// Segments lists the archived segments in dir followed by the active journal,
+// oldest first
+func Segments(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var segments []string
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if _, ok := segmentSequence(path); !ok {
+			continue
+		}
+		segments = append(segments, path)
+	}
+	// Segment names are zero padded, so lexical order is sequence order
+	sort.Strings(segments)
+
+	active := filepath.Join(dir, activeFile)
+	if _, err := os.Stat(active); err == nil {
+		segments = append(segments, active)
+	}
+	return segments, nil
+}
+
+// segmentSequence returns the last sequence number in an archived segment,
+// read from its name. The active journal is not a segment.
+func segmentSequence(path string) (uint64, bool) {
+	name := filepath.Base(path)
+	if !strings.HasPrefix(name, segmentPrefix) || !strings.HasSuffix(name, segmentExtension) {
+		return 0, false
+	}
+	sequence, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, segmentPrefix), segmentExtension), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return sequence, true
+}
+
+// ReadFile calls fn for each intact record in path. It returns the offset just
+// past the last intact record; a shorter offset than the file size means the
+// tail was torn by a crash mid-write.
+func ReadFile(path string, fn func(Record) error) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	reader := bufio.NewReader(file)
+	var offset int64
+	for {
+		record, size, err := readRecord(reader)
+		if err == io.EOF {
+			return offset, nil
+		}
+		if err == io.ErrUnexpectedEOF {
+			return offset, nil
+		}
+		if errors.Is(err, ErrCorrupt) {
+			if offset+size >= info.Size() {
+				return offset, nil
+			}
+			return offset, fmt.Errorf("%s at offset %d: %w", path, offset, err)
+		}
+		if err != nil {
+			return offset, err
+		}
+		if err := fn(record); err != nil {
+			return offset, err
+		}
+		offset += size
+	}
+}
+
+func writeRecord(w io.Writer, record Record) error {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	frame := make([]byte, headerSize+len(payload))
+	binary.BigEndian.PutUint32(frame[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(frame[4:8], crc32.Checksum(payload, crcTable))
+	copy(frame[headerSize:], payload)
+	_, err = w.Write(frame)
+	return err
+}
+
+// readRecord returns the next record and the number of bytes its frame occupies
+func readRecord(r io.Reader) (Record, int64, error) {
+	var header [headerSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return Record{}, 0, err
+	}
+	length := binary.BigEndian.Uint32(header[0:4])
+	checksum := binary.BigEndian.Uint32(header[4:8])
+	size := int64(headerSize) + int64(length)
+	if length > maxRecordSize {
+		return Record{}, size, fmt.Errorf("record length %d: %w", length, ErrCorrupt)
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return Record{}, size, err
+	}
+	if crc32.Checksum(payload, crcTable) != checksum {
+		return Record{}, size, fmt.Errorf("checksum mismatch: %w", ErrCorrupt)
+	}
+
+	var record Record
+	if err := json.Unmarshal(payload, &record); err != nil {
+		return Record{}, size, fmt.Errorf("decoding record: %w", ErrCorrupt)
+	}
+	return record, size, nil
+}
@@ -0,0 +1,456 @@
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/events"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+	"github.com/google/uuid"
+)
+
+// Repository is an InMemoryOrderRepository whose every mutation is written
+// to the journal before it is applied to memory, so memory never holds a
+// change the journal lost. Reads go straight to memory.
+type Repository struct {
+	*repository.InMemoryOrderRepository
+	journal *Journal
+	// mutex serialises mutations with their journal records so the journal
+	// order always matches the order the state changed in
+	mutex sync.Mutex
+}
+
+// Recover rebuilds an in-memory repository from the latest snapshot and the
+// journal in dir, truncates a torn final record, and returns a Repository
+// that keeps journaling from where the log left off
+func Recover(dir string, sync bool) (*Repository, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	snapshot, err := ReadSnapshot(dir)
+	if err != nil {
+		return nil, err
+	}
+	repo := repository.NewInMemoryOrderRepository()
+	repo.Restore(snapshot.State)
+
+	all, err := Segments(dir)
+	if err != nil {
+		return nil, err
+	}
+	// Segments the snapshot covers are normally compacted away already
+	var segments []string
+	for _, path := range all {
+		if last, ok := segmentSequence(path); ok && last <= snapshot.Sequence {
+			continue
+		}
+		segments = append(segments, path)
+	}
+	sequence, err := replay(repo, segments, snapshot.Sequence, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := Open(dir, sync)
+	if err != nil {
+		return nil, err
+	}
+	j.sequence = sequence
+	return &Repository{InMemoryOrderRepository: repo, journal: j}, nil
+}
+
+// Rebuild replays the given journal files from an empty repository, stopping
+// before the first record after until when it is non-zero. It is meant for
+// investigating a past session and never modifies the files. Records before
+// the latest snapshot are gone once TakeSnapshot has compacted them.
+func Rebuild(paths []string, until time.Time) (*repository.InMemoryOrderRepository, uint64, error) {
+	repo := repository.NewInMemoryOrderRepository()
+	sequence, err := replayFiles(repo, paths, 0, until, false)
+	return repo, sequence, err
+}
+
+func replay(repo *repository.InMemoryOrderRepository, paths []string, after uint64, until time.Time) (uint64, error) {
+	return replayFiles(repo, paths, after, until, true)
+}
+
+func replayFiles(repo *repository.InMemoryOrderRepository, paths []string, after uint64, until time.Time, repair bool) (uint64, error) {
+	sequence := after
+	for i, path := range paths {
+		offset, err := ReadFile(path, func(record Record) error {
+			if !until.IsZero() && record.Timestamp.After(until) {
+				return errStop
+			}
+			if record.Sequence <= after {
+				return nil
+			}
+			if record.Sequence != sequence+1 {
+				return fmt.Errorf("%s: sequence %d follows %d: %w", path, record.Sequence, sequence, ErrCorrupt)
+			}
+			if err := Apply(repo, record); err != nil {
+				return fmt.Errorf("%s: record %d: %w", path, record.Sequence, err)
+			}
+			sequence = record.Sequence
+			return nil
+		})
+		if err == errStop {
+			return sequence, nil
+		}
+		if err != nil {
+			return sequence, err
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return sequence, err
+		}
+		if offset == info.Size() {
+			continue
+		}
+		// Only the active journal may end in a partial write
+		if !repair || i != len(paths)-1 || filepath.Base(path) != activeFile {
+			return sequence, fmt.Errorf("%s: damaged record at offset %d: %w", path, offset, ErrCorrupt)
+		}
+		log.Printf("Journal: discarding %d bytes of torn write at end of %s", info.Size()-offset, path)
+		if err := os.Truncate(path, offset); err != nil {
+			return sequence, err
+		}
+	}
+	return sequence, nil
+}
+
+var errStop = errors.New("stop replay")
+
+// Apply replays a single record onto repo, queueing the outbox messages it carries
+func Apply(repo *repository.InMemoryOrderRepository, record Record) error {
+	if err := applyState(repo, record); err != nil {
+		return err
+	}
+	repo.QueueOutbox(record.Outbox)
+	return nil
+}
+
+func applyState(repo *repository.InMemoryOrderRepository, record Record) error {
+	switch record.Type {
+	case OrderCreated, OrderAmended, OrderFilled, OrderCancelled, OrderStatus:
+		var order models.Order
+		if err := json.Unmarshal(record.Data, &order); err != nil {
+			return err
+		}
+		repo.PutOrder(order)
+	case OrderDeleted:
+		var id string
+		if err := json.Unmarshal(record.Data, &id); err != nil {
+			return err
+		}
+		repo.RemoveOrder(id)
+	case PositionOpened, PositionChanged:
+		var position models.Position
+		if err := json.Unmarshal(record.Data, &position); err != nil {
+			return err
+		}
+		repo.PutPosition(position)
+	case PositionClosed:
+		var id string
+		if err := json.Unmarshal(record.Data, &id); err != nil {
+			return err
+		}
+		repo.RemovePosition(id)
+	case MarketCondition:
+		var condition models.MarketCondition
+		if err := json.Unmarshal(record.Data, &condition); err != nil {
+			return err
+		}
+		repo.PutMarketCondition(condition)
+	case OutboxSent:
+		var id int64
+		if err := json.Unmarshal(record.Data, &id); err != nil {
+			return err
+		}
+		if err := repo.MarkOutboxSent(id); err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+	case OutboxDead:
+		var dead DeadLetter
+		if err := json.Unmarshal(record.Data, &dead); err != nil {
+			return err
+		}
+		if err := repo.MarkOutboxDead(dead.ID, errors.New(dead.Error)); err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown record type %q", record.Type)
+	}
+	return nil
+}
+
+// TakeSnapshot writes the current state as a snapshot and deletes the
+// journal records it covers, bounding the work and disk the next recovery needs
+func (r *Repository) TakeSnapshot() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	snapshot := Snapshot{
+		Sequence: r.journal.Sequence(),
+		TakenAt:  time.Now().UTC(),
+		State:    r.InMemoryOrderRepository.Snapshot(),
+	}
+	if err := WriteSnapshot(r.journal.Dir(), snapshot); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err := r.journal.Rotate(); err != nil {
+		return err
+	}
+	return r.journal.Compact(snapshot.Sequence)
+}
+
+// RunSnapshots takes a snapshot every interval until ctx is cancelled
+func (r *Repository) RunSnapshots(ctx context.Context, logger *log.Logger, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.TakeSnapshot(); err != nil {
+				logger.Printf("Journal snapshot failed: %v", err)
+			}
+		}
+	}
+}
+
+// Close flushes and closes the journal
+func (r *Repository) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.journal.Close()
+}
+
+// putOrder journals order as its new state, then stores it and queues its events
+func (r *Repository) putOrder(recordType string, previous *models.Order, order models.Order) error {
+	messages, err := repository.OrderOutbox(previous, order)
+	if err != nil {
+		return err
+	}
+	if _, err := r.journal.Append(recordType, order, messages...); err != nil {
+		return fmt.Errorf("journaling order: %w", err)
+	}
+	r.InMemoryOrderRepository.PutOrder(order)
+	r.InMemoryOrderRepository.QueueOutbox(messages)
+	return nil
+}
+
+// putPosition journals position as its new state, then stores it and queues its event
+func (r *Repository) putPosition(recordType, eventType string, position models.Position) error {
+	messages, err := repository.PositionOutbox(eventType, position)
+	if err != nil {
+		return err
+	}
+	if _, err := r.journal.Append(recordType, position, messages...); err != nil {
+		return fmt.Errorf("journaling position: %w", err)
+	}
+	r.InMemoryOrderRepository.PutPosition(position)
+	r.InMemoryOrderRepository.QueueOutbox(messages)
+	return nil
+}
+
+func statusRecordType(status models.OrderStatus) string {
+	switch status {
+	case models.OrderStatusExecuted:
+		return OrderFilled
+	case models.OrderStatusCancelled:
+		return OrderCancelled
+	default:
+		return OrderStatus
+	}
+}
+
+func (r *Repository) CreateOrder(order models.Order) (models.Order, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if order.ClientOrderID != "" {
+		_, err := r.GetOrderByClientOrderID(order.UserID, order.AccountID, order.ClientOrderID)
+		if err == nil {
+			return models.Order{}, repository.ErrDuplicateClientOrderID
+		}
+		if !errors.Is(err, repository.ErrNotFound) {
+			return models.Order{}, err
+		}
+	}
+	if order.ID == "" {
+		order.ID = uuid.New().String()
+	}
+	order.CreatedAt = time.Now().Unix()
+	if err := r.putOrder(OrderCreated, nil, order); err != nil {
+		return models.Order{}, err
+	}
+	return order, nil
+}
+
+func (r *Repository) SaveOrder(order map[string]interface{}) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	id, ok := order["ID"].(string)
+	if !ok || id == "" {
+		return errors.New("invalid order ID")
+	}
+	symbol, _ := order["Symbol"].(string)
+	status, _ := order["Status"].(models.OrderStatus)
+	strategy, _ := order["Strategy"].(models.TradeStrategy)
+
+	previous, err := r.InMemoryOrderRepository.GetOrder(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		previous = nil
+	} else if err != nil {
+		return err
+	}
+	return r.putOrder(OrderAmended, previous, models.Order{
+		ID:        id,
+		Symbol:    symbol,
+		Status:    status,
+		Strategy:  strategy,
+		CreatedAt: time.Now().Unix(),
+	})
+}
+
+func (r *Repository) UpdateOrder(order models.Order) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	previous, err := r.InMemoryOrderRepository.GetOrder(order.ID)
+	if err != nil {
+		return err
+	}
+	return r.putOrder(OrderAmended, previous, order)
+}
+
+func (r *Repository) UpdateOrderStatus(id string, status models.OrderStatus) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.setStatus(id, status)
+}
+
+func (r *Repository) ExecuteChildOrder(orderID string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.setStatus(orderID, models.OrderStatusExecuted)
+}
+
+// setStatus moves an order to status; the caller holds the mutex
+func (r *Repository) setStatus(id string, status models.OrderStatus) error {
+	previous, err := r.InMemoryOrderRepository.GetOrder(id)
+	if err != nil {
+		return err
+	}
+	current := *previous
+	current.Status = status
+	return r.putOrder(statusRecordType(status), previous, current)
+}
+
+func (r *Repository) DeleteOrder(id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, err := r.InMemoryOrderRepository.GetOrder(id); err != nil {
+		return err
+	}
+	if _, err := r.journal.Append(OrderDeleted, id); err != nil {
+		return fmt.Errorf("journaling order: %w", err)
+	}
+	r.InMemoryOrderRepository.RemoveOrder(id)
+	return nil
+}
+
+func (r *Repository) CreatePosition(position models.Position) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if position.ID == "" {
+		position.ID = uuid.New().String()
+	}
+	position.OpenedAt = time.Now()
+	position.LastUpdatedAt = position.OpenedAt
+	return r.putPosition(PositionOpened, events.PositionOpened, position)
+}
+
+func (r *Repository) UpdatePosition(position models.Position) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, err := r.InMemoryOrderRepository.GetPosition(position.ID); err != nil {
+		return err
+	}
+	position.LastUpdatedAt = time.Now()
+	return r.putPosition(PositionChanged, events.PositionUpdated, position)
+}
+
+func (r *Repository) ClosePosition(id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	position, err := r.InMemoryOrderRepository.GetPosition(id)
+	if err != nil {
+		return err
+	}
+	messages, err := repository.PositionOutbox(events.PositionClosed, *position)
+	if err != nil {
+		return err
+	}
+	if _, err := r.journal.Append(PositionClosed, id, messages...); err != nil {
+		return fmt.Errorf("journaling position: %w", err)
+	}
+	r.InMemoryOrderRepository.RemovePosition(id)
+	r.InMemoryOrderRepository.QueueOutbox(messages)
+	return nil
+}
+
+func (r *Repository) SaveMarketCondition(condition models.MarketCondition) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, err := r.journal.Append(MarketCondition, condition); err != nil {
+		return fmt.Errorf("journaling market condition: %w", err)
+	}
+	r.InMemoryOrderRepository.PutMarketCondition(condition)
+	return nil
+}
+
+// MarkOutboxSent journals the delivery so a recovered outbox does not send
+// the event again. Failed attempts are not journaled and restart from zero.
+func (r *Repository) MarkOutboxSent(id int64) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, err := r.journal.Append(OutboxSent, id); err != nil {
+		return fmt.Errorf("journaling outbox delivery: %w", err)
+	}
+	return r.InMemoryOrderRepository.MarkOutboxSent(id)
+}
+
+func (r *Repository) MarkOutboxDead(id int64, cause error) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	dead := DeadLetter{ID: id}
+	if cause != nil {
+		dead.Error = cause.Error()
+	}
+	if _, err := r.journal.Append(OutboxDead, dead); err != nil {
+		return fmt.Errorf("journaling dead letter: %w", err)
+	}
+	return r.InMemoryOrderRepository.MarkOutboxDead(id, cause)
+}
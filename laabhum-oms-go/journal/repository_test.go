@@ -0,0 +1,183 @@
+package journal
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+)
+
+func newOrder(symbol string) models.Order {
+	return models.Order{Symbol: symbol, Quantity: 1, Price: 100, Side: models.SideBuy,
+		Type: models.LimitOrder, Status: models.OrderStatusPending}
+}
+
+func TestSnapshotCompactsSegments(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := Recover(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := repo.CreateOrder(newOrder("NSE:INFY"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	second, err := repo.CreateOrder(newOrder("NSE:TCS"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.UpdateOrderStatus(first.ID, models.OrderStatusCancelled); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	segments, err := Segments(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) != 1 || filepath.Base(segments[0]) != activeFile {
+		t.Fatalf("segments after snapshot: %v, want only the active journal", segments)
+	}
+
+	recovered, err := Recover(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer recovered.Close()
+	got, err := recovered.GetOrder(first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != models.OrderStatusCancelled {
+		t.Errorf("first order status %s, want cancelled", got.Status)
+	}
+	if _, err := recovered.GetOrder(second.ID); err != nil {
+		t.Errorf("second order: %v", err)
+	}
+}
+
+func TestFailedAppendLeavesMemoryUnchanged(t *testing.T) {
+	repo, err := Recover(t.TempDir(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	order, err := repo.CreateOrder(newOrder("NSE:INFY"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.CreatePosition(models.Position{ID: "pos-1", OrderID: order.ID, Symbol: "NSE:INFY", Quantity: 1, EntryPrice: 100}); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := repo.PendingOutbox(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every append fails once the journal file is closed
+	repo.journal.file.Close()
+
+	if _, err := repo.CreateOrder(newOrder("NSE:TCS")); err == nil {
+		t.Error("create order succeeded without a journal")
+	}
+	if err := repo.UpdateOrderStatus(order.ID, models.OrderStatusCancelled); err == nil {
+		t.Error("status update succeeded without a journal")
+	}
+	if err := repo.ClosePosition("pos-1"); err == nil {
+		t.Error("close position succeeded without a journal")
+	}
+
+	orders, err := repo.GetOrders(repository.OrderFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 1 || orders[0].Status != models.OrderStatusPending {
+		t.Errorf("orders after failed appends: %+v, want the one pending order", orders)
+	}
+	if _, err := repo.GetPosition("pos-1"); err != nil {
+		t.Errorf("position after failed close: %v", err)
+	}
+	after, err := repo.PendingOutbox(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(after) != len(pending) {
+		t.Errorf("outbox grew from %d to %d messages on failed appends", len(pending), len(after))
+	}
+}
+
+func TestRecoverKeepsUndeliveredEvents(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := Recover(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	order, err := repo.CreateOrder(newOrder("NSE:INFY"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.TakeSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.UpdateOrderStatus(order.ID, models.OrderStatusCancelled); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.CreateOrder(newOrder("NSE:TCS")); err != nil {
+		t.Fatal(err)
+	}
+	before, err := repo.PendingOutbox(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(before) != 3 {
+		t.Fatalf("%d pending messages, want 3", len(before))
+	}
+	// Deliver the first event and give up on the second
+	if err := repo.MarkOutboxSent(before[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.MarkOutboxDead(before[1].ID, errors.New("refused")); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	recovered, err := Recover(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer recovered.Close()
+	after, err := recovered.PendingOutbox(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(after) != 1 || after[0].ID != before[2].ID || string(after[0].Payload) != string(before[2].Payload) {
+		t.Fatalf("pending after recovery: %+v, want only message %d unchanged", after, before[2].ID)
+	}
+	dead, err := recovered.DeadOutbox(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dead) != 1 || dead[0].ID != before[1].ID || dead[0].LastError != "refused" {
+		t.Errorf("dead letters after recovery: %+v", dead)
+	}
+
+	// New events continue the outbox IDs rather than reusing them
+	if _, err := recovered.CreateOrder(newOrder("NSE:WIPRO")); err != nil {
+		t.Fatal(err)
+	}
+	latest, err := recovered.PendingOutbox(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(latest) != 2 || latest[1].ID <= before[2].ID {
+		t.Errorf("outbox after a new order: %+v", latest)
+	}
+}
@@ -0,0 +1,91 @@
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+)
+
+// Snapshot is the repository state as of a journal sequence number. Replay
+// starts from the latest snapshot and applies only later records.
+type Snapshot struct {
+	Sequence uint64
+	TakenAt  time.Time
+	State    repository.Snapshot
+}
+
+// snapshotFileContent is the on-disk form, checksummed over the encoded state
+type snapshotFileContent struct {
+	Sequence uint64          `json:"seq"`
+	TakenAt  time.Time       `json:"taken_at"`
+	Checksum uint32          `json:"checksum"`
+	State    json.RawMessage `json:"state"`
+}
+
+// WriteSnapshot atomically replaces the snapshot in dir
+func WriteSnapshot(dir string, snapshot Snapshot) error {
+	state, err := json.Marshal(snapshot.State)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(snapshotFileContent{
+		Sequence: snapshot.Sequence,
+		TakenAt:  snapshot.TakenAt,
+		Checksum: crc32.Checksum(state, crcTable),
+		State:    state,
+	})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, snapshotFile+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, snapshotFile))
+}
+
+// ReadSnapshot loads the snapshot in dir. A missing snapshot is not an error;
+// the zero Snapshot is returned and replay starts from the first record.
+func ReadSnapshot(dir string) (Snapshot, error) {
+	var snapshot Snapshot
+	data, err := os.ReadFile(filepath.Join(dir, snapshotFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return snapshot, nil
+		}
+		return snapshot, err
+	}
+
+	var content snapshotFileContent
+	if err := json.Unmarshal(data, &content); err != nil {
+		return snapshot, fmt.Errorf("decoding snapshot: %w", ErrCorrupt)
+	}
+	if crc32.Checksum(content.State, crcTable) != content.Checksum {
+		return snapshot, fmt.Errorf("snapshot checksum mismatch: %w", ErrCorrupt)
+	}
+	if err := json.Unmarshal(content.State, &snapshot.State); err != nil {
+		return snapshot, fmt.Errorf("decoding snapshot state: %w", ErrCorrupt)
+	}
+	snapshot.Sequence = content.Sequence
+	snapshot.TakenAt = content.TakenAt
+	return snapshot, nil
+}
@@ -1,57 +1,66 @@
 package models
 
-import "time"
+import (
+    "time"
 
-// Enums for Order Types, Status, and Trade Strategy
-type OrderType string
-type OrderStatus string
-type TradeStrategy string
+    orderv1 "github.com/Mukilan-T/laabhum-api-go/order/v1"
+)
+
+// Order enums come from the shared order contract, so the OMS and the gateway
+// agree on the wire
+type OrderType = orderv1.OrderType
+type OrderStatus = orderv1.OrderStatus
+type TradeStrategy = orderv1.TradeStrategy
+type ProductType = orderv1.ProductType
+type Segment = orderv1.Segment
+type InstrumentType = orderv1.InstrumentType
+type Side = orderv1.Side
 type PositionStatus string
 
 const (
-    LimitOrder  OrderType = "LIMIT"
-    MarketOrder OrderType = "MARKET"
-    StopOrder   OrderType = "STOP"
-
-    OrderStatusPending   OrderStatus = "pending"
-
-    OrderStatusExecuted  OrderStatus = "executed"
-
-    OrderStatusCancelled OrderStatus = "cancelled"
-
-    OrderStatusDeleted   OrderStatus = "deleted"
-
-    StrategyDayTrading     TradeStrategy = "DAY_TRADING"
-    StrategyPositionTrading TradeStrategy = "POSITION_TRADING"
-    StrategyScalping        TradeStrategy = "SCALPING"
-
-        PositionStatusOpen   PositionStatus = "open"
-
-    PositionStatusClosed PositionStatus = "closed"
-
-    CTCOrderType OrderType = "ctc" // Added CTCOrderType
-
+    LimitOrder  = orderv1.TypeLimit
+    MarketOrder = orderv1.TypeMarket
+    StopOrder   = orderv1.TypeStop
+    CTCOrderType      = orderv1.TypeCTC
+    MultiLegOrderType = orderv1.TypeMultiLeg // Parent of a multi-leg strategy order
+
+    SideBuy  = orderv1.SideBuy
+    SideSell = orderv1.SideSell
+
+    OrderStatusPending   = orderv1.StatusPending
+    OrderStatusExecuted  = orderv1.StatusExecuted
+    OrderStatusCancelled = orderv1.StatusCancelled
+    OrderStatusDeleted   = orderv1.StatusDeleted
+    OrderStatusQueued    = orderv1.StatusQueued   // After-market order waiting for the next open
+    OrderStatusRejected  = orderv1.StatusRejected // Multi-leg parent whose legs were rolled back
+
+    StrategyDayTrading      = orderv1.StrategyDayTrading
+    StrategyPositionTrading = orderv1.StrategyPositionTrading
+    StrategyScalping        = orderv1.StrategyScalping
+
+    ProductMIS  = orderv1.ProductMIS  // Intraday, squared off before market close
+    ProductCNC  = orderv1.ProductCNC  // Delivery, settled into holdings
+    ProductNRML = orderv1.ProductNRML // Carry-forward for derivatives
+
+    SegmentEquity    = orderv1.SegmentEquity
+    SegmentFNO       = orderv1.SegmentFNO
+    SegmentCurrency  = orderv1.SegmentCurrency
+    SegmentCommodity = orderv1.SegmentCommodity
+
+    InstrumentEquity = orderv1.InstrumentEquity
+    InstrumentFuture = orderv1.InstrumentFuture
+    InstrumentCall   = orderv1.InstrumentCall
+    InstrumentPut    = orderv1.InstrumentPut
 )
 
-// Order represents a general order with advanced trading attributes
-type Order struct {
-    ID            string        `json:"id"`
-    Symbol        string        `json:"symbol"`
-    Quantity      int           `json:"quantity"`
-    Price         float64       `json:"price"`
-    Side          string        `json:"side"` // "buy" or "sell"
-    Type          OrderType     `json:"type"` // LIMIT, MARKET, STOP
-    Status        OrderStatus   `json:"status"` // PENDING, EXECUTED, CANCELLED
-    StopPrice     float64       `json:"stop_price,omitempty"` // Stop Order Price (optional)
-    Strategy      TradeStrategy `json:"strategy"` // Trading strategy (e.g. scalping, day trading)
-    RiskPercentage float64      `json:"risk_percentage"` // % of capital risked
-    StopLossActivated bool // Add this field
-    TakeProfit    float64       `json:"take_profit"` // Take profit level
-    CreatedAt     int64         `json:"created_at"` // Timestamp for when the order is created
-    ExpiresAt     time.Time     `json:"expires_at,omitempty"` // Optional expiry time for order
-        ParentID  string // Add ParentID field
+const (
+    PositionStatusOpen    PositionStatus = "open"
+    PositionStatusClosed  PositionStatus = "closed"
+    PositionStatusExpired PositionStatus = "expired" // Contract expired while the position was open
+)
 
-}
+// Order is the order of the shared contract
+type Order = orderv1.Order
 
 // Position represents an open position in the market
 type Position struct {
@@ -64,11 +73,60 @@ type Position struct {
     StopLoss      float64       `json:"stop_loss"` // Dynamic stop-loss for trailing or fixed SL
     TakeProfit    float64       `json:"take_profit"` // Profit level to auto-close
     Strategy      TradeStrategy `json:"strategy"` // Associated trading strategy
+    Product       ProductType   `json:"product"` // MIS, CNC or NRML
+    Contract      *Contract     `json:"contract,omitempty"` // Futures and options terms
     OpenedAt      time.Time     `json:"opened_at"` // Time when the position was opened
     LastUpdatedAt time.Time     `json:"last_updated_at"` // Last update timestamp for price/stop loss
         Status       string // Add this line
+    UserID        string        `json:"user_id,omitempty"` // Who placed the order that opened the position
+    AccountID     string        `json:"account_id,omitempty"` // Account the position is held in
+
+}
+
+// Contract holds the terms of a futures or options contract
+type Contract = orderv1.Contract
+
+// StrategyLeg is one leg of a multi-leg order. Its quantity is the parent's
+// quantity multiplied by Ratio.
+type StrategyLeg struct {
+    Symbol string    `json:"symbol"`
+    Side   Side      `json:"side"` // "buy" or "sell"
+    Ratio  int       `json:"ratio"`
+    Type   OrderType `json:"type"` // LIMIT or MARKET
+    Price  float64   `json:"price"`
+}
 
+// MultiLegOrder places two to four legs (straddles, strangles, spreads) as one unit
+type MultiLegOrder struct {
+    Name     string        `json:"name"` // e.g. "straddle", "bull call spread"
+    Quantity int           `json:"quantity"` // Units per ratio, usually one lot
+    Product  ProductType   `json:"product"`
+    Strategy TradeStrategy `json:"strategy"`
+    Legs     []StrategyLeg `json:"legs"`
 }
+
+// LegPosition is the signed net quantity a multi-leg order holds in one symbol
+type LegPosition struct {
+    Symbol   string `json:"symbol"`
+    Quantity int    `json:"quantity"` // Positive long, negative short
+}
+
+// MultiLegSummary reports a multi-leg parent with its legs, net premium and combined position
+type MultiLegSummary struct {
+    Parent     Order         `json:"parent"`
+    Legs       []Order       `json:"legs"`
+    NetPremium float64       `json:"net_premium"` // Positive when the structure is a net credit
+    Position   []LegPosition `json:"position"`
+}
+
+// PositionConversion moves all or part of an open position to another product type
+type PositionConversion struct {
+    PositionID  string      `json:"position_id"`
+    FromProduct ProductType `json:"from_product"`
+    ToProduct   ProductType `json:"to_product"`
+    Quantity    int         `json:"quantity"` // 0 converts the whole position
+}
+
 type CTCOrder struct {
 
     ID        string  `json:"id"`
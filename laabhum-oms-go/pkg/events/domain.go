@@ -0,0 +1,212 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/google/uuid"
+)
+
+// SchemaVersion is the version of the domain event envelope and payloads.
+// Additive changes keep the version; renaming or removing a field bumps it.
+const SchemaVersion = 1
+
+// Kafka topics domain events are published to, keyed by symbol so every event
+// for an instrument lands on the same partition in order
+const (
+	TopicOrders    = "oms.orders.v1"
+	TopicTrades    = "oms.trades.v1"
+	TopicPositions = "oms.positions.v1"
+	TopicTicks     = "oms.ticks.v1"
+)
+
+// Domain event types
+const (
+	OrderCreated    = "order.created"
+	OrderUpdated    = "order.updated"
+	OrderExecuted   = "order.executed"
+	OrderCancelled  = "order.cancelled"
+	OrderRejected   = "order.rejected"
+	TradeExecuted   = "trade.executed"
+	PositionOpened  = "position.opened"
+	PositionUpdated = "position.updated"
+	PositionClosed  = "position.closed"
+	Tick            = "tick"
+)
+
+// DomainEvent is the envelope every order, trade and position event is published in
+type DomainEvent struct {
+	SchemaVersion int             `json:"schema_version"`
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	Topic         string          `json:"-"`
+	Symbol        string          `json:"symbol"`
+	UserID        string          `json:"user_id,omitempty"` // Who owns the order or position; consumers show events only to them
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// OrderPayload is the data of order.* events
+type OrderPayload struct {
+	OrderID   string             `json:"order_id"`
+	ParentID  string             `json:"parent_id,omitempty"`
+	Symbol    string             `json:"symbol"`
+	Side      models.Side        `json:"side"`
+	Type      models.OrderType   `json:"type"`
+	Product   models.ProductType `json:"product,omitempty"`
+	Segment   models.Segment     `json:"segment,omitempty"`
+	Quantity  int                `json:"quantity"`
+	Price     float64            `json:"price"`
+	StopPrice float64            `json:"stop_price,omitempty"`
+	Status    models.OrderStatus `json:"status"`
+	Previous  models.OrderStatus `json:"previous_status,omitempty"`
+	Strategy  string             `json:"strategy,omitempty"`
+	CreatedAt int64              `json:"created_at"`
+}
+
+// TradePayload is the data of trade.executed events
+type TradePayload struct {
+	OrderID  string      `json:"order_id"`
+	ParentID string      `json:"parent_id,omitempty"`
+	Symbol   string      `json:"symbol"`
+	Side     models.Side `json:"side"`
+	Quantity int         `json:"quantity"`
+	Price    float64     `json:"price"`
+}
+
+// PositionPayload is the data of position.* events
+type PositionPayload struct {
+	PositionID   string             `json:"position_id"`
+	OrderID      string             `json:"order_id"`
+	Symbol       string             `json:"symbol"`
+	Quantity     int                `json:"quantity"`
+	EntryPrice   float64            `json:"entry_price"`
+	CurrentPrice float64            `json:"current_price"`
+	Product      models.ProductType `json:"product,omitempty"`
+	Status       string             `json:"status,omitempty"`
+}
+
+// TickPayload is the data of tick events
+type TickPayload struct {
+	Symbol     string    `json:"symbol"`
+	Price      float64   `json:"price"`
+	Volume     int       `json:"volume"`
+	Volatility float64   `json:"volatility,omitempty"`
+	Trend      string    `json:"trend,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+func newDomainEvent(eventType, topic, symbol, userID string, payload interface{}) (DomainEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return DomainEvent{}, err
+	}
+	return DomainEvent{
+		SchemaVersion: SchemaVersion,
+		ID:            uuid.NewString(),
+		Type:          eventType,
+		Topic:         topic,
+		Symbol:        symbol,
+		UserID:        userID,
+		OccurredAt:    time.Now().UTC(),
+		Data:          data,
+	}, nil
+}
+
+// OrderEvents describes an order change as domain events. previous is nil for
+// a new order. An order reaching executed also produces a trade for its fill.
+func OrderEvents(previous *models.Order, current models.Order) ([]DomainEvent, error) {
+	payload := OrderPayload{
+		OrderID:   current.ID,
+		ParentID:  current.ParentID,
+		Symbol:    current.Symbol,
+		Side:      current.Side,
+		Type:      current.Type,
+		Product:   current.Product,
+		Segment:   current.Segment,
+		Quantity:  current.Quantity,
+		Price:     current.Price,
+		StopPrice: current.StopPrice,
+		Status:    current.Status,
+		Strategy:  string(current.Strategy),
+		CreatedAt: current.CreatedAt,
+	}
+
+	var types []string
+	if previous == nil {
+		types = append(types, OrderCreated)
+	} else {
+		payload.Previous = previous.Status
+	}
+	if previous == nil || previous.Status != current.Status {
+		switch current.Status {
+		case models.OrderStatusExecuted:
+			types = append(types, OrderExecuted)
+		case models.OrderStatusCancelled:
+			types = append(types, OrderCancelled)
+		case models.OrderStatusRejected:
+			types = append(types, OrderRejected)
+		default:
+			if previous != nil {
+				types = append(types, OrderUpdated)
+			}
+		}
+	} else {
+		types = append(types, OrderUpdated)
+	}
+
+	var out []DomainEvent
+	for _, eventType := range types {
+		event, err := newDomainEvent(eventType, TopicOrders, current.Symbol, current.UserID, payload)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, event)
+	}
+
+	// Parent orders of multi-leg strategies are never filled themselves
+	filled := current.Status == models.OrderStatusExecuted && (previous == nil || previous.Status != current.Status)
+	if filled && current.Type != models.MultiLegOrderType {
+		trade, err := newDomainEvent(TradeExecuted, TopicTrades, current.Symbol, current.UserID, TradePayload{
+			OrderID:  current.ID,
+			ParentID: current.ParentID,
+			Symbol:   current.Symbol,
+			Side:     current.Side,
+			Quantity: current.Quantity,
+			Price:    current.Price,
+		})
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, trade)
+	}
+	return out, nil
+}
+
+// PositionEvent describes a position change as a domain event
+func PositionEvent(eventType string, position models.Position) (DomainEvent, error) {
+	return newDomainEvent(eventType, TopicPositions, position.Symbol, position.UserID, PositionPayload{
+		PositionID:   position.ID,
+		OrderID:      position.OrderID,
+		Symbol:       position.Symbol,
+		Quantity:     position.Quantity,
+		EntryPrice:   position.EntryPrice,
+		CurrentPrice: position.CurrentPrice,
+		Product:      position.Product,
+		Status:       string(position.Status),
+	})
+}
+
+// TickEvent describes a market price update as a domain event. Ticks are
+// published on the in-process bus only; they are not written to the outbox.
+func TickEvent(condition models.MarketCondition) (DomainEvent, error) {
+	return newDomainEvent(Tick, TopicTicks, condition.Symbol, "", TickPayload{
+		Symbol:     condition.Symbol,
+		Price:      condition.Price,
+		Volume:     condition.Volume,
+		Volatility: condition.Volatility,
+		Trend:      condition.Trend,
+		Timestamp:  condition.Timestamp,
+	})
+}
@@ -0,0 +1,125 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Event types published by the OMS
+const (
+	SquareOffWarning   = "squareoff.warning"
+	SquareOffCompleted = "squareoff.completed"
+	AMOReleased        = "amo.released"
+	ExpiryProcessed    = "expiry.processed"
+)
+
+// Event is a single notification on the OMS event stream
+type Event struct {
+	Sequence  uint64      `json:"sequence"`
+	Type      string      `json:"type"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// DefaultHistory is how many recent events a Bus keeps for consumers resuming after a disconnect
+const DefaultHistory = 4096
+
+// Publisher accepts events for delivery to interested consumers
+type Publisher interface {
+	Publish(eventType string, data interface{}) error
+}
+
+// Stream is a Publisher that consumers can also follow and resume
+type Stream interface {
+	Publisher
+	Subscribe(buffer int) chan Event
+	Unsubscribe(ch chan Event)
+	Since(sequence uint64) ([]Event, bool)
+}
+
+// Bus is an in-process publisher that fans events out to subscribers
+type Bus struct {
+	mutex       sync.RWMutex
+	sequence    uint64
+	subscribers map[chan Event]struct{}
+	history     []Event
+	limit       int
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		subscribers: make(map[chan Event]struct{}),
+		limit:       DefaultHistory,
+	}
+}
+
+// Publish stamps the event with the next sequence number and delivers it to every
+// subscriber. Subscribers that are not keeping up miss the event rather than
+// blocking the publisher.
+func (b *Bus) Publish(eventType string, data interface{}) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.sequence++
+	event := Event{
+		Sequence:  b.sequence,
+		Type:      eventType,
+		Timestamp: time.Now(),
+		Data:      data,
+	}
+	b.history = append(b.history, event)
+	if len(b.history) >= 2*b.limit {
+		// Trim in batches, copying so the dropped events can be collected
+		b.history = append([]Event(nil), b.history[len(b.history)-b.limit:]...)
+	}
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe returns a channel receiving every event published from now on
+func (b *Bus) Subscribe(buffer int) chan Event {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	ch := make(chan Event, buffer)
+	b.subscribers[ch] = struct{}{}
+	return ch
+}
+
+// Unsubscribe stops delivery to ch and closes it
+func (b *Bus) Unsubscribe(ch chan Event) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if _, ok := b.subscribers[ch]; ok {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+// Since returns the retained events after sequence. The second result is false
+// when events after sequence have already been dropped from the history, or
+// sequence is beyond anything this bus has published (it was restarted), in
+// which case the caller has missed events and must resynchronise.
+func (b *Bus) Since(sequence uint64) ([]Event, bool) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	if sequence > b.sequence {
+		return nil, false
+	}
+	if sequence == b.sequence {
+		return nil, true
+	}
+	complete := len(b.history) > 0 && b.history[0].Sequence <= sequence+1
+	start := 0
+	for start < len(b.history) && b.history[start].Sequence <= sequence {
+		start++
+	}
+	return append([]Event(nil), b.history[start:]...), complete
+}
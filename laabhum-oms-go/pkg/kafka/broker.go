@@ -0,0 +1,81 @@
+package kafka
+
+import (
+	"errors"
+	"hash/fnv"
+	"sync"
+
+	"github.com/IBM/sarama"
+)
+
+// ErrBrokerDown is returned by a MemoryBroker taken down with SetDown
+var ErrBrokerDown = errors.New("kafka: broker unavailable")
+
+// MemoryBroker is an in-process stand-in for a Kafka cluster. It keeps every
+// message in per-topic partitions chosen by key hash, the way the real
+// producer's hash partitioner does, and can be taken down to exercise retries.
+type MemoryBroker struct {
+	mutex      sync.Mutex
+	partitions int32
+	down       bool
+	topics     map[string][][]*sarama.ProducerMessage
+}
+
+func NewMemoryBroker(partitions int32) *MemoryBroker {
+	if partitions < 1 {
+		partitions = 1
+	}
+	return &MemoryBroker{
+		partitions: partitions,
+		topics:     make(map[string][][]*sarama.ProducerMessage),
+	}
+}
+
+// SetDown makes every send fail until it is called again with false
+func (b *MemoryBroker) SetDown(down bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.down = down
+}
+
+func (b *MemoryBroker) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.down {
+		return 0, 0, ErrBrokerDown
+	}
+
+	partition := int32(0)
+	if msg.Key != nil {
+		key, err := msg.Key.Encode()
+		if err != nil {
+			return 0, 0, err
+		}
+		hash := fnv.New32a()
+		hash.Write(key)
+		partition = int32(hash.Sum32() % uint32(b.partitions))
+	}
+
+	topic, ok := b.topics[msg.Topic]
+	if !ok {
+		topic = make([][]*sarama.ProducerMessage, b.partitions)
+		b.topics[msg.Topic] = topic
+	}
+	msg.Partition = partition
+	msg.Offset = int64(len(topic[partition]))
+	topic[partition] = append(topic[partition], msg)
+	return msg.Partition, msg.Offset, nil
+}
+
+// Messages returns everything published to topic, partition by partition
+func (b *MemoryBroker) Messages(topic string) []*sarama.ProducerMessage {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var messages []*sarama.ProducerMessage
+	for _, partition := range b.topics[topic] {
+		messages = append(messages, partition...)
+	}
+	return messages
+}
@@ -1,21 +1,28 @@
 package kafka
 
 import (
-	"log"
-
 	"github.com/IBM/sarama"
 )
 
-func SetupProducer(brokers []string) sarama.SyncProducer {
+// Sender is the part of sarama.SyncProducer the relay needs; MemoryBroker implements it too
+type Sender interface {
+    SendMessage(msg *sarama.ProducerMessage) (partition int32, offset int64, err error)
+}
+
+// SetupProducer connects a synchronous producer that waits for all in-sync
+// replicas and partitions by key, so events for one symbol stay in order
+func SetupProducer(brokers []string) (sarama.SyncProducer, error) {
     config := sarama.NewConfig()
-    producer, err := sarama.NewSyncProducer(brokers, config)
-    if err != nil {
-        log.Fatalf("Error creating Kafka producer: %v", err)
-    }
-    return producer
+    config.Producer.RequiredAcks = sarama.WaitForAll
+    config.Producer.Return.Successes = true
+    config.Producer.Partitioner = sarama.NewHashPartitioner
+    config.Producer.Idempotent = true
+    config.Net.MaxOpenRequests = 1
+    config.Producer.Retry.Max = 5
+    return sarama.NewSyncProducer(brokers, config)
 }
 
-func SendMessage(producer sarama.SyncProducer, topic string, message string) error {
+func SendMessage(producer Sender, topic string, message string) error {
     msg := &sarama.ProducerMessage{
         Topic: topic,
         Value: sarama.StringEncoder(message),
@@ -23,15 +30,3 @@ func SendMessage(producer sarama.SyncProducer, topic string, message string) err
     _, _, err := producer.SendMessage(msg)
     return err
 }
-
-func PublishOrderCreated(orderID string) {
-    log.Printf("Published 'Order Created' event for order ID: %s", orderID)
-}
-
-func PublishOrderExecuted(orderID string) {
-    log.Printf("Published 'Order Executed' event for order ID: %s", orderID)
-}
-
-func PublishOrderCanceled(orderID string) {
-    log.Printf("Published 'Order Canceled' event for order ID: %s", orderID)
-}
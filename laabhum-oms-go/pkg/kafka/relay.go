@@ -0,0 +1,162 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/events"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+)
+
+const (
+	defaultRelayBatch = 100
+	// DefaultMaxAttempts is how often the broker may refuse a message before
+	// it is dead-lettered
+	DefaultMaxAttempts = 10
+)
+
+// Relay moves domain events from a repository outbox to Kafka. A message is
+// marked sent only after the broker acknowledges it, so events written while
+// Kafka is down are delivered once it is back; consumers must tolerate the
+// occasional duplicate after a crash between send and mark. A message the
+// broker keeps refusing is dead-lettered so it cannot block those after it.
+type Relay struct {
+	logger      *log.Logger
+	outbox      repository.Outbox
+	producer    Sender
+	local       events.Publisher
+	interval    time.Duration
+	batch       int
+	maxAttempts int
+}
+
+func NewRelay(logger *log.Logger, outbox repository.Outbox, producer Sender, interval time.Duration) *Relay {
+	return &Relay{
+		logger:      logger,
+		outbox:      outbox,
+		producer:    producer,
+		interval:    interval,
+		batch:       defaultRelayBatch,
+		maxAttempts: DefaultMaxAttempts,
+	}
+}
+
+// SetMaxAttempts sets how often the broker may refuse a message before it is
+// dead-lettered. Failures to reach the broker at all never count.
+func (r *Relay) SetMaxAttempts(attempts int) {
+	r.maxAttempts = attempts
+}
+
+// SetLocal also publishes every relayed domain event to an in-process
+// publisher, such as the bus streamed to gateway subscribers. With a nil
+// producer the relay only delivers locally.
+func (r *Relay) SetLocal(publisher events.Publisher) {
+	r.local = publisher
+}
+
+// Flush sends pending messages in outbox order until the outbox is drained or a
+// send fails. It stops at the first failure so later events for the same
+// symbol never overtake an earlier one, unless that failure dead-letters the
+// message.
+func (r *Relay) Flush() (int, error) {
+	sent := 0
+	for {
+		messages, err := r.outbox.PendingOutbox(r.batch)
+		if err != nil {
+			return sent, err
+		}
+		if len(messages) == 0 {
+			return sent, nil
+		}
+		for _, message := range messages {
+			if err := r.send(message); err != nil {
+				if !unreachable(err) && message.Attempts+1 >= r.maxAttempts {
+					if err := r.outbox.MarkOutboxDead(message.ID, err); err != nil {
+						return sent, err
+					}
+					r.logger.Printf("Outbox: dead-lettered message %d for %s after %d attempts: %v",
+						message.ID, message.Topic, message.Attempts+1, err)
+					continue
+				}
+				if markErr := r.outbox.MarkOutboxFailed(message.ID, err); markErr != nil {
+					r.logger.Printf("Outbox: recording failure of message %d: %v", message.ID, markErr)
+				}
+				return sent, err
+			}
+			if err := r.outbox.MarkOutboxSent(message.ID); err != nil {
+				return sent, err
+			}
+			r.publishLocal(message)
+			sent++
+		}
+	}
+}
+
+// unreachable reports whether err means the broker could not be reached,
+// rather than that it refused the message, so the outage is waited out
+func unreachable(err error) bool {
+	var netErr net.Error
+	if errors.Is(err, ErrBrokerDown) || errors.As(err, &netErr) {
+		return true
+	}
+	for _, target := range []error{sarama.ErrOutOfBrokers, sarama.ErrNotConnected, sarama.ErrClosedClient,
+		sarama.ErrShuttingDown, sarama.ErrBrokerNotAvailable, sarama.ErrLeaderNotAvailable,
+		sarama.ErrNotLeaderForPartition, sarama.ErrRequestTimedOut, sarama.ErrNetworkException,
+		sarama.ErrNotEnoughReplicas, sarama.ErrNotEnoughReplicasAfterAppend} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Relay) send(message repository.OutboxMessage) error {
+	if r.producer == nil {
+		return nil
+	}
+	_, _, err := r.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: message.Topic,
+		Key:   sarama.StringEncoder(message.Key),
+		Value: sarama.ByteEncoder(message.Payload),
+	})
+	return err
+}
+
+// publishLocal hands a delivered message to the local publisher. A message that
+// cannot be decoded is logged and skipped; it has already reached Kafka.
+func (r *Relay) publishLocal(message repository.OutboxMessage) {
+	if r.local == nil {
+		return
+	}
+	var event events.DomainEvent
+	if err := json.Unmarshal(message.Payload, &event); err != nil {
+		r.logger.Printf("Outbox: decoding message %d for local delivery: %v", message.ID, err)
+		return
+	}
+	event.Topic = message.Topic
+	if err := r.local.Publish(event.Type, event); err != nil {
+		r.logger.Printf("Outbox: publishing message %d locally: %v", message.ID, err)
+	}
+}
+
+// Run flushes the outbox every interval until ctx is cancelled
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.Flush(); err != nil {
+				r.logger.Printf("Outbox relay: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package kafka
+
+import (
+	"errors"
+	"io"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/events"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+)
+
+// recorder is a local publisher keeping the event types it was given
+type recorder struct {
+	mutex sync.Mutex
+	types []string
+}
+
+func (r *recorder) Publish(eventType string, data interface{}) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.types = append(r.types, eventType)
+	return nil
+}
+
+// refusing fails every message for one key the way a broker rejecting it would
+type refusing struct {
+	*MemoryBroker
+	key string
+}
+
+func (r refusing) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	if key, _ := msg.Key.Encode(); string(key) == r.key {
+		return 0, 0, sarama.ErrMessageSizeTooLarge
+	}
+	return r.MemoryBroker.SendMessage(msg)
+}
+
+func newRelay(outbox repository.Outbox, producer Sender) *Relay {
+	return NewRelay(log.New(io.Discard, "", 0), outbox, producer, time.Second)
+}
+
+func createOrder(t *testing.T, repo repository.OrderRepository, symbol string) models.Order {
+	t.Helper()
+	order, err := repo.CreateOrder(models.Order{Symbol: symbol, Quantity: 1, Price: 100, Side: models.SideBuy,
+		Type: models.LimitOrder, Status: models.OrderStatusPending})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return order
+}
+
+func pending(t *testing.T, outbox repository.Outbox) []repository.OutboxMessage {
+	t.Helper()
+	messages, err := outbox.PendingOutbox(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return messages
+}
+
+func TestRelayDeliversInOrder(t *testing.T) {
+	repo := repository.NewInMemoryOrderRepository()
+	broker := NewMemoryBroker(3)
+	local := &recorder{}
+	relay := newRelay(repo, broker)
+	relay.SetLocal(local)
+
+	order := createOrder(t, repo, "NSE:INFY")
+	if err := repo.UpdateOrderStatus(order.ID, models.OrderStatusExecuted); err != nil {
+		t.Fatal(err)
+	}
+
+	sent, err := relay.Flush()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent != 3 {
+		t.Errorf("sent %d messages, want created, executed and trade", sent)
+	}
+	if left := pending(t, repo); len(left) != 0 {
+		t.Errorf("%d messages left in the outbox", len(left))
+	}
+
+	orders := broker.Messages(events.TopicOrders)
+	if len(orders) != 2 || orders[0].Offset != 0 || orders[1].Offset != 1 || orders[0].Partition != orders[1].Partition {
+		t.Fatalf("order topic holds %d messages, want 2 in order on one partition", len(orders))
+	}
+	if trades := broker.Messages(events.TopicTrades); len(trades) != 1 {
+		t.Errorf("trade topic holds %d messages, want 1", len(trades))
+	}
+	want := []string{events.OrderCreated, events.OrderExecuted, events.TradeExecuted}
+	if len(local.types) != len(want) {
+		t.Fatalf("published locally %v, want %v", local.types, want)
+	}
+	for i := range want {
+		if local.types[i] != want[i] {
+			t.Errorf("local event %d is %s, want %s", i, local.types[i], want[i])
+		}
+	}
+}
+
+func TestRelayWaitsOutBrokerOutage(t *testing.T) {
+	repo := repository.NewInMemoryOrderRepository()
+	broker := NewMemoryBroker(1)
+	relay := newRelay(repo, broker)
+	relay.SetMaxAttempts(2)
+
+	createOrder(t, repo, "NSE:INFY")
+	createOrder(t, repo, "NSE:TCS")
+	broker.SetDown(true)
+	for i := 0; i < 5; i++ {
+		if _, err := relay.Flush(); !errors.Is(err, ErrBrokerDown) {
+			t.Fatalf("flush %d: %v, want broker down", i, err)
+		}
+	}
+	left := pending(t, repo)
+	if len(left) != 2 || left[0].Attempts != 5 {
+		t.Fatalf("outbox after outage: %+v, want both messages with the first tried 5 times", left)
+	}
+	if dead, _ := repo.DeadOutbox(0); len(dead) != 0 {
+		t.Errorf("outage dead-lettered %d messages", len(dead))
+	}
+
+	broker.SetDown(false)
+	if sent, err := relay.Flush(); err != nil || sent != 2 {
+		t.Fatalf("flush after outage sent %d: %v", sent, err)
+	}
+	if got := broker.Messages(events.TopicOrders); len(got) != 2 {
+		t.Errorf("broker holds %d messages, want 2", len(got))
+	}
+}
+
+func TestRelayDeadLettersRefusedMessage(t *testing.T) {
+	repo := repository.NewInMemoryOrderRepository()
+	broker := NewMemoryBroker(1)
+	relay := newRelay(repo, refusing{broker, "NSE:BAD"})
+	relay.SetMaxAttempts(3)
+
+	createOrder(t, repo, "NSE:BAD")
+	createOrder(t, repo, "NSE:INFY")
+
+	for i := 0; i < 2; i++ {
+		if _, err := relay.Flush(); !errors.Is(err, sarama.ErrMessageSizeTooLarge) {
+			t.Fatalf("flush %d: %v, want the refusal", i, err)
+		}
+		if got := broker.Messages(events.TopicOrders); len(got) != 0 {
+			t.Fatalf("a later message overtook the refused one on flush %d", i)
+		}
+	}
+	sent, err := relay.Flush()
+	if err != nil || sent != 1 {
+		t.Fatalf("third flush sent %d: %v, want the message after the dead letter", sent, err)
+	}
+
+	dead, err := repo.DeadOutbox(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dead) != 1 || dead[0].Key != "NSE:BAD" || dead[0].Attempts != 3 || dead[0].LastError == "" {
+		t.Fatalf("dead letters: %+v, want the refused message after 3 attempts", dead)
+	}
+	if left := pending(t, repo); len(left) != 0 {
+		t.Errorf("%d messages left in the outbox", len(left))
+	}
+	if got := broker.Messages(events.TopicOrders); len(got) != 1 || string(got[0].Key.(sarama.StringEncoder)) != "NSE:INFY" {
+		t.Errorf("broker holds %d messages, want only NSE:INFY", len(got))
+	}
+}
@@ -3,6 +3,7 @@ package nats
 import (
 	"github.com/nats-io/nats.go"
 	"log"
+	"time"
 )
 
 type NatsClient struct {
@@ -20,6 +21,40 @@ func NewNatsClient(url string) *NatsClient {
 	}
 }
 
+// Connect dials url and reports connection errors instead of swallowing them
+func Connect(url string, options ...nats.Option) (*NatsClient, error) {
+	nc, err := nats.Connect(url, options...)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := nats.NewEncodedConn(nc, nats.JSON_ENCODER)
+	if err != nil {
+		nc.Close()
+		return nil, err
+	}
+	return &NatsClient{conn: nc, ec: ec}, nil
+}
+
+// QueueSubscribe delivers each message on subject to one member of queue
+func (c *NatsClient) QueueSubscribe(subject, queue string, handler nats.MsgHandler) (*nats.Subscription, error) {
+	return c.conn.QueueSubscribe(subject, queue, handler)
+}
+
+// Publish sends v JSON encoded
+func (c *NatsClient) Publish(subject string, v interface{}) error {
+	return c.ec.Publish(subject, v)
+}
+
+// Request sends request JSON encoded and decodes the reply into response
+func (c *NatsClient) Request(subject string, request, response interface{}, timeout time.Duration) error {
+	return c.ec.Request(subject, request, response, timeout)
+}
+
+// Drain lets in-flight messages finish, then closes the connection
+func (c *NatsClient) Drain() error {
+	return c.conn.Drain()
+}
+
 func ConnectNATS(url string) *nats.Conn {
 	conn, err := nats.Connect(url)
 	if err != nil {
@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// migration is one forward-only schema change. Versions must increase and a
+// released migration must never be edited; add a new one instead. check, when
+// set, runs first and fails the migration on data it cannot convert.
+type migration struct {
+	version     int
+	description string
+	check       func(tx *sql.Tx) error
+	statements  []string
+}
+
+var migrations = []migration{
+	{
+		version:     1,
+		description: "create orders, positions, trades and market conditions",
+		statements: []string{
+			`CREATE TABLE orders (
+				id                  TEXT PRIMARY KEY,
+				symbol              TEXT NOT NULL,
+				quantity            INTEGER NOT NULL,
+				price               REAL NOT NULL,
+				side                TEXT NOT NULL DEFAULT '',
+				type                TEXT NOT NULL DEFAULT '',
+				status              TEXT NOT NULL DEFAULT '',
+				stop_price          REAL NOT NULL DEFAULT 0,
+				strategy            TEXT NOT NULL DEFAULT '',
+				product             TEXT NOT NULL DEFAULT '',
+				segment             TEXT NOT NULL DEFAULT '',
+				amo                 INTEGER NOT NULL DEFAULT 0,
+				contract            TEXT NOT NULL DEFAULT '',
+				risk_percentage     REAL NOT NULL DEFAULT 0,
+				stop_loss_activated INTEGER NOT NULL DEFAULT 0,
+				take_profit         REAL NOT NULL DEFAULT 0,
+				created_at          INTEGER NOT NULL,
+				expires_at          TEXT NOT NULL DEFAULT '',
+				parent_id           TEXT NOT NULL DEFAULT ''
+			)`,
+			`CREATE TABLE positions (
+				id              TEXT PRIMARY KEY,
+				order_id        TEXT NOT NULL DEFAULT '',
+				symbol          TEXT NOT NULL,
+				quantity        INTEGER NOT NULL,
+				entry_price     REAL NOT NULL,
+				current_price   REAL NOT NULL DEFAULT 0,
+				stop_loss       REAL NOT NULL DEFAULT 0,
+				take_profit     REAL NOT NULL DEFAULT 0,
+				strategy        TEXT NOT NULL DEFAULT '',
+				product         TEXT NOT NULL DEFAULT '',
+				contract        TEXT NOT NULL DEFAULT '',
+				status          TEXT NOT NULL DEFAULT '',
+				opened_at       TEXT NOT NULL,
+				last_updated_at TEXT NOT NULL
+			)`,
+			`CREATE TABLE trades (
+				id         TEXT PRIMARY KEY,
+				order_id   TEXT NOT NULL,
+				parent_id  TEXT NOT NULL DEFAULT '',
+				symbol     TEXT NOT NULL,
+				quantity   INTEGER NOT NULL,
+				price      REAL NOT NULL,
+				trade_time TEXT NOT NULL
+			)`,
+			`CREATE TABLE scalper_orders (
+				id              TEXT PRIMARY KEY,
+				symbol          TEXT NOT NULL,
+				quantity        INTEGER NOT NULL,
+				price           REAL NOT NULL,
+				stop_loss       REAL NOT NULL,
+				take_profit     REAL NOT NULL,
+				risk_percentage REAL NOT NULL,
+				created_at      INTEGER NOT NULL,
+				expires_at      TEXT NOT NULL DEFAULT '',
+				timestamp       TEXT NOT NULL DEFAULT ''
+			)`,
+			`CREATE TABLE market_conditions (
+				symbol     TEXT PRIMARY KEY,
+				price      REAL NOT NULL,
+				volume     INTEGER NOT NULL,
+				volatility REAL NOT NULL,
+				trend      TEXT NOT NULL DEFAULT '',
+				timestamp  TEXT NOT NULL
+			)`,
+		},
+	},
+	{
+		version:     2,
+		description: "index orders by symbol, status and parent, trades by parent",
+		statements: []string{
+			`CREATE INDEX idx_orders_symbol ON orders(symbol)`,
+			`CREATE INDEX idx_orders_status ON orders(status)`,
+			`CREATE INDEX idx_orders_parent_id ON orders(parent_id)`,
+			`CREATE INDEX idx_trades_parent_id ON trades(parent_id)`,
+			`CREATE INDEX idx_positions_order_id ON positions(order_id)`,
+		},
+	},
+	{
+		version:     3,
+		description: "add transactional outbox for domain events",
+		statements: []string{
+			`CREATE TABLE outbox (
+				id         INTEGER PRIMARY KEY AUTOINCREMENT,
+				topic      TEXT NOT NULL,
+				key        TEXT NOT NULL,
+				payload    BLOB NOT NULL,
+				created_at TEXT NOT NULL,
+				attempts   INTEGER NOT NULL DEFAULT 0,
+				last_error TEXT NOT NULL DEFAULT '',
+				sent_at    TEXT NOT NULL DEFAULT ''
+			)`,
+			`CREATE INDEX idx_outbox_pending ON outbox(sent_at, id)`,
+		},
+	},
+	{
+		version:     4,
+		description: "add client order IDs to orders",
+		statements: []string{
+			`ALTER TABLE orders ADD COLUMN client_order_id TEXT NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		version:     5,
+		description: "add leg indexes to multi-leg children",
+		statements: []string{
+			`ALTER TABLE orders ADD COLUMN leg_index INTEGER NOT NULL DEFAULT 0`,
+		},
+	},
+	{
+		version:     6,
+		description: "store position times as unix nanoseconds so they sort",
+		statements: []string{
+			`CREATE TABLE positions_v6 (
+				id              TEXT PRIMARY KEY,
+				order_id        TEXT NOT NULL DEFAULT '',
+				symbol          TEXT NOT NULL,
+				quantity        INTEGER NOT NULL,
+				entry_price     REAL NOT NULL,
+				current_price   REAL NOT NULL DEFAULT 0,
+				stop_loss       REAL NOT NULL DEFAULT 0,
+				take_profit     REAL NOT NULL DEFAULT 0,
+				strategy        TEXT NOT NULL DEFAULT '',
+				product         TEXT NOT NULL DEFAULT '',
+				contract        TEXT NOT NULL DEFAULT '',
+				status          TEXT NOT NULL DEFAULT '',
+				opened_at       INTEGER NOT NULL,
+				last_updated_at INTEGER NOT NULL
+			)`,
+			// SQLite keeps milliseconds of the RFC3339 times written so far
+			`INSERT INTO positions_v6 SELECT id, order_id, symbol, quantity, entry_price, current_price,
+				stop_loss, take_profit, strategy, product, contract, status,
+				CASE opened_at WHEN '' THEN 0
+					ELSE CAST(ROUND((julianday(opened_at) - 2440587.5) * 86400000) AS INTEGER) * 1000000 END,
+				CASE last_updated_at WHEN '' THEN 0
+					ELSE CAST(ROUND((julianday(last_updated_at) - 2440587.5) * 86400000) AS INTEGER) * 1000000 END
+				FROM positions`,
+			`DROP TABLE positions`,
+			`ALTER TABLE positions_v6 RENAME TO positions`,
+			`CREATE INDEX idx_positions_order_id ON positions(order_id)`,
+			`CREATE INDEX idx_positions_opened_at ON positions(opened_at, id)`,
+		},
+	},
+	{
+		version:     7,
+		description: "delete sent outbox messages and keep undeliverable ones as dead letters",
+		statements: []string{
+			`DELETE FROM outbox WHERE sent_at <> ''`,
+			`CREATE TABLE outbox_dead_letters (
+				id         INTEGER PRIMARY KEY,
+				topic      TEXT NOT NULL,
+				key        TEXT NOT NULL,
+				payload    BLOB NOT NULL,
+				created_at TEXT NOT NULL,
+				attempts   INTEGER NOT NULL,
+				last_error TEXT NOT NULL,
+				dead_at    TEXT NOT NULL
+			)`,
+		},
+	},
+	{
+		version:     8,
+		description: "store the CTC order type in the contract's upper case",
+		statements: []string{
+			`UPDATE orders SET type = 'CTC' WHERE type = 'ctc'`,
+		},
+	},
+	{
+		version:     9,
+		description: "record who placed each order",
+		statements: []string{
+			`ALTER TABLE orders ADD COLUMN user_id TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE orders ADD COLUMN account_id TEXT NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		version:     10,
+		description: "keep client order IDs unique per user and account",
+		check:       checkUniqueClientOrderIDs,
+		statements: []string{
+			`ALTER TABLE orders ADD COLUMN submission_hash TEXT NOT NULL DEFAULT ''`,
+			`CREATE UNIQUE INDEX idx_orders_client_order_id ON orders(user_id, account_id, client_order_id)
+				WHERE client_order_id != ''`,
+		},
+	},
+	{
+		version:     11,
+		description: "record who owns each position",
+		statements: []string{
+			`ALTER TABLE positions ADD COLUMN user_id TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE positions ADD COLUMN account_id TEXT NOT NULL DEFAULT ''`,
+			`UPDATE positions SET
+				user_id = (SELECT user_id FROM orders WHERE orders.id = positions.order_id),
+				account_id = (SELECT account_id FROM orders WHERE orders.id = positions.order_id)
+			WHERE order_id IN (SELECT id FROM orders)`,
+		},
+	},
+}
+
+// migrate brings the schema up to the latest version, applying each pending
+// migration in its own transaction
+func migrate(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version     INTEGER PRIMARY KEY,
+		description TEXT NOT NULL,
+		applied_at  TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return fmt.Errorf("creating schema_migrations: %w", err)
+	}
+
+	var current int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return fmt.Errorf("reading schema version: %w", err)
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if m.check != nil {
+			if err := m.check(tx); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("migration %d (%s): %w", m.version, m.description, err)
+			}
+		}
+		for _, stmt := range m.statements {
+			if _, err := tx.Exec(stmt); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("migration %d (%s): %w", m.version, m.description, err)
+			}
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version, description) VALUES (?, ?)`, m.version, m.description); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("recording migration %d: %w", m.version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("committing migration %d: %w", m.version, err)
+		}
+	}
+	return nil
+}
+
+// checkUniqueClientOrderIDs refuses to index client order IDs that several
+// orders of one user and account share, naming the orders so an operator can
+// decide which keeps the ID
+func checkUniqueClientOrderIDs(tx *sql.Tx) error {
+	rows, err := tx.Query(`SELECT user_id, account_id, client_order_id, GROUP_CONCAT(id, ', ')
+		FROM orders WHERE client_order_id != ''
+		GROUP BY user_id, account_id, client_order_id HAVING COUNT(*) > 1`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	var duplicates []string
+	for rows.Next() {
+		var userID, accountID, clientOrderID, ids string
+		if err := rows.Scan(&userID, &accountID, &clientOrderID, &ids); err != nil {
+			return err
+		}
+		duplicates = append(duplicates, fmt.Sprintf("%q for user %q and account %q by orders %s", clientOrderID, userID, accountID, ids))
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(duplicates) > 0 {
+		return fmt.Errorf("client order IDs used more than once, clear all but one of each: %s", strings.Join(duplicates, "; "))
+	}
+	return nil
+}
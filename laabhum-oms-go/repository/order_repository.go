@@ -3,31 +3,23 @@ package repository
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/events"
 	"github.com/google/uuid"
 )
 
+// ErrNotFound is wrapped by every error reporting a missing order, position
+// or other record, in the form "order not found"
+var ErrNotFound = errors.New("not found")
+
+// ErrDuplicateClientOrderID is returned when an order is created under a
+// client order ID its user and account already placed an order under
+var ErrDuplicateClientOrderID = errors.New("client order ID already used")
 
-type Order struct {
-    ID                string        `json:"id"`
-    Symbol            string        `json:"symbol"`
-    Quantity          int           `json:"quantity"`
-    Price             float64       `json:"price"`
-    Side              string        `json:"side"` // "buy" or "sell"
-    Type              models.OrderType     `json:"type"` // LIMIT, MARKET, STOP
-    Status            models.OrderStatus   `json:"status"` // PENDING, EXECUTED, CANCELLED
-    StopPrice         float64       `json:"stop_price,omitempty"` // Stop Order Price (optional)
-    Strategy          models.TradeStrategy `json:"strategy"` // Trading strategy (e.g. scalping, day trading)
-    RiskPercentage    float64       `json:"risk_percentage"` // % of capital risked
-    StopLossActivated bool          `json:"stop_loss_activated"` // Add this field
-    TakeProfit        float64       `json:"take_profit"` // Take profit level
-    CreatedAt         int64         `json:"created_at"` // Timestamp for when the order is created
-    ExpiresAt         time.Time     `json:"expires_at,omitempty"` // Optional expiry time for order
-    ParentID          string        `json:"parent_id"` // Add ParentID field
-}
 
 func (r *InMemoryOrderRepository) GetTrades(parentID string) ([]models.Trade, error) {
 	trades, ok := r.trades[parentID]
@@ -36,28 +28,31 @@ func (r *InMemoryOrderRepository) GetTrades(parentID string) ([]models.Trade, er
 	}
 	return trades, nil
 }
+// Matches reports whether order passes every field set in the filter.
+// FromDate and ToDate bound its creation time to the second, inclusively.
 func (f OrderFilter) Matches(order models.Order) bool {
-
     if f.Symbol != "" && f.Symbol != order.Symbol {
-
         return false
-
     }
-
     if f.Status != "" && f.Status != order.Status {
-
         return false
-
     }
-
+    if f.Strategy != "" && f.Strategy != order.Strategy {
+        return false
+    }
+    if !f.FromDate.IsZero() && order.CreatedAt < f.FromDate.Unix() {
+        return false
+    }
+    if !f.ToDate.IsZero() && order.CreatedAt > f.ToDate.Unix() {
+        return false
+    }
     if f.ParentID != "" && f.ParentID != order.ParentID {
-
         return false
-
     }
-
+    if f.UserID != "" && f.UserID != order.UserID {
+        return false
+    }
     return true
-
 }
 
 type OrderRepository interface {
@@ -67,29 +62,36 @@ type OrderRepository interface {
     CreatePosition(position models.Position) error
     GetPosition(id string) (*models.Position, error)
     UpdatePosition(position models.Position) error
-    GetOpenPositions() ([]models.Position, error)
+    GetOpenPositions() ([]models.Position, error) // Every position not flagged expired
     ClosePosition(id string) error
     CreateScalperOrder(order models.ScalperOrder) (*models.ScalperOrder, error)
     SaveOrder(order map[string]interface{}) error
     GetTrades(parentID string) ([]models.Trade, error)
     SaveMarketCondition(condition models.MarketCondition) error
     GetLatestMarketCondition(symbol string) (*models.MarketCondition, error)
-    GetOrders(filter OrderFilter) ([]Order, error) // Adjust this based on your actual Order struct
+    GetOrders(filter OrderFilter) ([]models.Order, error)
+    // GetOrderByClientOrderID returns the order a user and account placed
+    // under a client order ID; orders placed without a user have them empty
+    GetOrderByClientOrderID(userID, accountID, clientOrderID string) (*models.Order, error)
     CreateOrder(order models.Order) (models.Order, error)
     ExecuteChildOrder(orderID string) error // Add this method signature
 
     UpdateOrderStatus(id string, status models.OrderStatus) error // Add this method to the interface
 }
 func (r *InMemoryOrderRepository) ExecuteChildOrder(orderID string) error {
+    r.mutex.Lock()
+    defer r.mutex.Unlock()
+
     order, exists := r.orders[orderID]
     if !exists {
-        return fmt.Errorf("order not found")
+        return fmt.Errorf("order %w", ErrNotFound)
     }
+    previous := *order
     // Implement logic for executing child order here
     // For example, update order status to "executed"
     order.Status = "executed"
     r.orders[orderID] = order
-    return nil
+    return r.appendOutbox(OrderOutbox(&previous, *order))
 }
 
 type OrderFilter struct {
@@ -99,6 +101,7 @@ type OrderFilter struct {
     FromDate time.Time
     ToDate   time.Time
     ParentID string // Add ParentID field
+    UserID   string // Orders placed by this user
 }
 
 type InMemoryOrderRepository struct {
@@ -106,6 +109,9 @@ type InMemoryOrderRepository struct {
     positions        map[string]*models.Position
     marketConditions map[string]*models.MarketCondition
     trades           map[string][]models.Trade
+    outbox           map[int64]*OutboxMessage
+    outboxSeq        int64
+    deadLetters      []OutboxMessage
     mutex            sync.RWMutex
     StopLossActivated bool
 }
@@ -116,6 +122,7 @@ func NewInMemoryOrderRepository() *InMemoryOrderRepository {
         positions:        make(map[string]*models.Position),
         marketConditions: make(map[string]*models.MarketCondition),
         trades:           make(map[string][]models.Trade),
+        outbox:           make(map[int64]*OutboxMessage),
     }
 }
 
@@ -132,6 +139,11 @@ func (r *InMemoryOrderRepository) SaveOrder(order map[string]interface{}) error
     // Assuming order is a map with string keys and interface{} values
     // You might need to convert this map to your Order struct
     // This is just a placeholder implementation
+    var previous *models.Order
+    if existing, exists := r.orders[id]; exists {
+        copied := *existing
+        previous = &copied
+    }
     r.orders[id] = &models.Order{
         ID:        id,
         Symbol:    order["Symbol"].(string),
@@ -140,16 +152,22 @@ func (r *InMemoryOrderRepository) SaveOrder(order map[string]interface{}) error
         CreatedAt: time.Now().Unix(),
     }
 
-    return nil
+    return r.appendOutbox(OrderOutbox(previous, *r.orders[id]))
 }
 func (repo *InMemoryOrderRepository) CreateOrder(order models.Order) (models.Order, error) {
     repo.mutex.Lock()
     defer repo.mutex.Unlock()
 
+    if order.ClientOrderID != "" && repo.byClientOrderID(order.UserID, order.AccountID, order.ClientOrderID) != nil {
+        return models.Order{}, ErrDuplicateClientOrderID
+    }
     if order.ID == "" {
         order.ID = uuid.New().String()
     }
     order.CreatedAt = time.Now().Unix()
+    if err := repo.appendOutbox(OrderOutbox(nil, order)); err != nil {
+        return models.Order{}, err
+    }
     repo.orders[order.ID] = &order // Keep the order in the map as a pointer, but return as a value
 
     return order, nil // Return the order as a value, not a pointer
@@ -169,70 +187,89 @@ func (r *InMemoryOrderRepository) GetOrder(id string) (*models.Order, error) {
 
     order, exists := r.orders[id]
     if !exists {
-        return nil, errors.New("order not found")
+        return nil, fmt.Errorf("order %w", ErrNotFound)
     }
-    return order, nil
+    copied := *order // Callers must not change the stored order behind the lock
+    return &copied, nil
+}
+
+func (r *InMemoryOrderRepository) GetOrderByClientOrderID(userID, accountID, clientOrderID string) (*models.Order, error) {
+    r.mutex.RLock()
+    defer r.mutex.RUnlock()
+
+    order := r.byClientOrderID(userID, accountID, clientOrderID)
+    if order == nil {
+        return nil, fmt.Errorf("order %w", ErrNotFound)
+    }
+    copied := *order
+    return &copied, nil
+}
+
+// byClientOrderID finds an order by its owner and client order ID; callers hold the mutex
+func (r *InMemoryOrderRepository) byClientOrderID(userID, accountID, clientOrderID string) *models.Order {
+    for _, order := range r.orders {
+        if order.ClientOrderID == clientOrderID && order.UserID == userID && order.AccountID == accountID {
+            return order
+        }
+    }
+    return nil
 }
 
 func (r *InMemoryOrderRepository) UpdateOrder(order models.Order) error {
     r.mutex.Lock()
     defer r.mutex.Unlock()
 
-    if _, exists := r.orders[order.ID]; !exists {
-        return errors.New("order not found")
+    previous, exists := r.orders[order.ID]
+    if !exists {
+        return fmt.Errorf("order %w", ErrNotFound)
+    }
+    if err := r.appendOutbox(OrderOutbox(previous, order)); err != nil {
+        return err
     }
     r.orders[order.ID] = &order
     return nil
 }
-func (r *InMemoryOrderRepository) GetOrders(filter OrderFilter) ([]Order, error) {
+func (r *InMemoryOrderRepository) GetOrders(filter OrderFilter) ([]models.Order, error) {
     r.mutex.RLock()
     defer r.mutex.RUnlock()
 
-    var orders []Order
+    var orders []models.Order
     for _, order := range r.orders {
         if filter.Matches(*order) {
-            orders = append(orders, Order{
-                ID:                order.ID,
-                Symbol:            order.Symbol,
-                Quantity:          order.Quantity,
-                Price:             order.Price,
-                Type:              order.Type,
-                Status:            order.Status, // Ensure models.OrderStatus is used directly
-                CreatedAt:         order.CreatedAt,
-                StopLossActivated: order.StopLossActivated, // Ensure models.Order has StopLossActivated field
-                ParentID:          order.ParentID,
-            })
+            orders = append(orders, *order)
         }
     }
     return orders, nil
 }
 
 
-func (r *InMemoryOrderRepository) orderMatchesFilter(order *models.Order, filter OrderFilter) bool {
-    if filter.Symbol != "" && order.Symbol != filter.Symbol {
-        return false
-    }
-    if filter.Status != "" && order.Status != filter.Status {
-        return false
-    }
-    if filter.Strategy != "" && order.Strategy != filter.Strategy {
-        return false
-    }
-    if !filter.FromDate.IsZero() && time.Unix(order.CreatedAt, 0).Before(filter.FromDate) {
-        return false
-    }
-    if !filter.ToDate.IsZero() && time.Unix(order.CreatedAt, 0).After(filter.ToDate) {
-        return false
+// Backends accepted by Open
+const (
+    BackendMemory = "memory"
+    BackendSQLite = "sqlite"
+)
+
+// Open creates the OrderRepository for backend. dsn is ignored by the in-memory store.
+func Open(backend, dsn string) (OrderRepository, error) {
+    switch backend {
+    case "", BackendMemory:
+        return NewInMemoryOrderRepository(), nil
+    case BackendSQLite:
+        if dsn == "" {
+            return nil, errors.New("sqlite repository needs a DSN")
+        }
+        return NewSQLiteOrderRepository(dsn)
     }
-    return true
+    return nil, fmt.Errorf("unknown repository backend %q", backend)
 }
 
+
 func (r *InMemoryOrderRepository) DeleteOrder(id string) error {
     r.mutex.Lock()
     defer r.mutex.Unlock()
 
     if _, exists := r.orders[id]; !exists {
-        return errors.New("order not found")
+        return fmt.Errorf("order %w", ErrNotFound)
     }
     delete(r.orders, id)
     return nil
@@ -247,6 +284,9 @@ func (r *InMemoryOrderRepository) CreatePosition(position models.Position) error
     }
     position.OpenedAt = time.Now()
     position.LastUpdatedAt = time.Now()
+    if err := r.appendOutbox(PositionOutbox(events.PositionOpened, position)); err != nil {
+        return err
+    }
     r.positions[position.ID] = &position
     return nil
 }
@@ -257,9 +297,10 @@ func (r *InMemoryOrderRepository) GetPosition(id string) (*models.Position, erro
 
     position, exists := r.positions[id]
     if !exists {
-        return nil, errors.New("position not found")
+        return nil, fmt.Errorf("position %w", ErrNotFound)
     }
-    return position, nil
+    copied := *position
+    return &copied, nil
 }
 
 func (r *InMemoryOrderRepository) UpdatePosition(position models.Position) error {
@@ -267,9 +308,12 @@ func (r *InMemoryOrderRepository) UpdatePosition(position models.Position) error
     defer r.mutex.Unlock()
 
     if _, exists := r.positions[position.ID]; !exists {
-        return errors.New("position not found")
+        return fmt.Errorf("position %w", ErrNotFound)
     }
     position.LastUpdatedAt = time.Now()
+    if err := r.appendOutbox(PositionOutbox(events.PositionUpdated, position)); err != nil {
+        return err
+    }
     r.positions[position.ID] = &position
     return nil
 }
@@ -280,8 +324,18 @@ func (r *InMemoryOrderRepository) GetOpenPositions() ([]models.Position, error)
 
     var positions []models.Position
     for _, position := range r.positions {
+        if position.Status == string(models.PositionStatusExpired) {
+            continue
+        }
         positions = append(positions, *position)
     }
+    // Oldest first, as the SQLite store returns them
+    sort.Slice(positions, func(i, j int) bool {
+        if !positions[i].OpenedAt.Equal(positions[j].OpenedAt) {
+            return positions[i].OpenedAt.Before(positions[j].OpenedAt)
+        }
+        return positions[i].ID < positions[j].ID
+    })
     return positions, nil
 }
 
@@ -291,7 +345,10 @@ func (r *InMemoryOrderRepository) ClosePosition(id string) error {
 
     position, exists := r.positions[id]
     if !exists {
-        return errors.New("position not found")
+        return fmt.Errorf("position %w", ErrNotFound)
+    }
+    if err := r.appendOutbox(PositionOutbox(events.PositionClosed, *position)); err != nil {
+        return err
     }
     delete(r.positions, id)
     
@@ -315,9 +372,10 @@ func (r *InMemoryOrderRepository) GetLatestMarketCondition(symbol string) (*mode
 
     condition, exists := r.marketConditions[symbol]
     if !exists {
-        return nil, errors.New("market condition not found for symbol")
+        return nil, fmt.Errorf("market condition %w for symbol", ErrNotFound)
     }
-    return condition, nil
+    copied := *condition
+    return &copied, nil
 }
 
 func (r *InMemoryOrderRepository) UpdateOrderStatus(id string, status models.OrderStatus) error {
@@ -326,7 +384,13 @@ func (r *InMemoryOrderRepository) UpdateOrderStatus(id string, status models.Ord
 
     order, exists := r.orders[id]
     if !exists {
-        return errors.New("order not found")
+        return fmt.Errorf("order %w", ErrNotFound)
+    }
+    previous := *order
+    updated := *order
+    updated.Status = status
+    if err := r.appendOutbox(OrderOutbox(&previous, updated)); err != nil {
+        return err
     }
     order.Status = status
     return nil
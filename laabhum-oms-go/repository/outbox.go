@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/events"
+)
+
+// OutboxMessage is a domain event stored alongside the change that caused it,
+// waiting to be relayed to the message broker
+type OutboxMessage struct {
+	ID        int64     `json:"id"`
+	Topic     string    `json:"topic"`
+	Key       string    `json:"key"`
+	Payload   []byte    `json:"payload"`
+	CreatedAt time.Time `json:"created_at"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error,omitempty"`
+}
+
+// Outbox is implemented by repositories that record domain events in the same
+// transaction as the order and position changes they describe
+type Outbox interface {
+	// PendingOutbox returns up to limit unsent messages, oldest first
+	PendingOutbox(limit int) ([]OutboxMessage, error)
+	// MarkOutboxSent deletes a delivered message
+	MarkOutboxSent(id int64) error
+	MarkOutboxFailed(id int64, cause error) error
+	// MarkOutboxDead moves a message that will never be delivered out of the
+	// pending queue and into the dead letters, recording why
+	MarkOutboxDead(id int64, cause error) error
+	// DeadOutbox returns up to limit dead letters, oldest first
+	DeadOutbox(limit int) ([]OutboxMessage, error)
+}
+
+func toOutboxMessages(domainEvents []events.DomainEvent) ([]OutboxMessage, error) {
+	messages := make([]OutboxMessage, 0, len(domainEvents))
+	for _, event := range domainEvents {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, OutboxMessage{
+			Topic:     event.Topic,
+			Key:       event.Symbol,
+			Payload:   payload,
+			CreatedAt: event.OccurredAt,
+		})
+	}
+	return messages, nil
+}
+
+// OrderOutbox builds the outbox messages for an order change; previous is nil for a new order
+func OrderOutbox(previous *models.Order, current models.Order) ([]OutboxMessage, error) {
+	domainEvents, err := events.OrderEvents(previous, current)
+	if err != nil {
+		return nil, err
+	}
+	return toOutboxMessages(domainEvents)
+}
+
+// PositionOutbox builds the outbox message for a position event
+func PositionOutbox(eventType string, position models.Position) ([]OutboxMessage, error) {
+	event, err := events.PositionEvent(eventType, position)
+	if err != nil {
+		return nil, err
+	}
+	return toOutboxMessages([]events.DomainEvent{event})
+}
+
+// QueueOutbox queues messages built for a change applied with PutOrder,
+// PutPosition or the other methods that store state exactly as given
+func (r *InMemoryOrderRepository) QueueOutbox(messages []OutboxMessage) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.appendOutbox(messages, nil)
+}
+
+// appendOutbox queues messages; the caller holds the write lock
+func (r *InMemoryOrderRepository) appendOutbox(messages []OutboxMessage, err error) error {
+	if err != nil {
+		return err
+	}
+	for _, message := range messages {
+		r.outboxSeq++
+		message.ID = r.outboxSeq
+		r.outbox[message.ID] = &message
+	}
+	return nil
+}
+
+func (r *InMemoryOrderRepository) PendingOutbox(limit int) ([]OutboxMessage, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	messages := make([]OutboxMessage, 0, len(r.outbox))
+	for _, message := range r.outbox {
+		messages = append(messages, *message)
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+	if limit > 0 && len(messages) > limit {
+		messages = messages[:limit]
+	}
+	return messages, nil
+}
+
+func (r *InMemoryOrderRepository) MarkOutboxSent(id int64) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.outbox[id]; !exists {
+		return fmt.Errorf("outbox message %w", ErrNotFound)
+	}
+	delete(r.outbox, id)
+	return nil
+}
+
+func (r *InMemoryOrderRepository) MarkOutboxDead(id int64, cause error) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	message, exists := r.outbox[id]
+	if !exists {
+		return fmt.Errorf("outbox message %w", ErrNotFound)
+	}
+	dead := *message
+	dead.Attempts++
+	if cause != nil {
+		dead.LastError = cause.Error()
+	}
+	delete(r.outbox, id)
+	r.deadLetters = append(r.deadLetters, dead)
+	return nil
+}
+
+func (r *InMemoryOrderRepository) DeadOutbox(limit int) ([]OutboxMessage, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	messages := append([]OutboxMessage(nil), r.deadLetters...)
+	if limit > 0 && len(messages) > limit {
+		messages = messages[:limit]
+	}
+	return messages, nil
+}
+
+func (r *InMemoryOrderRepository) MarkOutboxFailed(id int64, cause error) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	message, exists := r.outbox[id]
+	if !exists {
+		return fmt.Errorf("outbox message %w", ErrNotFound)
+	}
+	message.Attempts++
+	if cause != nil {
+		message.LastError = cause.Error()
+	}
+	return nil
+}
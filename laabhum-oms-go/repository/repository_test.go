@@ -0,0 +1,422 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/events"
+)
+
+// store is what both backends implement
+type store interface {
+	OrderRepository
+	Outbox
+}
+
+var backends = []struct {
+	name string
+	open func(t *testing.T) store
+}{
+	{"memory", func(t *testing.T) store { return NewInMemoryOrderRepository() }},
+	{"sqlite", func(t *testing.T) store {
+		repo, err := NewSQLiteOrderRepository(filepath.Join(t.TempDir(), "oms.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { repo.Close() })
+		return repo
+	}},
+}
+
+func pendingOrder(symbol string) models.Order {
+	return models.Order{
+		Symbol:   symbol,
+		Quantity: 10,
+		Price:    100,
+		Side:     models.SideBuy,
+		Type:     models.LimitOrder,
+		Status:   models.OrderStatusPending,
+		Strategy: models.StrategyDayTrading,
+		Product:  models.ProductMIS,
+		Segment:  models.SegmentEquity,
+	}
+}
+
+func mustCreate(t *testing.T, repo store, order models.Order) models.Order {
+	t.Helper()
+	created, err := repo.CreateOrder(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return created
+}
+
+func ids(orders []models.Order) map[string]bool {
+	found := make(map[string]bool)
+	for _, order := range orders {
+		found[order.ID] = true
+	}
+	return found
+}
+
+func TestRepositories(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo store)
+	}{
+		{"create and get order", func(t *testing.T, repo store) {
+			order := pendingOrder("NSE:NIFTY24OCT25000CE")
+			order.ParentID = "parent"
+			order.ClientOrderID = "client-1"
+			order.LegIndex = 2
+			order.UserID, order.AccountID = "trader-1", "acct-1"
+			order.Contract = &models.Contract{Underlying: "NSE:NIFTY", Strike: 25000}
+			created := mustCreate(t, repo, order)
+			if created.ID == "" || created.CreatedAt == 0 {
+				t.Fatalf("created order has no ID or time: %+v", created)
+			}
+
+			got, err := repo.GetOrder(created.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Symbol != order.Symbol || got.ParentID != "parent" || got.ClientOrderID != "client-1" ||
+				got.LegIndex != 2 || got.UserID != "trader-1" || got.AccountID != "acct-1" ||
+				got.Contract == nil || got.Contract.Strike != 25000 {
+				t.Errorf("got %+v, want fields of %+v", got, order)
+			}
+		}},
+		{"client order IDs are unique per user and account", func(t *testing.T, repo store) {
+			order := pendingOrder("NSE:INFY")
+			order.ClientOrderID, order.UserID, order.AccountID = "client-1", "trader-1", "acct-1"
+			order.SubmissionHash = "hash-1"
+			created := mustCreate(t, repo, order)
+			if _, err := repo.CreateOrder(order); !errors.Is(err, ErrDuplicateClientOrderID) {
+				t.Errorf("second order under client-1: %v, want a duplicate", err)
+			}
+			// Another user's account, or an order without one, may reuse the ID
+			other := order
+			other.UserID = "trader-2"
+			mustCreate(t, repo, other)
+			other.UserID, other.AccountID = "", ""
+			mustCreate(t, repo, other)
+
+			got, err := repo.GetOrderByClientOrderID("trader-1", "acct-1", "client-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.ID != created.ID || got.SubmissionHash != "hash-1" {
+				t.Errorf("got order %s with hash %q, want %s with hash-1", got.ID, got.SubmissionHash, created.ID)
+			}
+			if _, err := repo.GetOrderByClientOrderID("trader-1", "acct-2", "client-1"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("another account's order: %v, want not found", err)
+			}
+		}},
+		{"get order returns a copy", func(t *testing.T, repo store) {
+			created := mustCreate(t, repo, pendingOrder("NSE:INFY"))
+			got, err := repo.GetOrder(created.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got.Status = models.OrderStatusCancelled
+
+			again, err := repo.GetOrder(created.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again.Status != models.OrderStatusPending {
+				t.Errorf("changing a returned order changed the store: status %s", again.Status)
+			}
+		}},
+		{"update order and status", func(t *testing.T, repo store) {
+			created := mustCreate(t, repo, pendingOrder("NSE:INFY"))
+			created.Price = 101.5
+			if err := repo.UpdateOrder(created); err != nil {
+				t.Fatal(err)
+			}
+			if err := repo.UpdateOrderStatus(created.ID, models.OrderStatusExecuted); err != nil {
+				t.Fatal(err)
+			}
+			got, err := repo.GetOrder(created.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Price != 101.5 || got.Status != models.OrderStatusExecuted {
+				t.Errorf("got price %v status %s, want 101.5 executed", got.Price, got.Status)
+			}
+		}},
+		{"delete order", func(t *testing.T, repo store) {
+			created := mustCreate(t, repo, pendingOrder("NSE:INFY"))
+			if err := repo.DeleteOrder(created.ID); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := repo.GetOrder(created.ID); !errors.Is(err, ErrNotFound) {
+				t.Errorf("get after delete: %v, want not found", err)
+			}
+		}},
+		{"missing orders are not found", func(t *testing.T, repo store) {
+			missing := pendingOrder("NSE:INFY")
+			missing.ID = "missing"
+			for name, err := range map[string]error{
+				"get":    func() error { _, err := repo.GetOrder("missing"); return err }(),
+				"update": repo.UpdateOrder(missing),
+				"status": repo.UpdateOrderStatus("missing", models.OrderStatusCancelled),
+				"delete": repo.DeleteOrder("missing"),
+			} {
+				if !errors.Is(err, ErrNotFound) {
+					t.Errorf("%s: %v, want not found", name, err)
+				}
+			}
+		}},
+		{"filter orders", func(t *testing.T, repo store) {
+			infy := mustCreate(t, repo, pendingOrder("NSE:INFY"))
+			tcs := pendingOrder("NSE:TCS")
+			tcs.Strategy = models.StrategyPositionTrading
+			tcs = mustCreate(t, repo, tcs)
+			child := pendingOrder("NSE:TCS")
+			child.ParentID = infy.ID
+			child.UserID = "trader-1"
+			child = mustCreate(t, repo, child)
+			// Creation stamps the current time, so the orders are backdated
+			for i, order := range []*models.Order{&infy, &tcs, &child} {
+				order.CreatedAt = int64(1000 * (i + 1))
+				if err := repo.UpdateOrder(*order); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := repo.UpdateOrderStatus(tcs.ID, models.OrderStatusExecuted); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, tc := range []struct {
+				name   string
+				filter OrderFilter
+				want   []string
+			}{
+				{"all", OrderFilter{}, []string{infy.ID, tcs.ID, child.ID}},
+				{"symbol", OrderFilter{Symbol: "NSE:TCS"}, []string{tcs.ID, child.ID}},
+				{"status", OrderFilter{Status: models.OrderStatusPending}, []string{infy.ID, child.ID}},
+				{"parent", OrderFilter{ParentID: infy.ID}, []string{child.ID}},
+				{"symbol and status", OrderFilter{Symbol: "NSE:TCS", Status: models.OrderStatusExecuted}, []string{tcs.ID}},
+				{"strategy", OrderFilter{Strategy: models.StrategyPositionTrading}, []string{tcs.ID}},
+				{"from date", OrderFilter{FromDate: time.Unix(2000, 0)}, []string{tcs.ID, child.ID}},
+				{"to date", OrderFilter{ToDate: time.Unix(2000, 0)}, []string{infy.ID, tcs.ID}},
+				{"date range", OrderFilter{FromDate: time.Unix(1500, 0), ToDate: time.Unix(2500, 0)}, []string{tcs.ID}},
+				{"user", OrderFilter{UserID: "trader-1"}, []string{child.ID}},
+				{"no match", OrderFilter{Symbol: "NSE:WIPRO"}, nil},
+			} {
+				orders, err := repo.GetOrders(tc.filter)
+				if err != nil {
+					t.Fatal(err)
+				}
+				found := ids(orders)
+				if len(found) != len(tc.want) {
+					t.Errorf("%s: got %d orders, want %d", tc.name, len(found), len(tc.want))
+				}
+				for _, id := range tc.want {
+					if !found[id] {
+						t.Errorf("%s: order %s missing", tc.name, id)
+					}
+				}
+			}
+		}},
+		{"positions", func(t *testing.T, repo store) {
+			position := models.Position{ID: "pos-1", OrderID: "order-1", Symbol: "NSE:INFY", Quantity: 10,
+				EntryPrice: 100, CurrentPrice: 100, Product: models.ProductMIS, UserID: "trader-1", AccountID: "acct-1"}
+			if err := repo.CreatePosition(position); err != nil {
+				t.Fatal(err)
+			}
+			got, err := repo.GetPosition("pos-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.OpenedAt.IsZero() || got.Quantity != 10 || got.UserID != "trader-1" || got.AccountID != "acct-1" {
+				t.Fatalf("got %+v", got)
+			}
+
+			got.CurrentPrice = 110
+			if err := repo.UpdatePosition(*got); err != nil {
+				t.Fatal(err)
+			}
+			updated, err := repo.GetPosition("pos-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updated.CurrentPrice != 110 {
+				t.Errorf("current price %v, want 110", updated.CurrentPrice)
+			}
+
+			if err := repo.ClosePosition("pos-1"); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := repo.GetPosition("pos-1"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("get after close: %v, want not found", err)
+			}
+			if err := repo.ClosePosition("pos-1"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("second close: %v, want not found", err)
+			}
+			if err := repo.UpdatePosition(*updated); !errors.Is(err, ErrNotFound) {
+				t.Errorf("update after close: %v, want not found", err)
+			}
+		}},
+		{"open positions oldest first without expired", func(t *testing.T, repo store) {
+			// 09:15:00.1 formats shorter than 09:15:00.12 and sorts after it as text
+			opened := time.Date(2026, 10, 19, 9, 15, 0, 100000000, time.UTC)
+			for i, p := range []struct {
+				id       string
+				openedAt time.Time
+				status   models.PositionStatus
+			}{
+				{"later", opened.Add(20 * time.Millisecond), models.PositionStatusOpen},
+				{"earlier", opened, models.PositionStatusOpen},
+				{"expired", opened.Add(-time.Hour), models.PositionStatusExpired},
+			} {
+				position := models.Position{ID: p.id, OrderID: p.id, Symbol: "NSE:INFY", Quantity: i + 1, EntryPrice: 100}
+				if err := repo.CreatePosition(position); err != nil {
+					t.Fatal(err)
+				}
+				position.OpenedAt = p.openedAt
+				position.Status = string(p.status)
+				if err := repo.UpdatePosition(position); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			positions, err := repo.GetOpenPositions()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(positions) != 2 || positions[0].ID != "earlier" || positions[1].ID != "later" {
+				t.Fatalf("got %+v, want earlier then later", positions)
+			}
+			if !positions[0].OpenedAt.Equal(opened) {
+				t.Errorf("opened at %v, want %v", positions[0].OpenedAt, opened)
+			}
+		}},
+		{"outbox", func(t *testing.T, repo store) {
+			first := mustCreate(t, repo, pendingOrder("NSE:INFY"))
+			if err := repo.UpdateOrderStatus(first.ID, models.OrderStatusCancelled); err != nil {
+				t.Fatal(err)
+			}
+			if err := repo.CreatePosition(models.Position{ID: "pos-1", Symbol: "NSE:INFY", Quantity: 1, EntryPrice: 100}); err != nil {
+				t.Fatal(err)
+			}
+
+			pending, err := repo.PendingOutbox(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(pending) != 3 {
+				t.Fatalf("got %d pending messages, want 3", len(pending))
+			}
+			for i, topic := range []string{events.TopicOrders, events.TopicOrders, events.TopicPositions} {
+				if pending[i].Topic != topic || pending[i].Key != "NSE:INFY" || len(pending[i].Payload) == 0 {
+					t.Errorf("message %d: %+v, want topic %s", i, pending[i], topic)
+				}
+				if i > 0 && pending[i].ID <= pending[i-1].ID {
+					t.Errorf("messages out of order: %d after %d", pending[i].ID, pending[i-1].ID)
+				}
+			}
+
+			limited, err := repo.PendingOutbox(2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(limited) != 2 || limited[0].ID != pending[0].ID {
+				t.Errorf("limit 2 returned %d messages starting at %d", len(limited), limited[0].ID)
+			}
+
+			if err := repo.MarkOutboxFailed(pending[0].ID, errors.New("broker down")); err != nil {
+				t.Fatal(err)
+			}
+			if err := repo.MarkOutboxSent(pending[1].ID); err != nil {
+				t.Fatal(err)
+			}
+			left, err := repo.PendingOutbox(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(left) != 2 || left[0].ID != pending[0].ID || left[1].ID != pending[2].ID {
+				t.Fatalf("got %+v after marking, want messages %d and %d", left, pending[0].ID, pending[2].ID)
+			}
+			if left[0].Attempts != 1 || left[0].LastError != "broker down" {
+				t.Errorf("failed message has attempts %d, last error %q", left[0].Attempts, left[0].LastError)
+			}
+
+			if err := repo.MarkOutboxSent(pending[1].ID); !errors.Is(err, ErrNotFound) {
+				t.Errorf("marking a sent message again: %v, want not found", err)
+			}
+			if err := repo.MarkOutboxFailed(-1, nil); !errors.Is(err, ErrNotFound) {
+				t.Errorf("failing a missing message: %v, want not found", err)
+			}
+
+			if err := repo.MarkOutboxDead(pending[0].ID, errors.New("message too large")); err != nil {
+				t.Fatal(err)
+			}
+			dead, err := repo.DeadOutbox(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(dead) != 1 || dead[0].ID != pending[0].ID || dead[0].Attempts != 2 ||
+				dead[0].LastError != "message too large" || string(dead[0].Payload) != string(pending[0].Payload) {
+				t.Errorf("dead letters: %+v, want message %d after 2 attempts", dead, pending[0].ID)
+			}
+			if left, _ := repo.PendingOutbox(0); len(left) != 1 || left[0].ID != pending[2].ID {
+				t.Errorf("pending after dead-lettering: %+v, want only message %d", left, pending[2].ID)
+			}
+			if err := repo.MarkOutboxDead(pending[0].ID, nil); !errors.Is(err, ErrNotFound) {
+				t.Errorf("dead-lettering twice: %v, want not found", err)
+			}
+		}},
+	}
+
+	for _, backend := range backends {
+		for _, tc := range tests {
+			t.Run(backend.name+"/"+tc.name, func(t *testing.T) {
+				tc.run(t, backend.open(t))
+			})
+		}
+	}
+}
+
+func TestMigrationRefusesDuplicateClientOrderIDs(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "oms.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// A database from before client order IDs were unique
+	all := migrations
+	migrations = all[:9]
+	err = migrate(db)
+	migrations = all
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"order-1", "order-2", "order-3"} {
+		clientOrderID := "key-1"
+		if id == "order-3" {
+			clientOrderID = "key-2"
+		}
+		if _, err := db.Exec(`INSERT INTO orders (id, symbol, quantity, price, created_at, client_order_id, user_id)
+			VALUES (?, 'NSE:INFY', 1, 100, 0, ?, 'trader-1')`, id, clientOrderID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = migrate(db)
+	if err == nil || !strings.Contains(err.Error(), "order-1, order-2") || strings.Contains(err.Error(), "order-3") {
+		t.Fatalf("migrating duplicate client order IDs: %v, want an error naming order-1 and order-2", err)
+	}
+	var version int
+	db.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&version)
+	if version != 9 {
+		t.Errorf("schema at version %d after the failed migration, want 9", version)
+	}
+}
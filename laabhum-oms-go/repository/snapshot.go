@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"sort"
+
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+)
+
+// Snapshot is the complete state of an InMemoryOrderRepository
+type Snapshot struct {
+	Orders           []models.Order            `json:"orders"`
+	Positions        []models.Position         `json:"positions"`
+	Trades           map[string][]models.Trade `json:"trades"`
+	MarketConditions []models.MarketCondition  `json:"market_conditions"`
+	Outbox           []OutboxMessage           `json:"outbox,omitempty"` // Undelivered events
+	OutboxSequence   int64                     `json:"outbox_seq,omitempty"`
+	DeadLetters      []OutboxMessage           `json:"dead_letters,omitempty"`
+}
+
+// Snapshot copies the repository state, ordered so that equal states produce equal snapshots
+func (r *InMemoryOrderRepository) Snapshot() Snapshot {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	snapshot := Snapshot{
+		Orders:           make([]models.Order, 0, len(r.orders)),
+		Positions:        make([]models.Position, 0, len(r.positions)),
+		Trades:           make(map[string][]models.Trade, len(r.trades)),
+		MarketConditions: make([]models.MarketCondition, 0, len(r.marketConditions)),
+		OutboxSequence:   r.outboxSeq,
+		DeadLetters:      append([]OutboxMessage(nil), r.deadLetters...),
+	}
+	for _, order := range r.orders {
+		snapshot.Orders = append(snapshot.Orders, *order)
+	}
+	for _, position := range r.positions {
+		snapshot.Positions = append(snapshot.Positions, *position)
+	}
+	for parentID, trades := range r.trades {
+		snapshot.Trades[parentID] = append([]models.Trade(nil), trades...)
+	}
+	for _, condition := range r.marketConditions {
+		snapshot.MarketConditions = append(snapshot.MarketConditions, *condition)
+	}
+	for _, message := range r.outbox {
+		snapshot.Outbox = append(snapshot.Outbox, *message)
+	}
+
+	sort.Slice(snapshot.Orders, func(i, j int) bool { return snapshot.Orders[i].ID < snapshot.Orders[j].ID })
+	sort.Slice(snapshot.Positions, func(i, j int) bool { return snapshot.Positions[i].ID < snapshot.Positions[j].ID })
+	sort.Slice(snapshot.MarketConditions, func(i, j int) bool {
+		return snapshot.MarketConditions[i].Symbol < snapshot.MarketConditions[j].Symbol
+	})
+	sort.Slice(snapshot.Outbox, func(i, j int) bool { return snapshot.Outbox[i].ID < snapshot.Outbox[j].ID })
+	return snapshot
+}
+
+// Restore replaces the repository state with snapshot
+func (r *InMemoryOrderRepository) Restore(snapshot Snapshot) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.orders = make(map[string]*models.Order, len(snapshot.Orders))
+	r.positions = make(map[string]*models.Position, len(snapshot.Positions))
+	r.trades = make(map[string][]models.Trade, len(snapshot.Trades))
+	r.marketConditions = make(map[string]*models.MarketCondition, len(snapshot.MarketConditions))
+	r.outbox = make(map[int64]*OutboxMessage, len(snapshot.Outbox))
+	r.outboxSeq = snapshot.OutboxSequence
+	r.deadLetters = append([]OutboxMessage(nil), snapshot.DeadLetters...)
+
+	for i := range snapshot.Orders {
+		order := snapshot.Orders[i]
+		r.orders[order.ID] = &order
+	}
+	for i := range snapshot.Positions {
+		position := snapshot.Positions[i]
+		r.positions[position.ID] = &position
+	}
+	for parentID, trades := range snapshot.Trades {
+		r.trades[parentID] = append([]models.Trade(nil), trades...)
+	}
+	for i := range snapshot.MarketConditions {
+		condition := snapshot.MarketConditions[i]
+		r.marketConditions[condition.Symbol] = &condition
+	}
+	for i := range snapshot.Outbox {
+		message := snapshot.Outbox[i]
+		r.outbox[message.ID] = &message
+	}
+}
+
+// PutOrder stores order exactly as given, keeping its ID and timestamps
+func (r *InMemoryOrderRepository) PutOrder(order models.Order) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.orders[order.ID] = &order
+}
+
+// RemoveOrder drops an order if present
+func (r *InMemoryOrderRepository) RemoveOrder(id string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.orders, id)
+}
+
+// PutPosition stores position exactly as given, keeping its ID and timestamps
+func (r *InMemoryOrderRepository) PutPosition(position models.Position) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.positions[position.ID] = &position
+}
+
+// PutMarketCondition stores the latest market condition for its symbol
+func (r *InMemoryOrderRepository) PutMarketCondition(condition models.MarketCondition) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.marketConditions[condition.Symbol] = &condition
+}
+
+// RemovePosition drops a position if present
+func (r *InMemoryOrderRepository) RemovePosition(id string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.positions, id)
+}
@@ -0,0 +1,638 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/events"
+	"github.com/google/uuid"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+const orderColumns = `id, symbol, quantity, price, side, type, status, stop_price, strategy,
+	product, segment, amo, contract, risk_percentage, stop_loss_activated, take_profit,
+	created_at, expires_at, parent_id, client_order_id, leg_index, user_id, account_id, submission_hash`
+
+const positionColumns = `id, order_id, symbol, quantity, entry_price, current_price, stop_loss,
+	take_profit, strategy, product, contract, status, opened_at, last_updated_at, user_id, account_id`
+
+// SQLiteOrderRepository is a durable OrderRepository on an embedded SQLite database
+type SQLiteOrderRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteOrderRepository opens the database at dsn (a file path or
+// "file::memory:?cache=shared") and migrates it to the latest schema
+func NewSQLiteOrderRepository(dsn string) (*SQLiteOrderRepository, error) {
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows a single writer; one connection avoids SQLITE_BUSY under load
+	db.SetMaxOpenConns(1)
+
+	for _, pragma := range []string{"PRAGMA journal_mode=WAL", "PRAGMA foreign_keys=ON", "PRAGMA busy_timeout=5000"} {
+		if _, err := db.Exec(pragma); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("%s: %w", pragma, err)
+		}
+	}
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLiteOrderRepository{db: db}, nil
+}
+
+// Close releases the underlying database
+func (r *SQLiteOrderRepository) Close() error {
+	return r.db.Close()
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+// unixNanos stores t so that later times sort after earlier ones
+func unixNanos(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func fromUnixNanos(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n).UTC()
+}
+
+func encodeContract(contract *models.Contract) (string, error) {
+	if contract == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(contract)
+	return string(data), err
+}
+
+func decodeContract(value string) (*models.Contract, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var contract models.Contract
+	if err := json.Unmarshal([]byte(value), &contract); err != nil {
+		return nil, err
+	}
+	return &contract, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanOrder(row rowScanner) (*models.Order, error) {
+	var (
+		order     models.Order
+		contract  string
+		expiresAt string
+	)
+	err := row.Scan(&order.ID, &order.Symbol, &order.Quantity, &order.Price, &order.Side, &order.Type,
+		&order.Status, &order.StopPrice, &order.Strategy, &order.Product, &order.Segment, &order.AMO,
+		&contract, &order.RiskPercentage, &order.StopLossActivated, &order.TakeProfit, &order.CreatedAt,
+		&expiresAt, &order.ParentID, &order.ClientOrderID, &order.LegIndex, &order.UserID, &order.AccountID,
+		&order.SubmissionHash)
+	if err != nil {
+		return nil, err
+	}
+	if order.Contract, err = decodeContract(contract); err != nil {
+		return nil, err
+	}
+	if order.ExpiresAt, err = parseTime(expiresAt); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func scanPosition(row rowScanner) (*models.Position, error) {
+	var (
+		position              models.Position
+		contract              string
+		openedAt, lastUpdated int64
+	)
+	err := row.Scan(&position.ID, &position.OrderID, &position.Symbol, &position.Quantity, &position.EntryPrice,
+		&position.CurrentPrice, &position.StopLoss, &position.TakeProfit, &position.Strategy, &position.Product,
+		&contract, &position.Status, &openedAt, &lastUpdated, &position.UserID, &position.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if position.Contract, err = decodeContract(contract); err != nil {
+		return nil, err
+	}
+	position.OpenedAt = fromUnixNanos(openedAt)
+	position.LastUpdatedAt = fromUnixNanos(lastUpdated)
+	return &position, nil
+}
+
+func orderArgs(order models.Order) ([]interface{}, error) {
+	contract, err := encodeContract(order.Contract)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{order.ID, order.Symbol, order.Quantity, order.Price, order.Side, order.Type,
+		order.Status, order.StopPrice, order.Strategy, order.Product, order.Segment, order.AMO, contract,
+		order.RiskPercentage, order.StopLossActivated, order.TakeProfit, order.CreatedAt,
+		formatTime(order.ExpiresAt), order.ParentID, order.ClientOrderID, order.LegIndex, order.UserID,
+		order.AccountID, order.SubmissionHash}, nil
+}
+
+func positionArgs(position models.Position) ([]interface{}, error) {
+	contract, err := encodeContract(position.Contract)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{position.ID, position.OrderID, position.Symbol, position.Quantity, position.EntryPrice,
+		position.CurrentPrice, position.StopLoss, position.TakeProfit, position.Strategy, position.Product,
+		contract, position.Status, unixNanos(position.OpenedAt), unixNanos(position.LastUpdatedAt),
+		position.UserID, position.AccountID}, nil
+}
+
+// inTx runs fn in a transaction, committing only if it succeeds
+func (r *SQLiteOrderRepository) inTx(fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertOutbox returns a function storing outbox messages in tx, the same
+// transaction as the change they describe. It takes the message builder's
+// results directly so callers can write insertOutbox(tx)(OrderOutbox(...)).
+func insertOutbox(tx *sql.Tx) func([]OutboxMessage, error) error {
+	return func(messages []OutboxMessage, err error) error {
+		if err != nil {
+			return err
+		}
+		return writeOutbox(tx, messages)
+	}
+}
+
+func writeOutbox(tx *sql.Tx, messages []OutboxMessage) error {
+	for _, message := range messages {
+		if _, err := tx.Exec(`INSERT INTO outbox (topic, key, payload, created_at) VALUES (?, ?, ?, ?)`,
+			message.Topic, message.Key, message.Payload, formatTime(message.CreatedAt)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *SQLiteOrderRepository) CreateOrder(order models.Order) (models.Order, error) {
+	if order.ID == "" {
+		order.ID = uuid.New().String()
+	}
+	order.CreatedAt = time.Now().Unix()
+
+	args, err := orderArgs(order)
+	if err != nil {
+		return models.Order{}, err
+	}
+	err = r.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`INSERT INTO orders (`+orderColumns+`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`, args...); err != nil {
+			var sqliteErr *sqlite.Error
+			if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+				return ErrDuplicateClientOrderID
+			}
+			return err
+		}
+		return insertOutbox(tx)(OrderOutbox(nil, order))
+	})
+	if err != nil {
+		return models.Order{}, err
+	}
+	return order, nil
+}
+
+// orderInTx reads an order for update; a missing order is reported as not found
+func orderInTx(tx *sql.Tx, id string) (*models.Order, error) {
+	order, err := scanOrder(tx.QueryRow(`SELECT `+orderColumns+` FROM orders WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("order %w", ErrNotFound)
+	}
+	return order, err
+}
+
+func positionInTx(tx *sql.Tx, id string) (*models.Position, error) {
+	position, err := scanPosition(tx.QueryRow(`SELECT `+positionColumns+` FROM positions WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("position %w", ErrNotFound)
+	}
+	return position, err
+}
+
+func (r *SQLiteOrderRepository) SaveOrder(order map[string]interface{}) error {
+	id, ok := order["ID"].(string)
+	if !ok || id == "" {
+		return errors.New("invalid order ID")
+	}
+	symbol, _ := order["Symbol"].(string)
+	status, _ := order["Status"].(models.OrderStatus)
+	strategy, _ := order["Strategy"].(models.TradeStrategy)
+
+	return r.inTx(func(tx *sql.Tx) error {
+		previous, err := scanOrder(tx.QueryRow(`SELECT `+orderColumns+` FROM orders WHERE id = ?`, id))
+		if errors.Is(err, sql.ErrNoRows) {
+			previous = nil
+		} else if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`INSERT INTO orders (id, symbol, quantity, price, status, strategy, created_at)
+			VALUES (?, ?, 0, 0, ?, ?, ?)
+			ON CONFLICT(id) DO UPDATE SET symbol = excluded.symbol, status = excluded.status, strategy = excluded.strategy`,
+			id, symbol, status, strategy, time.Now().Unix()); err != nil {
+			return err
+		}
+		current, err := orderInTx(tx, id)
+		if err != nil {
+			return err
+		}
+		return insertOutbox(tx)(OrderOutbox(previous, *current))
+	})
+}
+
+func (r *SQLiteOrderRepository) CreateScalperOrder(order models.ScalperOrder) (*models.ScalperOrder, error) {
+	_, err := r.db.Exec(`INSERT INTO scalper_orders (id, symbol, quantity, price, stop_loss, take_profit,
+		risk_percentage, created_at, expires_at, timestamp) VALUES (?,?,?,?,?,?,?,?,?,?)`,
+		order.ID, order.Symbol, order.Quantity, order.Price, order.StopLoss, order.TakeProfit,
+		order.RiskPercentage, order.CreatedAt, formatTime(order.ExpiresAt), order.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *SQLiteOrderRepository) GetOrder(id string) (*models.Order, error) {
+	order, err := scanOrder(r.db.QueryRow(`SELECT `+orderColumns+` FROM orders WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("order %w", ErrNotFound)
+	}
+	return order, err
+}
+
+func (r *SQLiteOrderRepository) GetOrderByClientOrderID(userID, accountID, clientOrderID string) (*models.Order, error) {
+	order, err := scanOrder(r.db.QueryRow(`SELECT `+orderColumns+` FROM orders
+		WHERE user_id = ? AND account_id = ? AND client_order_id = ?`, userID, accountID, clientOrderID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("order %w", ErrNotFound)
+	}
+	return order, err
+}
+
+func (r *SQLiteOrderRepository) UpdateOrder(order models.Order) error {
+	args, err := orderArgs(order)
+	if err != nil {
+		return err
+	}
+	// Move the id from the first placeholder to the WHERE clause
+	args = append(args[1:], order.ID)
+	return r.inTx(func(tx *sql.Tx) error {
+		previous, err := orderInTx(tx, order.ID)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`UPDATE orders SET symbol = ?, quantity = ?, price = ?, side = ?, type = ?,
+			status = ?, stop_price = ?, strategy = ?, product = ?, segment = ?, amo = ?, contract = ?,
+			risk_percentage = ?, stop_loss_activated = ?, take_profit = ?, created_at = ?, expires_at = ?,
+			parent_id = ?, client_order_id = ?, leg_index = ?, user_id = ?, account_id = ?,
+			submission_hash = ? WHERE id = ?`, args...); err != nil {
+			return err
+		}
+		return insertOutbox(tx)(OrderOutbox(previous, order))
+	})
+}
+
+func (r *SQLiteOrderRepository) UpdateOrderStatus(id string, status models.OrderStatus) error {
+	return r.inTx(func(tx *sql.Tx) error {
+		previous, err := orderInTx(tx, id)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`UPDATE orders SET status = ? WHERE id = ?`, status, id); err != nil {
+			return err
+		}
+		current := *previous
+		current.Status = status
+		return insertOutbox(tx)(OrderOutbox(previous, current))
+	})
+}
+
+func (r *SQLiteOrderRepository) ExecuteChildOrder(orderID string) error {
+	return r.UpdateOrderStatus(orderID, models.OrderStatusExecuted)
+}
+
+func (r *SQLiteOrderRepository) DeleteOrder(id string) error {
+	result, err := r.db.Exec(`DELETE FROM orders WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	return requireRow(result, "order")
+}
+
+func (r *SQLiteOrderRepository) GetOrders(filter OrderFilter) ([]models.Order, error) {
+	var (
+		where []string
+		args  []interface{}
+	)
+	if filter.Symbol != "" {
+		where, args = append(where, "symbol = ?"), append(args, filter.Symbol)
+	}
+	if filter.Status != "" {
+		where, args = append(where, "status = ?"), append(args, filter.Status)
+	}
+	if filter.Strategy != "" {
+		where, args = append(where, "strategy = ?"), append(args, filter.Strategy)
+	}
+	if !filter.FromDate.IsZero() {
+		where, args = append(where, "created_at >= ?"), append(args, filter.FromDate.Unix())
+	}
+	if !filter.ToDate.IsZero() {
+		where, args = append(where, "created_at <= ?"), append(args, filter.ToDate.Unix())
+	}
+	if filter.ParentID != "" {
+		where, args = append(where, "parent_id = ?"), append(args, filter.ParentID)
+	}
+	if filter.UserID != "" {
+		where, args = append(where, "user_id = ?"), append(args, filter.UserID)
+	}
+
+	query := `SELECT ` + orderColumns + ` FROM orders`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	query += ` ORDER BY created_at, id`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []models.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, *order)
+	}
+	return orders, rows.Err()
+}
+
+func (r *SQLiteOrderRepository) CreatePosition(position models.Position) error {
+	if position.ID == "" {
+		position.ID = uuid.New().String()
+	}
+	position.OpenedAt = time.Now()
+	position.LastUpdatedAt = time.Now()
+
+	args, err := positionArgs(position)
+	if err != nil {
+		return err
+	}
+	return r.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`INSERT INTO positions (`+positionColumns+`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`, args...); err != nil {
+			return err
+		}
+		return insertOutbox(tx)(PositionOutbox(events.PositionOpened, position))
+	})
+}
+
+func (r *SQLiteOrderRepository) GetPosition(id string) (*models.Position, error) {
+	position, err := scanPosition(r.db.QueryRow(`SELECT `+positionColumns+` FROM positions WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("position %w", ErrNotFound)
+	}
+	return position, err
+}
+
+func (r *SQLiteOrderRepository) UpdatePosition(position models.Position) error {
+	position.LastUpdatedAt = time.Now()
+	args, err := positionArgs(position)
+	if err != nil {
+		return err
+	}
+	args = append(args[1:], position.ID)
+	return r.inTx(func(tx *sql.Tx) error {
+		result, err := tx.Exec(`UPDATE positions SET order_id = ?, symbol = ?, quantity = ?, entry_price = ?,
+			current_price = ?, stop_loss = ?, take_profit = ?, strategy = ?, product = ?, contract = ?,
+			status = ?, opened_at = ?, last_updated_at = ?, user_id = ?, account_id = ? WHERE id = ?`, args...)
+		if err != nil {
+			return err
+		}
+		if err := requireRow(result, "position"); err != nil {
+			return err
+		}
+		return insertOutbox(tx)(PositionOutbox(events.PositionUpdated, position))
+	})
+}
+
+func (r *SQLiteOrderRepository) GetOpenPositions() ([]models.Position, error) {
+	rows, err := r.db.Query(`SELECT `+positionColumns+` FROM positions WHERE status <> ? ORDER BY opened_at, id`,
+		models.PositionStatusExpired)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var positions []models.Position
+	for rows.Next() {
+		position, err := scanPosition(rows)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, *position)
+	}
+	return positions, rows.Err()
+}
+
+func (r *SQLiteOrderRepository) ClosePosition(id string) error {
+	return r.inTx(func(tx *sql.Tx) error {
+		position, err := positionInTx(tx, id)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM positions WHERE id = ?`, id); err != nil {
+			return err
+		}
+		return insertOutbox(tx)(PositionOutbox(events.PositionClosed, *position))
+	})
+}
+
+func (r *SQLiteOrderRepository) PendingOutbox(limit int) ([]OutboxMessage, error) {
+	return r.outboxMessages(`SELECT id, topic, key, payload, created_at, attempts, last_error FROM outbox
+		WHERE sent_at = '' ORDER BY id`, limit)
+}
+
+func (r *SQLiteOrderRepository) DeadOutbox(limit int) ([]OutboxMessage, error) {
+	return r.outboxMessages(`SELECT id, topic, key, payload, created_at, attempts, last_error FROM outbox_dead_letters
+		ORDER BY id`, limit)
+}
+
+func (r *SQLiteOrderRepository) outboxMessages(query string, limit int) ([]OutboxMessage, error) {
+	var args []interface{}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []OutboxMessage
+	for rows.Next() {
+		var (
+			message   OutboxMessage
+			createdAt string
+		)
+		if err := rows.Scan(&message.ID, &message.Topic, &message.Key, &message.Payload, &createdAt,
+			&message.Attempts, &message.LastError); err != nil {
+			return nil, err
+		}
+		if message.CreatedAt, err = parseTime(createdAt); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, rows.Err()
+}
+
+func (r *SQLiteOrderRepository) MarkOutboxSent(id int64) error {
+	result, err := r.db.Exec(`DELETE FROM outbox WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	return requireRow(result, "outbox message")
+}
+
+func (r *SQLiteOrderRepository) MarkOutboxDead(id int64, cause error) error {
+	lastError := ""
+	if cause != nil {
+		lastError = cause.Error()
+	}
+	return r.inTx(func(tx *sql.Tx) error {
+		result, err := tx.Exec(`INSERT INTO outbox_dead_letters (id, topic, key, payload, created_at, attempts, last_error, dead_at)
+			SELECT id, topic, key, payload, created_at, attempts + 1, ?, ? FROM outbox WHERE id = ?`,
+			lastError, formatTime(time.Now()), id)
+		if err != nil {
+			return err
+		}
+		if err := requireRow(result, "outbox message"); err != nil {
+			return err
+		}
+		_, err = tx.Exec(`DELETE FROM outbox WHERE id = ?`, id)
+		return err
+	})
+}
+
+func (r *SQLiteOrderRepository) MarkOutboxFailed(id int64, cause error) error {
+	lastError := ""
+	if cause != nil {
+		lastError = cause.Error()
+	}
+	result, err := r.db.Exec(`UPDATE outbox SET attempts = attempts + 1, last_error = ? WHERE id = ?`, lastError, id)
+	if err != nil {
+		return err
+	}
+	return requireRow(result, "outbox message")
+}
+
+func (r *SQLiteOrderRepository) GetTrades(parentID string) ([]models.Trade, error) {
+	rows, err := r.db.Query(`SELECT id, order_id, symbol, quantity, price, trade_time FROM trades
+		WHERE parent_id = ? ORDER BY trade_time, id`, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trades []models.Trade
+	for rows.Next() {
+		var (
+			trade     models.Trade
+			tradeTime string
+		)
+		if err := rows.Scan(&trade.ID, &trade.OrderID, &trade.Symbol, &trade.Quantity, &trade.Price, &tradeTime); err != nil {
+			return nil, err
+		}
+		if trade.TradeTime, err = parseTime(tradeTime); err != nil {
+			return nil, err
+		}
+		trades = append(trades, trade)
+	}
+	return trades, rows.Err()
+}
+
+func (r *SQLiteOrderRepository) SaveMarketCondition(condition models.MarketCondition) error {
+	_, err := r.db.Exec(`INSERT INTO market_conditions (symbol, price, volume, volatility, trend, timestamp)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(symbol) DO UPDATE SET price = excluded.price, volume = excluded.volume,
+			volatility = excluded.volatility, trend = excluded.trend, timestamp = excluded.timestamp`,
+		condition.Symbol, condition.Price, condition.Volume, condition.Volatility, condition.Trend,
+		formatTime(condition.Timestamp))
+	return err
+}
+
+func (r *SQLiteOrderRepository) GetLatestMarketCondition(symbol string) (*models.MarketCondition, error) {
+	var (
+		condition models.MarketCondition
+		timestamp string
+	)
+	err := r.db.QueryRow(`SELECT symbol, price, volume, volatility, trend, timestamp FROM market_conditions
+		WHERE symbol = ?`, symbol).Scan(&condition.Symbol, &condition.Price, &condition.Volume,
+		&condition.Volatility, &condition.Trend, &timestamp)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("market condition %w for symbol", ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if condition.Timestamp, err = parseTime(timestamp); err != nil {
+		return nil, err
+	}
+	return &condition, nil
+}
+
+// requireRow turns an update or delete that touched nothing into a not-found
+// error naming what was missing
+func requireRow(result sql.Result, what string) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%s %w", what, ErrNotFound)
+	}
+	return nil
+}
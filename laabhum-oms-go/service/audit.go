@@ -0,0 +1,44 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Mukilan-T/laabhum-oms-go/audit"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+)
+
+// SetAuditTrail replaces the trail order and position changes are recorded in
+func (s *OMSService) SetAuditTrail(trail *audit.Trail) {
+	s.trail = trail
+	s.repo = audit.NewRecorder(s.store, trail, s.source)
+}
+
+// As returns a view of the service whose changes are attributed to source.
+// The view shares all state with s and is meant to live for one request or job run.
+func (s *OMSService) As(source audit.Source) *OMSService {
+	view := *s
+	view.source = source
+	view.repo = audit.NewRecorder(s.store, s.trail, source)
+	return &view
+}
+
+// because supplies a reason for the changes that follow unless the caller already gave one
+func (s *OMSService) because(reason string) *OMSService {
+	if s.source.Reason != "" {
+		return s
+	}
+	source := s.source
+	source.Reason = reason
+	return s.As(source)
+}
+
+// Timeline returns every recorded change to an order and its positions, oldest first
+func (s *OMSService) Timeline(orderID string) ([]audit.Entry, error) {
+	entries := s.trail.Timeline(orderID)
+	if len(entries) == 0 {
+		if _, err := s.store.GetOrder(orderID); err != nil {
+			return nil, fmt.Errorf("order %w", repository.ErrNotFound)
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,32 @@
+package service
+
+import "errors"
+
+// Kinds of rejection the APIs answer as the caller's fault rather than the
+// OMS's. Errors of a kind match it with errors.Is but keep their own message.
+var (
+	ErrInvalidOrder = errors.New("invalid order parameters")                // The request itself is wrong
+	ErrOrderState   = errors.New("order can no longer be changed this way") // The order's status rules it out
+)
+
+// classified marks an error as being of a kind
+type classified struct {
+	error
+	kind error
+}
+
+func (e classified) Is(target error) bool { return target == e.kind }
+func (e classified) Unwrap() error        { return e.error }
+
+// invalid marks err as a mistake in the request; nil stays nil
+func invalid(err error) error {
+	if err == nil {
+		return nil
+	}
+	return classified{err, ErrInvalidOrder}
+}
+
+// badState marks err as refused because of the order's status
+func badState(err error) error {
+	return classified{err, ErrOrderState}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/audit"
+	"github.com/Mukilan-T/laabhum-oms-go/calendar"
+	"github.com/Mukilan-T/laabhum-oms-go/instruments"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/events"
+)
+
+// ExpiryAction decides what happens to positions in contracts expiring today
+type ExpiryAction string
+
+const (
+	ExpiryClose ExpiryAction = "close" // square off with a market order before the close
+	ExpiryFlag  ExpiryAction = "flag"  // leave open and let settlement handle it
+)
+
+// ExpiryConfig controls the daily expiry run
+type ExpiryConfig struct {
+	RunAt  string // time of day in IST, e.g. "15:20"
+	Action ExpiryAction
+}
+
+// DefaultExpiryConfig closes expiring positions ten minutes before the F&O close
+var DefaultExpiryConfig = ExpiryConfig{
+	RunAt:  "15:20",
+	Action: ExpiryClose,
+}
+
+// ExpiryReport describes what an expiry run did
+type ExpiryReport struct {
+	RanAt            time.Time    `json:"ran_at"`
+	Action           ExpiryAction `json:"action"`
+	ClosedPositions  []string     `json:"closed_positions"`
+	FlaggedPositions []string     `json:"flagged_positions"`
+	Errors           []string     `json:"errors,omitempty"`
+}
+
+// HandleExpiries deals with open positions in derivative contracts. Positions
+// expiring today are closed or left for settlement according to action; any
+// position whose contract has already expired is flagged as expired, since it
+// can no longer be traded; it then drops out of the open positions and stops
+// blocking margin.
+func (s *OMSService) HandleExpiries(action ExpiryAction) (*ExpiryReport, error) {
+	if action != ExpiryClose && action != ExpiryFlag {
+		return nil, fmt.Errorf("invalid expiry action %q", action)
+	}
+	s = s.because("contract expiry")
+
+	now := time.Now()
+	report := &ExpiryReport{
+		RanAt:            now.In(calendar.IST),
+		Action:           action,
+		ClosedPositions:  []string{},
+		FlaggedPositions: []string{},
+	}
+
+	positions, err := s.repo.GetOpenPositions()
+	if err != nil {
+		return nil, err
+	}
+	for _, position := range positions {
+		if position.Contract == nil {
+			continue
+		}
+
+		switch {
+		case instruments.ExpiryPassed(position.Contract.Expiry, now):
+			position.Status = string(models.PositionStatusExpired)
+			if err := s.repo.UpdatePosition(position); err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("flag position %s: %v", position.ID, err))
+				continue
+			}
+			report.FlaggedPositions = append(report.FlaggedPositions, position.ID)
+
+		case instruments.ExpiresOn(position.Contract.Expiry, now) && action == ExpiryClose:
+			if err := s.ClosePosition(position.ID); err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("close position %s: %v", position.ID, err))
+				continue
+			}
+			report.ClosedPositions = append(report.ClosedPositions, position.ID)
+		}
+	}
+
+	s.events.Publish(events.ExpiryProcessed, report)
+	return report, nil
+}
+
+// ExpiryScheduler runs HandleExpiries at a fixed time on every F&O trading day
+type ExpiryScheduler struct {
+	service *OMSService
+	logger  *log.Logger
+	runAt   time.Duration // offset from midnight IST
+	action  ExpiryAction
+}
+
+func NewExpiryScheduler(logger *log.Logger, service *OMSService, cfg ExpiryConfig) (*ExpiryScheduler, error) {
+	runAt, err := parseTimeOfDay(cfg.RunAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expiry run time: %w", err)
+	}
+	if cfg.Action != ExpiryClose && cfg.Action != ExpiryFlag {
+		return nil, fmt.Errorf("invalid expiry action %q", cfg.Action)
+	}
+	return &ExpiryScheduler{
+		service: service,
+		logger:  logger,
+		runAt:   runAt,
+		action:  cfg.Action,
+	}, nil
+}
+
+// Run blocks until ctx is cancelled, or the calendar has no trading day ahead
+func (j *ExpiryScheduler) Run(ctx context.Context) {
+	after := time.Now()
+	for {
+		next, err := j.service.nextTradingAt(models.SegmentFNO, after, j.runAt)
+		if err != nil {
+			j.logger.Printf("Expiry runs stopped: %v", err)
+			return
+		}
+		after = next
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		report, err := j.service.As(audit.Source{Actor: audit.System("expiry")}).HandleExpiries(j.action)
+		if err != nil {
+			j.logger.Printf("Expiry run failed: %v", err)
+			continue
+		}
+		j.logger.Printf("Expiry run closed %d positions, flagged %d, %d errors",
+			len(report.ClosedPositions), len(report.FlaggedPositions), len(report.Errors))
+	}
+}
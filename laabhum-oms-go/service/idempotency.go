@@ -0,0 +1,167 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+)
+
+// ErrIdempotencyConflict is returned when a key is reused for a different
+// order within the dedupe window
+var ErrIdempotencyConflict = errors.New("idempotency key already used")
+
+// DefaultIdempotencyWindow is how long a submission is remembered, long
+// enough to cover retries through a trading day
+const DefaultIdempotencyWindow = 24 * time.Hour
+
+// submissions serialises submissions under the same key, so a retry sent
+// while the first attempt is still being placed waits for its outcome. The
+// orders themselves are found in the repository, which keeps client order
+// IDs unique per user and account, so replays are recognised after a restart.
+// Keys are only held for the window: the next submission under a key after
+// it clears the key from the original order.
+type submissions struct {
+	mutex   sync.Mutex
+	window  time.Duration
+	pending map[string]chan struct{} // Closed once the submission under the key has finished
+}
+
+func newSubmissions(window time.Duration) *submissions {
+	return &submissions{window: window, pending: make(map[string]chan struct{})}
+}
+
+// claim takes key for the caller, or returns the channel of the submission
+// already holding it and false
+func (s *submissions) claim(key string) (chan struct{}, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if done, ok := s.pending[key]; ok {
+		return done, false
+	}
+	done := make(chan struct{})
+	s.pending[key] = done
+	return done, true
+}
+
+// finish releases a claimed key to the submissions waiting on it
+func (s *submissions) finish(key string, done chan struct{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.pending, key)
+	close(done)
+}
+
+// SetIdempotencyWindow changes how long submitted orders are deduplicated for
+func (s *OMSService) SetIdempotencyWindow(window time.Duration) {
+	s.submissions.mutex.Lock()
+	defer s.submissions.mutex.Unlock()
+	s.submissions.window = window
+}
+
+// SubmitOrder creates order once per idempotency key, falling back to the
+// order's client order ID when key is empty; the key is stored as the
+// order's client order ID. Keys belong to the caller's user and account.
+// Resubmitting the same order under a key within the dedupe window returns
+// the original order, as it is now, and true, and a different order fails
+// with ErrIdempotencyConflict. Once the window has passed the key places a
+// new order. Without either ID this is CreateOrder.
+func (s *OMSService) SubmitOrder(key string, order models.Order) (*models.Order, bool, error) {
+	order.SubmissionHash = ""
+	if key == "" {
+		key = order.ClientOrderID
+	}
+	if key == "" {
+		created, err := s.CreateOrder(order)
+		return created, false, err
+	}
+	if order.ClientOrderID != "" && order.ClientOrderID != key {
+		return nil, false, invalid(fmt.Errorf("idempotency key %q differs from client order ID %q", key, order.ClientOrderID))
+	}
+	fingerprint, err := orderFingerprint(order)
+	if err != nil {
+		return nil, false, err
+	}
+	order.ClientOrderID, order.SubmissionHash = key, fingerprint
+
+	// The owner the order is stored with, as the recorder stamps it
+	userID, accountID := order.UserID, order.AccountID
+	if s.source.IsUser() {
+		userID, accountID = s.source.UserID(), s.source.Account
+	}
+	claimKey := userID + "\x00" + accountID + "\x00" + key
+
+	for {
+		done, claimed := s.submissions.claim(claimKey)
+		if !claimed {
+			<-done
+			continue
+		}
+		original, err := s.repo.GetOrderByClientOrderID(userID, accountID, key)
+		if err == nil && !s.submissions.holds(original) {
+			if err = s.releaseKey(*original); err == nil {
+				err = repository.ErrNotFound
+			}
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			var created *models.Order
+			created, err = s.CreateOrder(order)
+			if !errors.Is(err, repository.ErrDuplicateClientOrderID) {
+				s.submissions.finish(claimKey, done)
+				return created, false, err
+			}
+			// Placed by another OMS on the same store since the lookup
+			original, err = s.repo.GetOrderByClientOrderID(userID, accountID, key)
+		}
+		s.submissions.finish(claimKey, done)
+		if err != nil {
+			return nil, false, fmt.Errorf("reading the order placed under this key: %w", err)
+		}
+		return s.replay(original, fingerprint)
+	}
+}
+
+// holds reports whether order was placed recently enough to keep its key
+func (s *submissions) holds(order *models.Order) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return time.Since(time.Unix(order.CreatedAt, 0)) < s.window
+}
+
+// releaseKey clears the client order ID of an order placed before the dedupe
+// window, so its key can place a new order. The audit trail keeps the ID.
+func (s *OMSService) releaseKey(order models.Order) error {
+	key := order.ClientOrderID
+	order.ClientOrderID, order.SubmissionHash = "", ""
+	if err := s.repo.UpdateOrder(order); err != nil {
+		return fmt.Errorf("releasing key %q from order %s: %w", key, order.ID, err)
+	}
+	return nil
+}
+
+// replay returns the order placed under a key for a resubmission with fingerprint
+func (s *OMSService) replay(original *models.Order, fingerprint string) (*models.Order, bool, error) {
+	if original.SubmissionHash != fingerprint {
+		return nil, false, fmt.Errorf("%w for a different order", ErrIdempotencyConflict)
+	}
+	return original, true, nil
+}
+
+// orderFingerprint hashes what the caller submitted, leaving out the fields the OMS assigns
+func orderFingerprint(order models.Order) (string, error) {
+	order.ID, order.Status, order.CreatedAt = "", "", 0
+	order.UserID, order.AccountID = "", ""
+	order.ClientOrderID, order.SubmissionHash = "", ""
+	data, err := json.Marshal(order)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/instruments"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+)
+
+// SetInstruments enables instrument validation against master. Without a
+// master, any symbol is accepted, in the same EXCHANGE:SYMBOL form.
+func (s *OMSService) SetInstruments(master *instruments.Master) {
+	s.instruments = master
+}
+
+// Instruments returns the instrument master, or nil if none is loaded
+func (s *OMSService) Instruments() *instruments.Master {
+	return s.instruments
+}
+
+// applyInstrument normalizes the order's symbol and checks its price and
+// quantity against the instrument's tick size, circuit limits, lot size and
+// freeze quantity
+func (s *OMSService) applyInstrument(order *models.Order) error {
+	if s.instruments == nil {
+		order.Symbol = instruments.Normalize(order.Symbol)
+		return nil
+	}
+
+	inst, err := s.instruments.Lookup(order.Symbol)
+	if err != nil {
+		return err
+	}
+	if order.Segment != "" && order.Segment != inst.Segment {
+		return fmt.Errorf("%s trades in segment %s, not %s", inst.Key(), inst.Segment, order.Segment)
+	}
+	if inst.Expired(time.Now()) {
+		return fmt.Errorf("%s expired on %s", inst.Key(), inst.Expiry)
+	}
+	order.Symbol = inst.Key()
+	order.Segment = inst.Segment
+	order.Contract = inst.Contract()
+
+	if err := inst.ValidateQuantity(order.Quantity); err != nil {
+		return err
+	}
+	if order.Type != models.MarketOrder {
+		if err := inst.ValidatePrice(order.Price); err != nil {
+			return err
+		}
+	}
+	if order.StopPrice > 0 {
+		if err := inst.ValidatePrice(order.StopPrice); err != nil {
+			return fmt.Errorf("stop price: %w", err)
+		}
+	}
+	return nil
+}
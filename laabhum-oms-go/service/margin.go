@@ -0,0 +1,388 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/Mukilan-T/laabhum-oms-go/instruments"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInsufficientFunds    = errors.New("insufficient funds")
+	ErrInsufficientHoldings = errors.New("insufficient holdings")
+)
+
+// DefaultAccountBalance is the capital each account starts with
+const DefaultAccountBalance = 10000.0
+
+// MarginRule describes how much of an order's value must be blocked for a product
+type MarginRule struct {
+	Fraction float64 // share of price * quantity blocked as margin
+}
+
+// DefaultMarginRules are the margin requirements applied per product type
+var DefaultMarginRules = map[models.ProductType]MarginRule{
+	models.ProductMIS:  {Fraction: 0.20}, // 5x intraday leverage
+	models.ProductCNC:  {Fraction: 1.00}, // delivery is fully paid
+	models.ProductNRML: {Fraction: 0.40}, // carry-forward needs more than intraday
+}
+
+// Account holds the cash balance and delivery holdings of one trading account
+type Account struct {
+	mutex    sync.RWMutex
+	balance  float64
+	holdings map[string]int
+	margin   sync.Mutex // Held from checking funds until the change blocking them is stored
+}
+
+func newAccount(balance float64) *Account {
+	return &Account{
+		balance:  balance,
+		holdings: make(map[string]int),
+	}
+}
+
+// Balance returns the cash balance of the account
+func (a *Account) Balance() float64 {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	return a.balance
+}
+
+// SetBalance replaces the cash balance of the account
+func (a *Account) SetBalance(balance float64) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.balance = balance
+}
+
+// Holdings returns the delivery quantity held for symbol, in any form instruments.Normalize accepts
+func (a *Account) Holdings(symbol string) int {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	return a.holdings[instruments.Normalize(symbol)]
+}
+
+// SetHoldings replaces the delivery quantity held for symbol
+func (a *Account) SetHoldings(symbol string, quantity int) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.holdings[instruments.Normalize(symbol)] = quantity
+}
+
+// reserve takes the account's margin for one check-and-store step, so two
+// orders cannot both be approved against the same available funds. The
+// returned function releases it.
+func (a *Account) reserve() func() {
+	a.margin.Lock()
+	return a.margin.Unlock
+}
+
+// Accounts holds every trading account by AccountID. Orders placed without
+// an account share the one under "". Accounts are opened on first use with
+// the opening balance.
+type Accounts struct {
+	mutex    sync.Mutex
+	opening  float64
+	accounts map[string]*Account
+}
+
+func newAccounts(opening float64) *Accounts {
+	return &Accounts{opening: opening, accounts: make(map[string]*Account)}
+}
+
+// SetOpeningBalance changes the cash balance accounts are opened with
+func (a *Accounts) SetOpeningBalance(balance float64) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.opening = balance
+}
+
+// Get returns the account with id, opening it if it is not known yet
+func (a *Accounts) Get(id string) *Account {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	account, ok := a.accounts[id]
+	if !ok {
+		account = newAccount(a.opening)
+		a.accounts[id] = account
+	}
+	return account
+}
+
+// SetMarginRules replaces the margin requirements applied per product type
+func (s *OMSService) SetMarginRules(rules map[models.ProductType]MarginRule) {
+	s.marginRules = rules
+}
+
+// Accounts exposes the accounts backing margin and holdings checks
+func (s *OMSService) Accounts() *Accounts {
+	return s.accounts
+}
+
+// accountOf returns the account order is stored under: the caller's when
+// they name one, as the recorder stamps it, otherwise the order's own
+func (s *OMSService) accountOf(order models.Order) string {
+	if s.source.IsUser() {
+		return s.source.Account
+	}
+	return order.AccountID
+}
+
+// defaultProduct picks a product for orders that don't specify one
+func defaultProduct(strategy models.TradeStrategy) models.ProductType {
+	if strategy == models.StrategyPositionTrading {
+		return models.ProductCNC
+	}
+	return models.ProductMIS
+}
+
+// RequiredMargin returns the margin blocked for quantity units at price under product
+func (s *OMSService) RequiredMargin(product models.ProductType, price float64, quantity int) (float64, error) {
+	rule, ok := s.marginRules[product]
+	if !ok {
+		return 0, fmt.Errorf("no margin rule for product %q", product)
+	}
+	return price * float64(quantity) * rule.Fraction, nil
+}
+
+// hedge is the legs of one parent order in one underlying
+type hedge struct {
+	parentID   string
+	underlying string
+}
+
+// marginBook totals blocked margin. Legs of one parent order in the same
+// underlying hedge each other, so each such group blocks the larger of its
+// bought and sold margin rather than both.
+type marginBook struct {
+	standalone float64
+	hedged     map[hedge]*[2]float64 // Bought and sold margin
+}
+
+func newMarginBook() *marginBook {
+	return &marginBook{hedged: make(map[hedge]*[2]float64)}
+}
+
+// add blocks margin for an order on side placed under parentID, empty for standalone orders
+func (b *marginBook) add(parentID, underlying string, side models.Side, margin float64) {
+	if parentID == "" {
+		b.standalone += margin
+		return
+	}
+	key := hedge{parentID, underlying}
+	sides, ok := b.hedged[key]
+	if !ok {
+		sides = &[2]float64{}
+		b.hedged[key] = sides
+	}
+	if side == models.SideSell {
+		sides[1] += margin
+	} else {
+		sides[0] += margin
+	}
+}
+
+func (b *marginBook) total() float64 {
+	total := b.standalone
+	for _, sides := range b.hedged {
+		total += math.Max(sides[0], sides[1])
+	}
+	return total
+}
+
+// underlyingOf returns what a symbol's risk moves with: a derivative
+// contract's underlying, or the symbol itself
+func underlyingOf(symbol string, contract *models.Contract) string {
+	if contract != nil && contract.Underlying != "" {
+		return contract.Underlying
+	}
+	return symbol
+}
+
+// UsedMargin sums the margin blocked in account by pending and queued orders
+// and open positions, offsetting the hedged legs of multi-leg orders
+func (s *OMSService) UsedMargin(account string) (float64, error) {
+	book := newMarginBook()
+
+	pending, err := s.repo.GetOrders(repository.OrderFilter{Status: models.OrderStatusPending})
+	if err != nil {
+		return 0, err
+	}
+	queued, err := s.repo.GetOrders(repository.OrderFilter{Status: models.OrderStatusQueued})
+	if err != nil {
+		return 0, err
+	}
+	for _, order := range append(pending, queued...) {
+		if order.AccountID != account {
+			continue
+		}
+		if order.Type == models.MultiLegOrderType {
+			continue // its legs carry the margin
+		}
+		if order.Side == "sell" && order.Product == models.ProductCNC {
+			continue // delivery sells are backed by holdings, not cash
+		}
+		margin, err := s.RequiredMargin(order.Product, order.Price, order.Quantity)
+		if err != nil {
+			continue
+		}
+		book.add(order.ParentID, underlyingOf(order.Symbol, order.Contract), order.Side, margin)
+	}
+
+	positions, err := s.repo.GetOpenPositions()
+	if err != nil {
+		return 0, err
+	}
+	for _, position := range positions {
+		if position.AccountID != account {
+			continue
+		}
+		margin, err := s.RequiredMargin(position.Product, position.EntryPrice, position.Quantity)
+		if err != nil {
+			continue
+		}
+		// The opening order says which parent and side the position belongs to
+		parentID, side := "", models.SideBuy
+		if order, err := s.repo.GetOrder(position.OrderID); err == nil {
+			parentID, side = order.ParentID, order.Side
+		}
+		book.add(parentID, underlyingOf(position.Symbol, position.Contract), side, margin)
+	}
+
+	return book.total(), nil
+}
+
+// AvailableFunds returns the cash left in account after blocked margin
+func (s *OMSService) AvailableFunds(account string) (float64, error) {
+	used, err := s.UsedMargin(account)
+	if err != nil {
+		return 0, err
+	}
+	return s.accounts.Get(account).Balance() - used, nil
+}
+
+// checkFunds verifies an opening order can be backed by the funds or
+// holdings of account
+func (s *OMSService) checkFunds(account string, order models.Order) error {
+	if order.Side == "sell" && order.Product == models.ProductCNC {
+		if held := s.accounts.Get(account).Holdings(order.Symbol); held < order.Quantity {
+			return fmt.Errorf("%w: %s holds %d, order needs %d", ErrInsufficientHoldings, order.Symbol, held, order.Quantity)
+		}
+		return nil
+	}
+
+	required, err := s.RequiredMargin(order.Product, order.Price, order.Quantity)
+	if err != nil {
+		return err
+	}
+	available, err := s.AvailableFunds(account)
+	if err != nil {
+		return err
+	}
+	if required > available {
+		return fmt.Errorf("%w: required %.2f, available %.2f", ErrInsufficientFunds, required, available)
+	}
+	return nil
+}
+
+// conversionAllowed reports whether a position may move between the two products
+func conversionAllowed(from, to models.ProductType) bool {
+	switch {
+	case from == models.ProductMIS && (to == models.ProductCNC || to == models.ProductNRML):
+		return true
+	case to == models.ProductMIS && (from == models.ProductCNC || from == models.ProductNRML):
+		return true
+	}
+	return false
+}
+
+// ConvertPosition moves all or part of an open position to another product type,
+// checking that the account can carry the new margin or holdings requirement
+func (s *OMSService) ConvertPosition(req models.PositionConversion) (*models.Position, error) {
+	if !req.FromProduct.IsValid() || !req.ToProduct.IsValid() {
+		return nil, invalid(errors.New("invalid product type"))
+	}
+	if !conversionAllowed(req.FromProduct, req.ToProduct) {
+		return nil, invalid(fmt.Errorf("cannot convert %s position to %s", req.FromProduct, req.ToProduct))
+	}
+	s = s.because(fmt.Sprintf("product conversion %s to %s", req.FromProduct, req.ToProduct))
+	stored, err := s.repo.GetPosition(req.PositionID)
+	if err != nil {
+		return nil, err
+	}
+	account := s.accounts.Get(stored.AccountID)
+	release := account.reserve()
+	defer release()
+	// Read again under the reservation, in case another conversion changed it
+	if stored, err = s.repo.GetPosition(req.PositionID); err != nil {
+		return nil, err
+	}
+	position := *stored
+	if position.Product != req.FromProduct {
+		return nil, invalid(fmt.Errorf("position %s is %s, not %s", position.ID, position.Product, req.FromProduct))
+	}
+
+	quantity := req.Quantity
+	if quantity == 0 {
+		quantity = position.Quantity
+	}
+	if quantity < 0 || quantity > position.Quantity {
+		return nil, invalid(fmt.Errorf("invalid conversion quantity %d for position of %d", quantity, position.Quantity))
+	}
+
+	current, err := s.RequiredMargin(req.FromProduct, position.EntryPrice, quantity)
+	if err != nil {
+		return nil, err
+	}
+	next, err := s.RequiredMargin(req.ToProduct, position.EntryPrice, quantity)
+	if err != nil {
+		return nil, err
+	}
+	if extra := next - current; extra > 0 {
+		available, err := s.AvailableFunds(position.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		if extra > available {
+			return nil, fmt.Errorf("%w: conversion needs %.2f more, available %.2f", ErrInsufficientFunds, extra, available)
+		}
+	}
+
+	// A short carried into delivery has to be covered by holdings
+	if req.ToProduct == models.ProductCNC {
+		if order, err := s.repo.GetOrder(position.OrderID); err == nil && order.Side == "sell" {
+			if held := account.Holdings(position.Symbol); held < quantity {
+				return nil, fmt.Errorf("%w: %s holds %d, conversion needs %d", ErrInsufficientHoldings, position.Symbol, held, quantity)
+			}
+		}
+	}
+
+	if quantity == position.Quantity {
+		position.Product = req.ToProduct
+		if err := s.repo.UpdatePosition(position); err != nil {
+			return nil, err
+		}
+		return s.repo.GetPosition(position.ID)
+	}
+
+	// Partial conversion splits the position in two
+	position.Quantity -= quantity
+	if err := s.repo.UpdatePosition(position); err != nil {
+		return nil, err
+	}
+
+	converted := position
+	converted.ID = uuid.NewString()
+	converted.Quantity = quantity
+	converted.Product = req.ToProduct
+	if err := s.repo.CreatePosition(converted); err != nil {
+		return nil, err
+	}
+	return s.repo.GetPosition(converted.ID)
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/instruments"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/events"
+)
+
+// Events returns the publisher OMS events are sent to
+func (s *OMSService) Events() events.Publisher {
+	return s.events
+}
+
+// RecordTick stores the latest market price for a symbol, publishes it to
+// local subscribers and marks every open position in it to that price, so
+// subscribers see both the tick and the resulting change in unrealised PnL
+func (s *OMSService) RecordTick(condition models.MarketCondition) error {
+	if condition.Symbol == "" {
+		return errors.New("symbol is required")
+	}
+	if condition.Price <= 0 {
+		return errors.New("price must be positive")
+	}
+	condition.Symbol = instruments.Normalize(condition.Symbol)
+	if condition.Timestamp.IsZero() {
+		condition.Timestamp = time.Now()
+	}
+	if err := s.repo.SaveMarketCondition(condition); err != nil {
+		return err
+	}
+	if event, err := events.TickEvent(condition); err == nil {
+		s.events.Publish(event.Type, event)
+	}
+
+	positions, err := s.repo.GetOpenPositions()
+	if err != nil {
+		return err
+	}
+	for _, position := range positions {
+		if position.Symbol != condition.Symbol || position.CurrentPrice == condition.Price {
+			continue
+		}
+		position.CurrentPrice = condition.Price
+		position.LastUpdatedAt = condition.Timestamp
+		if err := s.repo.UpdatePosition(position); err != nil {
+			return err
+		}
+	}
+	return nil
+}
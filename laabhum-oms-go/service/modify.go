@@ -0,0 +1,138 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+)
+
+// ModifyOrder changes the quantity, price or stop price of an order that has
+// not been filled yet. Zero fields in changes keep their current value. The
+// modified order is validated against the instrument and must still fit within
+// the account's funds.
+func (s *OMSService) ModifyOrder(orderID string, changes models.Order) (*models.Order, error) {
+	s = s.because("modify requested")
+
+	if changes.Quantity < 0 || changes.Price < 0 || changes.StopPrice < 0 {
+		return nil, ErrInvalidOrder
+	}
+	stored, err := s.repo.GetOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if stored.Status != models.OrderStatusPending && stored.Status != models.OrderStatusQueued {
+		return nil, badState(fmt.Errorf("order %s is %s and can no longer be modified", orderID, stored.Status))
+	}
+
+	original := *stored
+	order := *stored
+	if changes.Quantity > 0 {
+		order.Quantity = changes.Quantity
+	}
+	if changes.Price > 0 {
+		order.Price = changes.Price
+	}
+	if changes.StopPrice > 0 {
+		order.StopPrice = changes.StopPrice
+	}
+	if err := s.applyInstrument(&order); err != nil {
+		return nil, invalid(err)
+	}
+	release := s.accounts.Get(stored.AccountID).reserve()
+	defer release()
+	if err := s.checkModifiedFunds(original, order); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateOrder(order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// SetStopLoss arms or disarms the stop loss of an order that has not been
+// filled yet. Arming needs a stop price to trigger at.
+func (s *OMSService) SetStopLoss(orderID string, active bool) (*models.Order, error) {
+	if active {
+		s = s.because("stop loss activated")
+	} else {
+		s = s.because("stop loss cancelled")
+	}
+
+	stored, err := s.repo.GetOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if stored.Status != models.OrderStatusPending && stored.Status != models.OrderStatusQueued {
+		return nil, badState(fmt.Errorf("order %s is %s and can no longer be modified", orderID, stored.Status))
+	}
+	if active && stored.StopPrice <= 0 {
+		return nil, invalid(fmt.Errorf("order %s has no stop price to activate a stop loss at", orderID))
+	}
+
+	order := *stored
+	order.StopLossActivated = active
+	if err := s.repo.UpdateOrder(order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// checkModifiedFunds verifies the account can carry the difference between an
+// order as stored, whose margin is already blocked, and as modified
+func (s *OMSService) checkModifiedFunds(original, modified models.Order) error {
+	if modified.Side == "sell" && modified.Product == models.ProductCNC {
+		return s.checkFunds(modified.AccountID, modified)
+	}
+
+	before, err := s.RequiredMargin(original.Product, original.Price, original.Quantity)
+	if err != nil {
+		return err
+	}
+	after, err := s.RequiredMargin(modified.Product, modified.Price, modified.Quantity)
+	if err != nil {
+		return err
+	}
+	if after <= before {
+		return nil
+	}
+	available, err := s.AvailableFunds(modified.AccountID)
+	if err != nil {
+		return err
+	}
+	if after-before > available {
+		return fmt.Errorf("%w: modification needs %.2f more, available %.2f", ErrInsufficientFunds, after-before, available)
+	}
+	return nil
+}
+
+// GetPositions returns the open positions, only those opened by userID's
+// orders when it is not empty
+func (s *OMSService) GetPositions(userID string) ([]models.Position, error) {
+	positions, err := s.repo.GetOpenPositions()
+	if err != nil {
+		return nil, err
+	}
+	owned := []models.Position{}
+	for _, position := range positions {
+		if userID == "" || position.UserID == userID {
+			owned = append(owned, position)
+		}
+	}
+	return owned, nil
+}
+
+// GetPosition returns one position without repricing it
+func (s *OMSService) GetPosition(id string) (*models.Position, error) {
+	return s.repo.GetPosition(id)
+}
+
+// GetOrder returns a copy of a stored order
+func (s *OMSService) GetOrder(id string) (*models.Order, error) {
+	stored, err := s.repo.GetOrder(id)
+	if err != nil {
+		return nil, err
+	}
+	order := *stored
+	return &order, nil
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/audit"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	minLegs = 2
+	maxLegs = 4
+)
+
+// validateLeg runs the per-order checks createOrder would, without storing anything
+func (s *OMSService) validateLeg(order *models.Order) error {
+	if order.Side != "buy" && order.Side != "sell" {
+		return invalid(fmt.Errorf("invalid side %q", order.Side))
+	}
+	if order.Type != models.LimitOrder && order.Type != models.MarketOrder {
+		return invalid(fmt.Errorf("invalid leg order type %q", order.Type))
+	}
+	if order.Price <= 0 || order.Quantity <= 0 {
+		return ErrInvalidOrder
+	}
+	if err := s.applyInstrument(order); err != nil {
+		return invalid(err)
+	}
+	if !order.Product.IsValid() {
+		return invalid(fmt.Errorf("invalid product type %q", order.Product))
+	}
+	if order.Segment == "" {
+		order.Segment = models.SegmentEquity
+	}
+	queue, err := s.checkSession(*order)
+	if err != nil {
+		return err
+	}
+	if queue {
+		return invalid(errors.New("multi-leg orders cannot be queued until the session opens"))
+	}
+	return nil
+}
+
+// CreateMultiLegOrder validates every leg up front, checks the combined margin,
+// then places the legs as children of a single parent. If any leg is rejected,
+// legs already placed are cancelled, or unwound if they have filled, and the
+// parent is marked rejected.
+func (s *OMSService) CreateMultiLegOrder(req models.MultiLegOrder) (*models.MultiLegSummary, error) {
+	if len(req.Legs) < minLegs || len(req.Legs) > maxLegs {
+		return nil, invalid(fmt.Errorf("multi-leg orders need %d to %d legs, got %d", minLegs, maxLegs, len(req.Legs)))
+	}
+	if req.Quantity <= 0 {
+		return nil, invalid(errors.New("invalid multi-leg quantity"))
+	}
+	if req.Product == "" {
+		req.Product = defaultProduct(req.Strategy)
+	}
+
+	parentID := uuid.NewString()
+	legs := make([]models.Order, len(req.Legs))
+	book := newMarginBook()
+	for i, leg := range req.Legs {
+		if leg.Ratio <= 0 {
+			return nil, invalid(fmt.Errorf("leg %d: ratio must be positive", i+1))
+		}
+		order := models.Order{
+			Symbol:   leg.Symbol,
+			Quantity: req.Quantity * leg.Ratio,
+			Price:    leg.Price,
+			Side:     leg.Side,
+			Type:     leg.Type,
+			Strategy: req.Strategy,
+			Product:  req.Product,
+			ParentID: parentID,
+			LegIndex: i + 1,
+		}
+		if err := s.validateLeg(&order); err != nil {
+			return nil, fmt.Errorf("leg %d (%s): %w", i+1, leg.Symbol, err)
+		}
+		if order.Side == "buy" || order.Product != models.ProductCNC {
+			margin, err := s.RequiredMargin(order.Product, order.Price, order.Quantity)
+			if err != nil {
+				return nil, err
+			}
+			book.add(parentID, underlyingOf(order.Symbol, order.Contract), order.Side, margin)
+		}
+		legs[i] = order
+	}
+
+	// Funds are checked for the structure as a whole, so hedged legs offset
+	// each other, and stay reserved until every leg is stored
+	account := s.accountOf(legs[0])
+	release := s.accounts.Get(account).reserve()
+	defer release()
+	for i, leg := range legs {
+		if leg.Side == "sell" && leg.Product == models.ProductCNC {
+			if held := s.accounts.Get(account).Holdings(leg.Symbol); held < leg.Quantity {
+				return nil, fmt.Errorf("leg %d (%s): %w: holds %d, leg needs %d", i+1, leg.Symbol, ErrInsufficientHoldings, held, leg.Quantity)
+			}
+		}
+	}
+	required := book.total()
+	available, err := s.AvailableFunds(account)
+	if err != nil {
+		return nil, err
+	}
+	if required > available {
+		return nil, fmt.Errorf("%w: legs require %.2f, available %.2f", ErrInsufficientFunds, required, available)
+	}
+
+	// The parent carries the net price per unit of the structure: a sell for
+	// a net credit, a buy for a net debit
+	net := 0.0
+	for _, leg := range legs {
+		if leg.Side == "sell" {
+			net += leg.Price * float64(leg.Quantity)
+		} else {
+			net -= leg.Price * float64(leg.Quantity)
+		}
+	}
+	net /= float64(req.Quantity)
+	side, price := models.Side("buy"), -net
+	if net > 0 {
+		side, price = "sell", net
+	}
+	parent, err := s.because(fmt.Sprintf("multi-leg order %q", req.Name)).repo.CreateOrder(models.Order{
+		ID:        parentID,
+		Symbol:    structureSymbol(legs),
+		Quantity:  req.Quantity,
+		Price:     price,
+		Side:      side,
+		Type:      models.MultiLegOrderType,
+		Status:    models.OrderStatusPending,
+		Strategy:  req.Strategy,
+		Product:   req.Product,
+		Segment:   legs[0].Segment,
+		CreatedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	placed := []models.Order{}
+	for i, leg := range legs {
+		created, err := s.storeOrder(leg, false, true)
+		if err != nil {
+			rollback := s.As(audit.Source{
+				Actor:     s.source.Actor,
+				Account:   s.source.Account,
+				Reason:    fmt.Sprintf("leg %d (%s) rejected: %v", i+1, leg.Symbol, err),
+				RequestID: s.source.RequestID,
+			})
+			rollbackErr := rollback.unwindLegs(placed)
+			rollback.repo.UpdateOrderStatus(parent.ID, models.OrderStatusRejected)
+			if rollbackErr != nil {
+				return nil, fmt.Errorf("leg %d (%s) rejected: %v; rollback incomplete: %v", i+1, leg.Symbol, err, rollbackErr)
+			}
+			return nil, fmt.Errorf("leg %d (%s) rejected, earlier legs rolled back: %w", i+1, leg.Symbol, err)
+		}
+		placed = append(placed, *created)
+	}
+
+	status := models.OrderStatusExecuted
+	for _, leg := range placed {
+		if leg.Status != models.OrderStatusExecuted {
+			status = models.OrderStatusPending
+		}
+	}
+	if err := s.repo.UpdateOrderStatus(parent.ID, status); err != nil {
+		return nil, err
+	}
+
+	return s.GetMultiLegOrder(parent.ID)
+}
+
+// structureSymbol names a multi-leg order by the underlying its legs share,
+// or by its first leg when they have none in common
+func structureSymbol(legs []models.Order) string {
+	symbol := underlyingOf(legs[0].Symbol, legs[0].Contract)
+	for _, leg := range legs[1:] {
+		if underlyingOf(leg.Symbol, leg.Contract) != symbol {
+			return legs[0].Symbol
+		}
+	}
+	return symbol
+}
+
+// unwindLegs cancels pending legs and closes positions opened by filled legs
+func (s *OMSService) unwindLegs(legs []models.Order) error {
+	var failures []string
+	for _, leg := range legs {
+		if leg.Status != models.OrderStatusExecuted {
+			if err := s.CancelOrder(leg.ID); err != nil {
+				failures = append(failures, fmt.Sprintf("cancel %s: %v", leg.ID, err))
+			}
+			continue
+		}
+
+		position, err := s.positionForOrder(leg.ID)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("find position for %s: %v", leg.ID, err))
+			continue
+		}
+		position.CurrentPrice = leg.Price
+		if err := s.repo.UpdatePosition(*position); err != nil {
+			failures = append(failures, fmt.Sprintf("update position %s: %v", position.ID, err))
+			continue
+		}
+		if err := s.ClosePosition(position.ID); err != nil {
+			failures = append(failures, fmt.Sprintf("close position %s: %v", position.ID, err))
+		}
+	}
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// positionForOrder finds the open position created by orderID
+func (s *OMSService) positionForOrder(orderID string) (*models.Position, error) {
+	positions, err := s.repo.GetOpenPositions()
+	if err != nil {
+		return nil, err
+	}
+	for _, position := range positions {
+		if position.OrderID == orderID {
+			return &position, nil
+		}
+	}
+	return nil, fmt.Errorf("position %w", repository.ErrNotFound)
+}
+
+// GetMultiLegOrder reports a multi-leg parent with its legs, net premium and combined position
+func (s *OMSService) GetMultiLegOrder(parentID string) (*models.MultiLegSummary, error) {
+	parent, err := s.repo.GetOrder(parentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent.Type != models.MultiLegOrderType {
+		return nil, fmt.Errorf("order %s is not a multi-leg order", parentID)
+	}
+
+	children, err := s.repo.GetOrders(repository.OrderFilter{ParentID: parentID})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.MultiLegSummary{Parent: *parent, Legs: []models.Order{}, Position: []models.LegPosition{}}
+	net := make(map[string]int)
+	for _, leg := range children {
+		summary.Legs = append(summary.Legs, leg)
+		if leg.Status == models.OrderStatusCancelled {
+			continue
+		}
+		if parent.Status == models.OrderStatusRejected && leg.Status == models.OrderStatusExecuted {
+			// A filled leg of a rejected order counts only while its position is still open
+			if _, err := s.positionForOrder(leg.ID); errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+		}
+
+		value := leg.Price * float64(leg.Quantity)
+		if leg.Side == "sell" {
+			summary.NetPremium += value
+			net[leg.Symbol] -= leg.Quantity
+		} else {
+			summary.NetPremium -= value
+			net[leg.Symbol] += leg.Quantity
+		}
+	}
+
+	sort.SliceStable(summary.Legs, func(i, j int) bool {
+		a, b := summary.Legs[i], summary.Legs[j]
+		if a.LegIndex != b.LegIndex {
+			return a.LegIndex < b.LegIndex
+		}
+		return a.CreatedAt < b.CreatedAt // Legs stored before leg indexes
+	})
+	for symbol, quantity := range net {
+		summary.Position = append(summary.Position, models.LegPosition{Symbol: symbol, Quantity: quantity})
+	}
+	sort.Slice(summary.Position, func(i, j int) bool { return summary.Position[i].Symbol < summary.Position[j].Symbol })
+
+	return summary, nil
+}
@@ -4,17 +4,51 @@ import (
 	"errors"
 	"time"
     "fmt"
+	"github.com/Mukilan-T/laabhum-oms-go/audit"
+	"github.com/Mukilan-T/laabhum-oms-go/calendar"
+	"github.com/Mukilan-T/laabhum-oms-go/instruments"
 	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/events"
 	"github.com/Mukilan-T/laabhum-oms-go/repository"
 	"github.com/google/uuid"
 )
 
 type OMSService struct {
-    repo repository.OrderRepository
+    // repo records every change in the audit trail; store is the bare repository underneath
+    repo        repository.OrderRepository
+    store       repository.OrderRepository
+    trail       *audit.Trail
+    source      audit.Source
+    accounts    *Accounts
+    marginRules map[models.ProductType]MarginRule
+    events      events.Publisher
+    calendar    *calendar.Calendar
+    clock       func() time.Time // the time orders are checked against the calendar at
+    instruments *instruments.Master
+    submissions *submissions // orders by idempotency key, shared by every view
 }
 
 func NewOMSService(repo repository.OrderRepository) *OMSService {
-    return &OMSService{repo: repo}
+    cal, _ := calendar.New(calendar.DefaultSessions, nil)
+    trail := audit.NewTrail()
+    source := audit.Source{Actor: audit.System("oms")}
+    return &OMSService{
+        repo:        audit.NewRecorder(repo, trail, source),
+        store:       repo,
+        trail:       trail,
+        source:      source,
+        accounts:    newAccounts(DefaultAccountBalance),
+        marginRules: DefaultMarginRules,
+        events:      events.NewBus(),
+        calendar:    cal,
+        clock:       time.Now,
+        submissions: newSubmissions(DefaultIdempotencyWindow),
+    }
+}
+
+// SetEventPublisher replaces the publisher OMS events are sent to
+func (s *OMSService) SetEventPublisher(publisher events.Publisher) {
+    s.events = publisher
 }
 
 type InMemoryOrderRepository struct {
@@ -51,7 +85,7 @@ func (r *InMemoryOrderRepository) ExecuteChildOrder(parentID, childID string) er
 func (r *InMemoryOrderRepository) GetTrades(parentID string) ([]models.Trade, error) {
     trades, ok := r.trades[parentID]
     if !ok {
-        return nil, errors.New("trades not found for the given parentID")
+        return nil, fmt.Errorf("trades %w for the given parentID", repository.ErrNotFound)
     }
     return trades, nil
 }
@@ -89,7 +123,7 @@ func (r *InMemoryOrderRepository) UpdateOrderStatus(orderID, status string) erro
     // Implement the method to satisfy the OrderRepository interface
     order, ok := r.orders[orderID]
     if !ok {
-        return errors.New("order not found")
+        return fmt.Errorf("order %w", repository.ErrNotFound)
     }
     order.Status = models.OrderStatus(status)
     r.orders[orderID] = order
@@ -117,7 +151,7 @@ func (r *InMemoryOrderRepository) GetPosition(positionID string) (models.Positio
     // Implement the method to satisfy the OrderRepository interface
     position, ok := r.positions[positionID]
     if !ok {
-        return models.Position{}, errors.New("position not found")
+        return models.Position{}, fmt.Errorf("position %w", repository.ErrNotFound)
     }
     return position, nil
 }
@@ -126,7 +160,7 @@ func (r *InMemoryOrderRepository) ClosePosition(positionID string) error {
     // Implement the method to satisfy the OrderRepository interface
     position, ok := r.positions[positionID]
     if !ok {
-        return errors.New("position not found")
+        return fmt.Errorf("position %w", repository.ErrNotFound)
     }
     position.Status = string(models.PositionStatusClosed)
     r.positions[positionID] = position
@@ -136,7 +170,7 @@ func (r *InMemoryOrderRepository) ClosePosition(positionID string) error {
 // CreateScalperOrder processes high-frequency scalping orders with tight stop losses and quick profit-taking
 func (s *OMSService) CreateScalperOrder(order models.ScalperOrder) (*models.ScalperOrder, error) {
     if order.Price <= 0 || order.StopLoss <= 0 || order.RiskPercentage <= 0 {
-        return nil, errors.New("invalid scalper order parameters")
+        return nil, invalid(errors.New("invalid scalper order parameters"))
     }
 
     order.ID = uuid.NewString()
@@ -144,11 +178,12 @@ func (s *OMSService) CreateScalperOrder(order models.ScalperOrder) (*models.Scal
 
     // Ensure quick execution and tight risk management
     if order.Price <= order.StopLoss {
-        return nil, errors.New("price must be greater than stop loss")
+        return nil, invalid(errors.New("price must be greater than stop loss"))
     }
 
     // Calculate position size based on risk percentage
-    accountBalance := 10000.0 // Example account balance
+    // Scalper orders carry no account of their own
+    accountBalance := s.accounts.Get(s.accountOf(models.Order{})).Balance()
     riskAmount := accountBalance * order.RiskPercentage
     positionSize := riskAmount / (order.Price - order.StopLoss)
     order.Quantity = int(positionSize)
@@ -161,22 +196,81 @@ func (s *OMSService) ExecuteChildOrder(parentID, childID string) error {
     return s.repo.ExecuteChildOrder(childID)
 }
 
-// GetTrades retrieves executed trades for a given parent order ID
+// GetTrades retrieves executed trades for a given parent order ID, as an
+// empty list rather than nil when there are none
 func (s *OMSService) GetTrades(parentID string) ([]models.Trade, error) {
-    return s.repo.GetTrades(parentID)
+    trades, err := s.repo.GetTrades(parentID)
+    if err != nil {
+        return nil, err
+    }
+    if trades == nil {
+        trades = []models.Trade{}
+    }
+    return trades, nil
 }
 
 // CreateOrder creates a new order in the system (supports market, limit, and stop orders)
 func (s *OMSService) CreateOrder(order models.Order) (*models.Order, error) {
+    return s.createOrder(order, true)
+}
+
+// createOrder validates and stores an order. Opening orders are checked against
+// the trading session, funds and holdings, and open a position once executed.
+// Closing orders are not: they exit risk whenever they are placed, which for
+// square-off, stop-loss and expiry closes is often outside the normal session.
+func (s *OMSService) createOrder(order models.Order, opening bool) (*models.Order, error) {
     if order.Price <= 0 || order.Quantity <= 0 {
-        return nil, errors.New("invalid order parameters")
+        return nil, ErrInvalidOrder
+    }
+
+    // A closing order trades the position's own instrument, even once expired
+    if opening {
+        if err := s.applyInstrument(&order); err != nil {
+            return nil, invalid(err)
+        }
+    }
+    if order.Product == "" {
+        order.Product = defaultProduct(order.Strategy)
+    }
+    if !order.Product.IsValid() {
+        return nil, invalid(fmt.Errorf("invalid product type %q", order.Product))
+    }
+    if order.Segment == "" {
+        order.Segment = models.SegmentEquity
+    }
+    queue := false
+    if opening {
+        var err error
+        if queue, err = s.checkSession(order); err != nil {
+            return nil, err
+        }
+        account := s.accountOf(order)
+        release := s.accounts.Get(account).reserve()
+        defer release()
+        if err := s.checkFunds(account, order); err != nil {
+            return nil, err
+        }
     }
+    return s.storeOrder(order, queue, opening)
+}
 
+// storeOrder places a validated order, queued until the session opens or
+// with the status its type starts in, and opens the position of an executed
+// opening order. Opening orders need the account's margin reservation held.
+func (s *OMSService) storeOrder(order models.Order, queue, opening bool) (*models.Order, error) {
     order.ID = uuid.NewString()
     order.CreatedAt = time.Now().Unix()
 
     // Validate order type and strategy (e.g., Market, Limit, Stop)
-    if order.Type == models.MarketOrder {
+    if queue {
+        // Held until the next session open
+        order.Status = models.OrderStatusQueued
+        if order.AMO {
+            s = s.because("after-market order queued until the session opens")
+        } else {
+            s = s.because("pre-open market order queued for the session open")
+        }
+    } else if order.Type == models.MarketOrder {
         // Direct market execution
         order.Status = models.OrderStatusExecuted
     } else if order.Type == models.LimitOrder {
@@ -188,27 +282,38 @@ func (s *OMSService) CreateOrder(order models.Order) (*models.Order, error) {
     if err != nil {
         return nil, err
     }
+
+    if opening && createdOrder.Status == models.OrderStatusExecuted {
+        if err := s.openPosition(createdOrder); err != nil {
+            return nil, err
+        }
+    }
     return &createdOrder, nil
 }
 
+// openPosition records the position created by an executed opening order
+func (s *OMSService) openPosition(order models.Order) error {
+    return s.repo.CreatePosition(models.Position{
+        OrderID:      order.ID,
+        Symbol:       order.Symbol,
+        Quantity:     order.Quantity,
+        EntryPrice:   order.Price,
+        CurrentPrice: order.Price,
+        TakeProfit:   order.TakeProfit,
+        Strategy:     order.Strategy,
+        Product:      order.Product,
+        Contract:     order.Contract,
+        Status:       string(models.PositionStatusOpen),
+        UserID:       order.UserID,
+        AccountID:    order.AccountID,
+    })
+}
+
 func (s *OMSService) GetOrders(filter repository.OrderFilter) ([]models.Order, error) {
-    orders, err := s.repo.GetOrders(filter)
-    if err != nil {
-        return nil, err
+    if filter.Symbol != "" {
+        filter.Symbol = instruments.Normalize(filter.Symbol)
     }
-    var modelOrders []models.Order
-    for _, order := range orders {
-        modelOrders = append(modelOrders, models.Order{
-            ID:        order.ID,
-            Symbol:    order.Symbol,
-            Quantity:  order.Quantity,
-            Price:     order.Price,
-            Type:      models.OrderType(order.Type),
-            Status:    models.OrderStatus(order.Status),
-            CreatedAt: order.CreatedAt,
-        })
-    }
-    return modelOrders, nil
+    return s.repo.GetOrders(filter)
 }
 
 func (s *OMSService) validateOrderData(order map[string]interface{}) error {
@@ -306,10 +411,16 @@ func (s *OMSService) MonitorPositions() error {
         }
 
         // Profit-taking strategy
-        if currentPrice >= position.TakeProfit {
-            if err := s.ClosePosition(position.ID); err != nil {
+        if position.TakeProfit > 0 && currentPrice >= position.TakeProfit {
+            risk := s.As(audit.Source{
+                Actor:   audit.RiskEngine,
+                Account: s.source.Account,
+                Reason:  fmt.Sprintf("take profit %.2f reached at %.2f", position.TakeProfit, currentPrice),
+            })
+            if err := risk.ClosePosition(position.ID); err != nil {
                 return err
             }
+            continue
         }
 
         position.CurrentPrice = currentPrice
@@ -320,35 +431,52 @@ func (s *OMSService) MonitorPositions() error {
     return nil
 }
 
-// ClosePosition closes an open position by creating a sell order and updating the position status
+// ClosePosition closes an open position by creating an offsetting order and updating the position status
 func (s *OMSService) ClosePosition(positionID string) error {
     position, err := s.repo.GetPosition(positionID)
     if err != nil {
         return err
     }
 
-    // Create a closing sell order for the position
+    // Create a closing order on the opposite side of the one that opened the
+    // position, owned like it when the OMS closes it
+    side := models.SideSell
+    var userID, accountID string
+    if opening, err := s.repo.GetOrder(position.OrderID); err == nil {
+        if opening.Side == models.SideSell {
+            side = models.SideBuy
+        }
+        userID, accountID = opening.UserID, opening.AccountID
+    }
     closingOrder := models.Order{
         ID:        uuid.NewString(),
         Symbol:    position.Symbol,
         Quantity:  position.Quantity,
         Price:     position.CurrentPrice,
-        Side:      "sell",
+        Side:      side,
         Type:      models.MarketOrder,
         Status:    models.OrderStatusPending,
+        Strategy:  position.Strategy,
+        Product:   position.Product,
+        Contract:  position.Contract,
         CreatedAt: time.Now().Unix(),
+        UserID:    userID,
+        AccountID: accountID,
     }
 
-    if _, err := s.CreateOrder(closingOrder); err != nil {
+    if _, err := s.createOrder(closingOrder, false); err != nil {
         return err
     }
 
     return s.repo.ClosePosition(positionID)
 }
 
-// getCurrentPrice simulates real-time market data retrieval
+// getCurrentPrice returns the last recorded tick for symbol, falling back to a
+// simulated price until market data has been received
 func (s *OMSService) getCurrentPrice(symbol string) float64 {
-    // Replace this with actual market data API integration
+    if condition, err := s.repo.GetLatestMarketCondition(symbol); err == nil && condition != nil {
+        return condition.Price
+    }
     return 100.0
 }
 
@@ -388,7 +516,7 @@ func (s *OMSService) CreateCTC(order models.CTCOrder) (*models.CTCOrder, error)
 
     _, err := s.repo.CreateOrder(models.Order{
         ID:        order.ID,
-        Symbol:    order.Symbol,
+        Symbol:    instruments.Normalize(order.Symbol),
         Quantity:  order.Quantity,
         Price:     order.Price,
         Type:      models.CTCOrderType,
@@ -486,7 +614,7 @@ func (s *OMSService) ExecuteOrder(order models.Order) error {
 }
 
 func (s *OMSService) CancelOrder(orderID string) error {
-    // Implement the method to cancel an order
+    s = s.because("cancel requested")
     return s.repo.UpdateOrderStatus(orderID, models.OrderStatusCancelled)
 }
 
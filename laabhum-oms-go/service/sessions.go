@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/audit"
+	"github.com/Mukilan-T/laabhum-oms-go/calendar"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/events"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+)
+
+var ErrMarketClosed = errors.New("market is closed")
+
+// SetCalendar replaces the trading calendar orders are checked against
+func (s *OMSService) SetCalendar(cal *calendar.Calendar) {
+	s.calendar = cal
+}
+
+// SetClock replaces the clock trading sessions are judged by, so a session
+// can be pinned to a known market phase
+func (s *OMSService) SetClock(clock func() time.Time) {
+	s.clock = clock
+}
+
+// Calendar returns the trading calendar orders are checked against
+func (s *OMSService) Calendar() *calendar.Calendar {
+	return s.calendar
+}
+
+// checkSession decides whether an opening order can be placed in segment's
+// current session. It returns true when the order must be queued until the
+// normal session opens instead: AMOs placed outside market hours, and market
+// orders placed in pre-open, which are matched at the open.
+func (s *OMSService) checkSession(order models.Order) (bool, error) {
+	now := s.clock()
+	switch phase := s.calendar.Phase(order.Segment, now); phase {
+	case calendar.PhaseNormal:
+		return false, nil
+	case calendar.PhasePreOpen:
+		switch order.Type {
+		case models.LimitOrder:
+			return false, nil
+		case models.MarketOrder:
+			return true, nil
+		}
+		return false, fmt.Errorf("%w: only limit and market orders are accepted in %s", ErrMarketClosed, phase)
+	}
+
+	// An order is only queued if a session will release it
+	next, err := s.calendar.NextOpen(order.Segment, now)
+	if err != nil {
+		return false, invalid(fmt.Errorf("cannot place or queue the order: %v", err))
+	}
+	if order.AMO {
+		return true, nil
+	}
+	return false, fmt.Errorf("%w for segment %s until %s, place it as an AMO to queue it",
+		ErrMarketClosed, order.Segment, next.Format(time.RFC3339))
+}
+
+// ReleaseQueuedOrders submits queued orders whose segment is now open
+func (s *OMSService) ReleaseQueuedOrders() ([]string, error) {
+	s = s.because("after-market order released at market open")
+	queued, err := s.repo.GetOrders(repository.OrderFilter{Status: models.OrderStatusQueued})
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock()
+	released := []string{}
+	for _, q := range queued {
+		if !s.calendar.IsOpen(q.Segment, now) {
+			continue
+		}
+		stored, err := s.repo.GetOrder(q.ID)
+		if err != nil {
+			return released, err
+		}
+		order := *stored
+		if order.Type == models.MarketOrder {
+			order.Status = models.OrderStatusExecuted
+		} else {
+			order.Status = models.OrderStatusPending
+		}
+		if err := s.repo.UpdateOrder(order); err != nil {
+			return released, err
+		}
+		if order.Status == models.OrderStatusExecuted {
+			if err := s.openPosition(order); err != nil {
+				return released, err
+			}
+		}
+		released = append(released, order.ID)
+	}
+
+	if len(released) > 0 {
+		s.events.Publish(events.AMOReleased, released)
+	}
+	return released, nil
+}
+
+// AMOReleaser releases queued after-market orders at each session open
+type AMOReleaser struct {
+	service *OMSService
+	logger  *log.Logger
+}
+
+func NewAMOReleaser(logger *log.Logger, service *OMSService) *AMOReleaser {
+	return &AMOReleaser{service: service, logger: logger}
+}
+
+// Run blocks until ctx is cancelled, releasing queued orders whenever a segment opens
+func (r *AMOReleaser) Run(ctx context.Context) {
+	for {
+		var next time.Time
+		now := time.Now()
+		for _, segment := range r.service.calendar.Segments() {
+			open, err := r.service.calendar.NextOpen(segment, now)
+			if err != nil {
+				continue
+			}
+			if next.IsZero() || open.Before(next) {
+				next = open
+			}
+		}
+		if next.IsZero() {
+			r.logger.Printf("No upcoming session open, AMO release stopped")
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		released, err := r.service.As(audit.Source{Actor: audit.System("amo-release")}).ReleaseQueuedOrders()
+		if err != nil {
+			r.logger.Printf("Releasing after-market orders failed: %v", err)
+		}
+		if len(released) > 0 {
+			r.logger.Printf("Released %d after-market orders", len(released))
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Mukilan-T/laabhum-oms-go/audit"
+	"github.com/Mukilan-T/laabhum-oms-go/calendar"
+	"github.com/Mukilan-T/laabhum-oms-go/models"
+	"github.com/Mukilan-T/laabhum-oms-go/pkg/events"
+	"github.com/Mukilan-T/laabhum-oms-go/repository"
+)
+
+// SquareOffConfig controls when intraday positions are flattened
+type SquareOffConfig struct {
+	Cutoff      string        // time of day in IST, e.g. "15:15"
+	WarningLead time.Duration // how long before the cutoff to warn
+}
+
+// DefaultSquareOffConfig squares off at 15:15 IST with a five minute warning
+var DefaultSquareOffConfig = SquareOffConfig{
+	Cutoff:      "15:15",
+	WarningLead: 5 * time.Minute,
+}
+
+// SquareOffReport describes what an intraday square-off run did
+type SquareOffReport struct {
+	RanAt           time.Time `json:"ran_at"`
+	CancelledOrders []string  `json:"cancelled_orders"`
+	ClosedPositions []string  `json:"closed_positions"`
+	Errors          []string  `json:"errors,omitempty"`
+}
+
+// SquareOffIntraday cancels open MIS orders, pending or queued, and closes open MIS positions
+// with market orders. Failures on individual orders or positions are collected
+// in the report so one bad position doesn't stop the rest from closing.
+func (s *OMSService) SquareOffIntraday() (*SquareOffReport, error) {
+	s = s.because("intraday square-off")
+	report := &SquareOffReport{
+		RanAt:           time.Now().In(calendar.IST),
+		CancelledOrders: []string{},
+		ClosedPositions: []string{},
+	}
+
+	orders, err := s.repo.GetOrders(repository.OrderFilter{})
+	if err != nil {
+		return nil, err
+	}
+	for _, order := range orders {
+		if order.Product != models.ProductMIS || order.Status.IsTerminal() {
+			continue
+		}
+		if err := s.CancelOrder(order.ID); err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("cancel order %s: %v", order.ID, err))
+			continue
+		}
+		report.CancelledOrders = append(report.CancelledOrders, order.ID)
+	}
+
+	positions, err := s.repo.GetOpenPositions()
+	if err != nil {
+		return nil, err
+	}
+	for _, position := range positions {
+		if position.Product != models.ProductMIS {
+			continue
+		}
+		if err := s.ClosePosition(position.ID); err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("close position %s: %v", position.ID, err))
+			continue
+		}
+		report.ClosedPositions = append(report.ClosedPositions, position.ID)
+	}
+
+	s.events.Publish(events.SquareOffCompleted, report)
+	return report, nil
+}
+
+// SquareOffNotice warns subscribers that intraday positions are about to be closed
+type SquareOffNotice struct {
+	Cutoff      time.Time `json:"cutoff"`
+	SecondsLeft int       `json:"seconds_left"`
+}
+
+// SquareOffScheduler runs SquareOffIntraday at the configured cutoff on every trading day
+type SquareOffScheduler struct {
+	service *OMSService
+	logger  *log.Logger
+	cutoff  time.Duration // offset from midnight IST
+	lead    time.Duration
+	now     func() time.Time
+}
+
+func NewSquareOffScheduler(logger *log.Logger, service *OMSService, cfg SquareOffConfig) (*SquareOffScheduler, error) {
+	cutoff, err := parseTimeOfDay(cfg.Cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("invalid square-off cutoff: %w", err)
+	}
+	if cfg.WarningLead < 0 || cfg.WarningLead >= cutoff {
+		return nil, fmt.Errorf("invalid square-off warning lead %s", cfg.WarningLead)
+	}
+	return &SquareOffScheduler{
+		service: service,
+		logger:  logger,
+		cutoff:  cutoff,
+		lead:    cfg.WarningLead,
+		now:     time.Now,
+	}, nil
+}
+
+// parseTimeOfDay turns "HH:MM" into an offset from midnight
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// nextAt returns the first time strictly after now that is offset past midnight IST
+func nextAt(now time.Time, offset time.Duration) time.Time {
+	local := now.In(calendar.IST)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, calendar.IST)
+	next := midnight.Add(offset)
+	if !next.After(local) {
+		next = midnight.AddDate(0, 0, 1).Add(offset)
+	}
+	return next
+}
+
+// nextTradingAt returns the first time after now that is offset past
+// midnight IST on a day segment trades, failing when the calendar has no
+// trading day within a year
+func (s *OMSService) nextTradingAt(segment models.Segment, now time.Time, offset time.Duration) (time.Time, error) {
+	next := nextAt(now, offset)
+	if s.calendar.IsTradingDay(segment, next) {
+		return next, nil
+	}
+	open, err := s.calendar.NextOpen(segment, next)
+	if err != nil {
+		return time.Time{}, err
+	}
+	local := open.In(calendar.IST)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, calendar.IST).Add(offset), nil
+}
+
+// Run blocks until ctx is cancelled, warning ahead of and then squaring off
+// at each cutoff. It stops when the calendar has no trading day ahead.
+func (j *SquareOffScheduler) Run(ctx context.Context) {
+	after := j.now()
+	for {
+		cutoff, err := j.service.nextTradingAt(models.SegmentEquity, after, j.cutoff)
+		if err != nil {
+			j.logger.Printf("Intraday square-off stopped: %v", err)
+			return
+		}
+		after = cutoff
+		warnAt := cutoff.Add(-j.lead)
+
+		if j.lead > 0 && j.now().Before(warnAt) {
+			if !j.sleepUntil(ctx, warnAt) {
+				return
+			}
+			j.logger.Printf("Intraday square-off in %s at %s", j.lead, cutoff.Format("15:04 MST"))
+			j.service.events.Publish(events.SquareOffWarning, SquareOffNotice{Cutoff: cutoff, SecondsLeft: int(j.lead.Seconds())})
+		}
+
+		if !j.sleepUntil(ctx, cutoff) {
+			return
+		}
+		report, err := j.service.As(audit.Source{Actor: audit.System("squareoff")}).SquareOffIntraday()
+		if err != nil {
+			j.logger.Printf("Intraday square-off failed: %v", err)
+			continue
+		}
+		j.logger.Printf("Intraday square-off cancelled %d orders, closed %d positions, %d errors",
+			len(report.CancelledOrders), len(report.ClosedPositions), len(report.Errors))
+	}
+}
+
+// sleepUntil waits for t and reports false if ctx was cancelled first
+func (j *SquareOffScheduler) sleepUntil(ctx context.Context, t time.Time) bool {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}